@@ -0,0 +1,124 @@
+package vsphere
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/vmware/govmomi"
+	"github.com/vmware/govmomi/property"
+	"github.com/vmware/govmomi/vim25/methods"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+// alarmManagerReference returns the reference to the AlarmManager of the
+// connected vCenter. govmomi does not ship a higher-level object for this
+// manager.
+func alarmManagerReference(client *govmomi.Client) (types.ManagedObjectReference, error) {
+	if client.ServiceContent.AlarmManager == nil {
+		return types.ManagedObjectReference{}, fmt.Errorf("alarm manager is not available on this connection")
+	}
+	return *client.ServiceContent.AlarmManager, nil
+}
+
+// alarmFromID returns a reference to the alarm with the supplied managed
+// object ID.
+func alarmFromID(id string) types.ManagedObjectReference {
+	return types.ManagedObjectReference{
+		Type:  "Alarm",
+		Value: id,
+	}
+}
+
+// alarmProperties returns the properties of an alarm.
+func alarmProperties(client *govmomi.Client, alarm types.ManagedObjectReference) (*mo.Alarm, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	var props mo.Alarm
+	pc := property.DefaultCollector(client.Client)
+	if err := pc.RetrieveOne(ctx, alarm, []string{"info"}, &props); err != nil {
+		return nil, err
+	}
+	return &props, nil
+}
+
+// alarmsForEntity returns the alarms defined directly on an entity.
+func alarmsForEntity(client *govmomi.Client, entity types.ManagedObjectReference) ([]types.ManagedObjectReference, error) {
+	ref, err := alarmManagerReference(client)
+	if err != nil {
+		return nil, err
+	}
+	req := types.GetAlarm{
+		This:   ref,
+		Entity: &entity,
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	res, err := methods.GetAlarm(ctx, client.Client, &req)
+	if err != nil {
+		return nil, err
+	}
+	return res.Returnval, nil
+}
+
+// alarmFromName locates an alarm defined directly on an entity by its name.
+// An error is returned if the alarm cannot be found.
+func alarmFromName(client *govmomi.Client, entity types.ManagedObjectReference, name string) (*mo.Alarm, error) {
+	alarms, err := alarmsForEntity(client, entity)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching alarms for %s %q: %s", entity.Type, entity.Value, err)
+	}
+	for _, alarm := range alarms {
+		props, err := alarmProperties(client, alarm)
+		if err != nil {
+			return nil, fmt.Errorf("error fetching alarm properties: %s", err)
+		}
+		if props.Info.Name == name {
+			return props, nil
+		}
+	}
+	return nil, fmt.Errorf("alarm %q not found on %s %q", name, entity.Type, entity.Value)
+}
+
+// createAlarm creates an alarm on an entity and returns its reference.
+func createAlarm(client *govmomi.Client, entity types.ManagedObjectReference, spec types.AlarmSpec) (types.ManagedObjectReference, error) {
+	ref, err := alarmManagerReference(client)
+	if err != nil {
+		return types.ManagedObjectReference{}, err
+	}
+	req := types.CreateAlarm{
+		This:   ref,
+		Entity: entity,
+		Spec:   &spec,
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	res, err := methods.CreateAlarm(ctx, client.Client, &req)
+	if err != nil {
+		return types.ManagedObjectReference{}, err
+	}
+	return res.Returnval, nil
+}
+
+// reconfigureAlarm replaces the definition of an alarm.
+func reconfigureAlarm(client *govmomi.Client, alarm types.ManagedObjectReference, spec types.AlarmSpec) error {
+	req := types.ReconfigureAlarm{
+		This: alarm,
+		Spec: &spec,
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	_, err := methods.ReconfigureAlarm(ctx, client.Client, &req)
+	return err
+}
+
+// removeAlarm removes an alarm.
+func removeAlarm(client *govmomi.Client, alarm types.ManagedObjectReference) error {
+	req := types.RemoveAlarm{
+		This: alarm,
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	_, err := methods.RemoveAlarm(ctx, client.Client, &req)
+	return err
+}
@@ -0,0 +1,175 @@
+package vsphere
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/vmware/govmomi"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/property"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+// authorizationManager returns the AuthorizationManager for the connected
+// vCenter or ESXi host.
+func authorizationManager(client *govmomi.Client) *object.AuthorizationManager {
+	return object.NewAuthorizationManager(client.Client)
+}
+
+// authorizationRoleFromName locates a role by its name. An error is returned
+// if the role cannot be found.
+func authorizationRoleFromName(client *govmomi.Client, name string) (*types.AuthorizationRole, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	roles, err := authorizationManager(client).RoleList(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching role list: %s", err)
+	}
+	role := roles.ByName(name)
+	if role == nil {
+		return nil, fmt.Errorf("role %q not found", name)
+	}
+	return role, nil
+}
+
+// authorizationRoleNotFoundError is an error type that is returned when a
+// role could not be found by ID.
+type authorizationRoleNotFoundError struct {
+	id int32
+}
+
+// Error implements error for authorizationRoleNotFoundError.
+func (e *authorizationRoleNotFoundError) Error() string {
+	return fmt.Sprintf("role ID %d not found", e.id)
+}
+
+// isAuthorizationRoleNotFoundError returns true if the error is an
+// authorizationRoleNotFoundError.
+func isAuthorizationRoleNotFoundError(err error) bool {
+	_, ok := err.(*authorizationRoleNotFoundError)
+	return ok
+}
+
+// authorizationRoleFromID locates a role by its ID. An
+// authorizationRoleNotFoundError is returned if the role cannot be found.
+func authorizationRoleFromID(client *govmomi.Client, id int32) (*types.AuthorizationRole, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	roles, err := authorizationManager(client).RoleList(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching role list: %s", err)
+	}
+	role := roles.ById(id)
+	if role == nil {
+		return nil, &authorizationRoleNotFoundError{id: id}
+	}
+	return role, nil
+}
+
+// entityPermissionForPrincipal returns the permission defined directly on an
+// entity for a specific principal, or nil if there is none.
+func entityPermissionForPrincipal(client *govmomi.Client, entity types.ManagedObjectReference, principal string, group bool) (*types.Permission, error) {
+	perms, err := entityPermissions(client, entity)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range perms {
+		if p.Principal == principal && p.Group == group {
+			return &p, nil
+		}
+	}
+	return nil, nil
+}
+
+// setEntityPermission adds or replaces a single permission on an entity.
+func setEntityPermission(client *govmomi.Client, entity types.ManagedObjectReference, perm types.Permission) error {
+	return setEntityPermissions(client, entity, []types.Permission{perm})
+}
+
+// removeEntityPermission removes the permission for a principal from an
+// entity.
+func removeEntityPermission(client *govmomi.Client, entity types.ManagedObjectReference, principal string, group bool) error {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	return authorizationManager(client).RemoveEntityPermission(ctx, entity, principal, group)
+}
+
+// authorizationPrivilegeIDs returns the IDs of all privileges known to the
+// AuthorizationManager, keyed by privilege ID.
+func authorizationPrivilegeIDs(client *govmomi.Client) (map[string]bool, error) {
+	m := authorizationManager(client)
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	var props mo.AuthorizationManager
+	pc := property.DefaultCollector(client.Client)
+	if err := pc.RetrieveOne(ctx, m.Reference(), []string{"privilegeList"}, &props); err != nil {
+		return nil, fmt.Errorf("error fetching privilege list: %s", err)
+	}
+	ids := make(map[string]bool)
+	for _, p := range props.PrivilegeList {
+		ids[p.PrivId] = true
+	}
+	return ids, nil
+}
+
+// validateAuthorizationPrivileges checks the supplied privilege IDs against
+// the privilege catalog of the AuthorizationManager, and returns an error
+// listing any that are unknown.
+func validateAuthorizationPrivileges(client *govmomi.Client, privileges []string) error {
+	known, err := authorizationPrivilegeIDs(client)
+	if err != nil {
+		return err
+	}
+	var unknown []string
+	for _, p := range privileges {
+		if !known[p] {
+			unknown = append(unknown, p)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return fmt.Errorf("unknown privileges: %s", strings.Join(unknown, ", "))
+	}
+	return nil
+}
+
+// addAuthorizationRole creates a new role with the supplied privileges and
+// returns its ID.
+func addAuthorizationRole(client *govmomi.Client, name string, privileges []string) (int32, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	return authorizationManager(client).AddRole(ctx, name, privileges)
+}
+
+// updateAuthorizationRole renames a role and replaces its privileges.
+func updateAuthorizationRole(client *govmomi.Client, id int32, name string, privileges []string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	return authorizationManager(client).UpdateRole(ctx, id, name, privileges)
+}
+
+// removeAuthorizationRole removes a role. The operation fails if the role is
+// still used in any permission.
+func removeAuthorizationRole(client *govmomi.Client, id int32) error {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	return authorizationManager(client).RemoveRole(ctx, id, true)
+}
+
+// entityPermissions returns the permissions defined directly on an entity.
+// Inherited permissions are not included.
+func entityPermissions(client *govmomi.Client, entity types.ManagedObjectReference) ([]types.Permission, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	return authorizationManager(client).RetrieveEntityPermissions(ctx, entity, false)
+}
+
+// setEntityPermissions adds or replaces a set of permissions on an entity.
+func setEntityPermissions(client *govmomi.Client, entity types.ManagedObjectReference, perms []types.Permission) error {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	return authorizationManager(client).SetEntityPermissions(ctx, entity, perms)
+}
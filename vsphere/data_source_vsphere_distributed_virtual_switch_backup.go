@@ -0,0 +1,89 @@
+package vsphere
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/structure"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/viapi"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+func dataSourceVSphereDistributedVirtualSwitchBackup() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceVSphereDistributedVirtualSwitchBackupRead,
+
+		Schema: map[string]*schema.Schema{
+			"distributed_virtual_switch_uuid": {
+				Type:         schema.TypeString,
+				Description:  "The UUID of the DVS to back up.",
+				Required:     true,
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"output_path": {
+				Type:         schema.TypeString,
+				Description:  "The path of the local file to write the backup to.",
+				Required:     true,
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"include_portgroups": {
+				Type:        schema.TypeBool,
+				Description: "Whether or not to include port groups in the backup.",
+				Optional:    true,
+				Default:     true,
+			},
+			"portgroup_keys": {
+				Type:        schema.TypeList,
+				Description: "The keys of the port groups to include in the backup. Defaults to all port groups on the DVS, except uplink port groups.",
+				Optional:    true,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func dataSourceVSphereDistributedVirtualSwitchBackupRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*VSphereClient).vimClient
+	if err := viapi.ValidateVirtualCenter(client); err != nil {
+		return err
+	}
+	id := d.Get("distributed_virtual_switch_uuid").(string)
+	dvs, err := dvsFromUUID(client, id)
+	if err != nil {
+		return fmt.Errorf("could not find DVS %q: %s", id, err)
+	}
+
+	selection := []types.BaseSelectionSet{
+		&types.DVSSelection{DvsUuid: id},
+	}
+	var keys []string
+	if d.Get("include_portgroups").(bool) {
+		keys = structure.SliceInterfacesToStrings(d.Get("portgroup_keys").([]interface{}))
+		if len(keys) < 1 {
+			keys, err = dvsPortgroupKeys(client, dvs)
+			if err != nil {
+				return fmt.Errorf("error fetching port groups for DVS %q: %s", id, err)
+			}
+		}
+		if len(keys) > 0 {
+			selection = append(selection, &types.DVPortgroupSelection{
+				DvsUuid:      id,
+				PortgroupKey: keys,
+			})
+		}
+	}
+
+	backup, err := exportDVSEntities(client, selection)
+	if err != nil {
+		return fmt.Errorf("error exporting DVS %q: %s", id, err)
+	}
+	path := d.Get("output_path").(string)
+	if err := writeDVSBackupFile(path, backup); err != nil {
+		return fmt.Errorf("error writing backup file %q: %s", path, err)
+	}
+
+	d.SetId(id)
+	return d.Set("portgroup_keys", keys)
+}
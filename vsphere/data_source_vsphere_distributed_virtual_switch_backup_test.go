@@ -0,0 +1,80 @@
+package vsphere
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccDataSourceVSphereDistributedVirtualSwitchBackup_basic(t *testing.T) {
+	path := filepath.Join(os.TempDir(), "terraform-test-dvs-backup.json")
+	defer os.Remove(path)
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceVSphereDistributedVirtualSwitchBackupConfig(path),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.vsphere_distributed_virtual_switch_backup.backup", "portgroup_keys.#", "1"),
+					resource.TestCheckResourceAttrPair(
+						"data.vsphere_distributed_virtual_switch_backup.backup", "portgroup_keys.0",
+						"vsphere_distributed_port_group.pg", "key",
+					),
+					testAccDataSourceVSphereDistributedVirtualSwitchBackupHasEntities(path, 2),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceVSphereDistributedVirtualSwitchBackupHasEntities(path string, expected int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		backup, err := readDVSBackupFile(path)
+		if err != nil {
+			return err
+		}
+		if len(backup) != expected {
+			return fmt.Errorf("expected %d entities in backup, got %d", expected, len(backup))
+		}
+		return nil
+	}
+}
+
+func testAccDataSourceVSphereDistributedVirtualSwitchBackupConfig(path string) string {
+	return fmt.Sprintf(`
+variable "datacenter" {
+  default = "%s"
+}
+
+data "vsphere_datacenter" "dc" {
+  name = "${var.datacenter}"
+}
+
+resource "vsphere_distributed_virtual_switch" "dvs" {
+  name          = "terraform-test-dvs"
+  datacenter_id = "${data.vsphere_datacenter.dc.id}"
+}
+
+resource "vsphere_distributed_port_group" "pg" {
+  name                            = "terraform-test-pg"
+  distributed_virtual_switch_uuid = "${vsphere_distributed_virtual_switch.dvs.id}"
+}
+
+data "vsphere_distributed_virtual_switch_backup" "backup" {
+  distributed_virtual_switch_uuid = "${vsphere_distributed_virtual_switch.dvs.id}"
+  output_path                     = "%s"
+
+  depends_on = ["vsphere_distributed_port_group.pg"]
+}
+`,
+		os.Getenv("VSPHERE_DATACENTER"),
+		path,
+	)
+}
@@ -0,0 +1,186 @@
+package vsphere
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/viapi"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+func dataSourceVSphereDistributedVirtualSwitchHealthCheck() *schema.Resource {
+	vlanRange := func(description string) *schema.Schema {
+		return &schema.Schema{
+			Type:        schema.TypeList,
+			Description: description,
+			Computed:    true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"min_vlan": {
+						Type:        schema.TypeInt,
+						Description: "The minimum VLAN in the range.",
+						Computed:    true,
+					},
+					"max_vlan": {
+						Type:        schema.TypeInt,
+						Description: "The maximum VLAN in the range.",
+						Computed:    true,
+					},
+				},
+			},
+		}
+	}
+
+	return &schema.Resource{
+		Read: dataSourceVSphereDistributedVirtualSwitchHealthCheckRead,
+
+		Schema: map[string]*schema.Schema{
+			"distributed_virtual_switch_uuid": {
+				Type:         schema.TypeString,
+				Description:  "The UUID of the DVS to read the health check results for.",
+				Required:     true,
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"host": {
+				Type:        schema.TypeList,
+				Description: "The health check results for each host that is a member of the DVS.",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"host_system_id": {
+							Type:        schema.TypeString,
+							Description: "The managed object ID of the host.",
+							Computed:    true,
+						},
+						"status": {
+							Type:        schema.TypeString,
+							Description: "The status of the host as a member of the DVS.",
+							Computed:    true,
+						},
+						"status_detail": {
+							Type:        schema.TypeString,
+							Description: "Additional detail on the status of the host, if any.",
+							Computed:    true,
+						},
+						"teaming_status": {
+							Type:        schema.TypeString,
+							Description: "The result of the teaming and failover health check. Can be one of normal, mismatch, or unknown, or empty if the check is not enabled.",
+							Computed:    true,
+						},
+						"uplink": {
+							Type:        schema.TypeList,
+							Description: "The VLAN and MTU health check results for each uplink of the host.",
+							Computed:    true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"name": {
+										Type:        schema.TypeString,
+										Description: "The name of the uplink.",
+										Computed:    true,
+									},
+									"mtu_mismatch": {
+										Type:        schema.TypeBool,
+										Description: "Whether or not the MTU of the DVS does not match the MTU of the physical switch.",
+										Computed:    true,
+									},
+									"trunked_vlan":           vlanRange("The VLANs that are trunked on the physical switch."),
+									"untrunked_vlan":         vlanRange("The VLANs that are not trunked on the physical switch."),
+									"mtu_supported_vlan":     vlanRange("The VLANs that support the MTU of the DVS on the physical switch."),
+									"mtu_not_supported_vlan": vlanRange("The VLANs that do not support the MTU of the DVS on the physical switch."),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceVSphereDistributedVirtualSwitchHealthCheckRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*VSphereClient).vimClient
+	if err := viapi.ValidateVirtualCenter(client); err != nil {
+		return err
+	}
+	id := d.Get("distributed_virtual_switch_uuid").(string)
+	dvs, err := dvsFromUUID(client, id)
+	if err != nil {
+		return fmt.Errorf("could not find DVS %q: %s", id, err)
+	}
+	props, err := dvsProperties(dvs)
+	if err != nil {
+		return fmt.Errorf("error fetching DVS properties: %s", err)
+	}
+	uplinkNames, err := dvsUplinkPortNames(dvs)
+	if err != nil {
+		return fmt.Errorf("error fetching DVS uplink ports: %s", err)
+	}
+
+	var hosts []map[string]interface{}
+	if props.Runtime != nil {
+		for _, rt := range props.Runtime.HostMemberRuntime {
+			hosts = append(hosts, flattenHostMemberRuntimeInfoHealthCheck(rt, uplinkNames))
+		}
+	}
+	if err := d.Set("host", hosts); err != nil {
+		return err
+	}
+	d.SetId(id)
+	return nil
+}
+
+// flattenHostMemberRuntimeInfoHealthCheck flattens the health check results
+// in a HostMemberRuntimeInfo. uplinkNames maps the keys of the uplink ports on
+// the DVS to their names.
+func flattenHostMemberRuntimeInfoHealthCheck(obj types.HostMemberRuntimeInfo, uplinkNames map[string]string) map[string]interface{} {
+	d := map[string]interface{}{
+		"host_system_id": obj.Host.Value,
+		"status":         obj.Status,
+		"status_detail":  obj.StatusDetail,
+	}
+
+	uplinks := make(map[string]map[string]interface{})
+	var keys []string
+	uplink := func(key string) map[string]interface{} {
+		if _, ok := uplinks[key]; !ok {
+			uplinks[key] = map[string]interface{}{"name": uplinkNames[key]}
+			keys = append(keys, key)
+		}
+		return uplinks[key]
+	}
+	for _, result := range obj.HealthCheckResult {
+		switch r := result.(type) {
+		case *types.VMwareDVSTeamingHealthCheckResult:
+			d["teaming_status"] = r.TeamingStatus
+		case *types.VMwareDVSVlanHealthCheckResult:
+			u := uplink(r.UplinkPortKey)
+			u["trunked_vlan"] = flattenSliceOfNumericRangeVlans(r.TrunkedVlan)
+			u["untrunked_vlan"] = flattenSliceOfNumericRangeVlans(r.UntrunkedVlan)
+		case *types.VMwareDVSMtuHealthCheckResult:
+			u := uplink(r.UplinkPortKey)
+			u["mtu_mismatch"] = r.MtuMismatch
+			u["mtu_supported_vlan"] = flattenSliceOfNumericRangeVlans(r.VlanSupportSwitchMtu)
+			u["mtu_not_supported_vlan"] = flattenSliceOfNumericRangeVlans(r.VlanNotSupportSwitchMtu)
+		}
+	}
+	var ul []interface{}
+	for _, key := range keys {
+		ul = append(ul, uplinks[key])
+	}
+	d["uplink"] = ul
+	return d
+}
+
+// flattenSliceOfNumericRangeVlans flattens a list of VLAN ranges into the
+// min_vlan and max_vlan format used by the vlan_range attribute.
+func flattenSliceOfNumericRangeVlans(objs []types.NumericRange) []interface{} {
+	var ranges []interface{}
+	for _, obj := range objs {
+		ranges = append(ranges, map[string]interface{}{
+			"min_vlan": obj.Start,
+			"max_vlan": obj.End,
+		})
+	}
+	return ranges
+}
@@ -0,0 +1,78 @@
+package vsphere
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccDataSourceVSphereDistributedVirtualSwitchHealthCheck_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccResourceVSphereDistributedVirtualSwitchPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceVSphereDistributedVirtualSwitchHealthCheckConfig(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.vsphere_distributed_virtual_switch_health_check.health", "host.#", "1"),
+					resource.TestCheckResourceAttrPair(
+						"data.vsphere_distributed_virtual_switch_health_check.health", "host.0.host_system_id",
+						"data.vsphere_host.host", "id",
+					),
+					resource.TestCheckResourceAttrSet("data.vsphere_distributed_virtual_switch_health_check.health", "host.0.status"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceVSphereDistributedVirtualSwitchHealthCheckConfig() string {
+	return fmt.Sprintf(`
+variable "datacenter" {
+  default = "%s"
+}
+
+variable "esxi_host" {
+  default = "%s"
+}
+
+variable "network_interface" {
+  default = "%s"
+}
+
+data "vsphere_datacenter" "dc" {
+  name = "${var.datacenter}"
+}
+
+data "vsphere_host" "host" {
+  name          = "${var.esxi_host}"
+  datacenter_id = "${data.vsphere_datacenter.dc.id}"
+}
+
+resource "vsphere_distributed_virtual_switch" "dvs" {
+  name          = "terraform-test-dvs"
+  datacenter_id = "${data.vsphere_datacenter.dc.id}"
+
+  vlan_mtu_health_check_enabled = true
+  teaming_health_check_enabled  = true
+
+  host {
+    host_system_id = "${data.vsphere_host.host.id}"
+    devices        = ["${var.network_interface}"]
+  }
+}
+
+data "vsphere_distributed_virtual_switch_health_check" "health" {
+  distributed_virtual_switch_uuid = "${vsphere_distributed_virtual_switch.dvs.id}"
+}
+`,
+		os.Getenv("VSPHERE_DATACENTER"),
+		os.Getenv("VSPHERE_ESXI_HOST"),
+		os.Getenv("VSPHERE_HOST_NIC0"),
+	)
+}
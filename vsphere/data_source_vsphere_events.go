@@ -0,0 +1,163 @@
+package vsphere
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/structure"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+var eventRecursionAllowedValues = []string{
+	string(types.EventFilterSpecRecursionOptionSelf),
+	string(types.EventFilterSpecRecursionOptionChildren),
+	string(types.EventFilterSpecRecursionOptionAll),
+}
+
+func dataSourceVSphereEvents() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceVSphereEventsRead,
+
+		Schema: map[string]*schema.Schema{
+			"entity_id": {
+				Type:        schema.TypeString,
+				Description: "The managed object ID of the entity to list events for.",
+				Required:    true,
+			},
+			"entity_type": {
+				Type:        schema.TypeString,
+				Description: "The managed object type of the entity, such as VirtualMachine, HostSystem, or Datacenter.",
+				Required:    true,
+			},
+			"recursion": {
+				Type:         schema.TypeString,
+				Description:  "Which events to list in relation to the entity. Can be one of self, children, or all.",
+				Optional:     true,
+				Default:      string(types.EventFilterSpecRecursionOptionSelf),
+				ValidateFunc: validation.StringInSlice(eventRecursionAllowedValues, false),
+			},
+			"begin_time": {
+				Type:         schema.TypeString,
+				Description:  "Only list events logged at or after this time, in RFC3339 format.",
+				Optional:     true,
+				ValidateFunc: validation.ValidateRFC3339TimeString,
+			},
+			"end_time": {
+				Type:         schema.TypeString,
+				Description:  "Only list events logged at or before this time, in RFC3339 format.",
+				Optional:     true,
+				ValidateFunc: validation.ValidateRFC3339TimeString,
+			},
+			"event_types": {
+				Type:        schema.TypeList,
+				Description: "Only list events of these types, such as VmReconfiguredEvent or esx.problem.vmfs.heartbeat.timedout.",
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"user_names": {
+				Type:        schema.TypeList,
+				Description: "Only list events logged by these users.",
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"max_events": {
+				Type:         schema.TypeInt,
+				Description:  "The maximum number of events to list.",
+				Optional:     true,
+				Default:      1000,
+				ValidateFunc: validation.IntBetween(1, 1000),
+			},
+			"events": {
+				Type:        schema.TypeList,
+				Description: "The events found, oldest first.",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"key": {
+							Type:        schema.TypeInt,
+							Description: "The unique key of the event.",
+							Computed:    true,
+						},
+						"chain_id": {
+							Type:        schema.TypeInt,
+							Description: "The key of the first event in the chain of related events.",
+							Computed:    true,
+						},
+						"type": {
+							Type:        schema.TypeString,
+							Description: "The type of the event.",
+							Computed:    true,
+						},
+						"user_name": {
+							Type:        schema.TypeString,
+							Description: "The user that caused the event.",
+							Computed:    true,
+						},
+						"message": {
+							Type:        schema.TypeString,
+							Description: "The formatted message of the event.",
+							Computed:    true,
+						},
+						"created_time": {
+							Type:        schema.TypeString,
+							Description: "The time the event was logged, in RFC3339 format.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceVSphereEventsRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*VSphereClient).vimClient
+	filter := types.EventFilterSpec{
+		Entity: &types.EventFilterSpecByEntity{
+			Entity: types.ManagedObjectReference{
+				Type:  d.Get("entity_type").(string),
+				Value: d.Get("entity_id").(string),
+			},
+			Recursion: types.EventFilterSpecRecursionOption(d.Get("recursion").(string)),
+		},
+		EventTypeId: structure.SliceInterfacesToStrings(d.Get("event_types").([]interface{})),
+		MaxCount:    int32(d.Get("max_events").(int)),
+	}
+	begin := structure.ParseTimePtr(d.Get("begin_time").(string))
+	end := structure.ParseTimePtr(d.Get("end_time").(string))
+	if begin != nil || end != nil {
+		filter.Time = &types.EventFilterSpecByTime{
+			BeginTime: begin,
+			EndTime:   end,
+		}
+	}
+	if users := structure.SliceInterfacesToStrings(d.Get("user_names").([]interface{})); len(users) > 0 {
+		filter.UserName = &types.EventFilterSpecByUsername{
+			UserList: users,
+		}
+	}
+
+	events, err := selectEventsForFilter(client, filter)
+	if err != nil {
+		return fmt.Errorf("error querying events for %s %q: %s", filter.Entity.Entity.Type, filter.Entity.Entity.Value, err)
+	}
+	var result []interface{}
+	for _, be := range events {
+		e := be.GetEvent()
+		result = append(result, map[string]interface{}{
+			"key":          int(e.Key),
+			"chain_id":     int(e.ChainId),
+			"type":         eventTypeID(be),
+			"user_name":    e.UserName,
+			"message":      e.FullFormattedMessage,
+			"created_time": e.CreatedTime.UTC().Format(time.RFC3339),
+		})
+	}
+	if err := d.Set("events", result); err != nil {
+		return fmt.Errorf("error setting events: %s", err)
+	}
+	d.SetId(time.Now().UTC().String())
+	return nil
+}
@@ -0,0 +1,73 @@
+package vsphere
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccDataSourceVSphereEvents_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccDataSourceVSphereEventsPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceVSphereEventsConfig(""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr("data.vsphere_events.events", "events.#", regexp.MustCompile("^([1-9]|10)$")),
+					resource.TestMatchResourceAttr("data.vsphere_events.events", "events.0.type", regexp.MustCompile(".+")),
+					resource.TestMatchResourceAttr("data.vsphere_events.events", "events.0.created_time", regexp.MustCompile("^[0-9]{4}-")),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceVSphereEvents_typeFilter(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccDataSourceVSphereEventsPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceVSphereEventsConfig(`event_types = ["terraform.test.nonexistent"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.vsphere_events.events", "events.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceVSphereEventsPreCheck(t *testing.T) {
+	if os.Getenv("VSPHERE_DATACENTER") == "" {
+		t.Skip("set VSPHERE_DATACENTER to run vsphere_events acceptance tests")
+	}
+}
+
+func testAccDataSourceVSphereEventsConfig(extra string) string {
+	return fmt.Sprintf(`
+data "vsphere_datacenter" "dc" {
+  name = "%s"
+}
+
+data "vsphere_events" "events" {
+  entity_id   = "${data.vsphere_datacenter.dc.id}"
+  entity_type = "Datacenter"
+  recursion   = "all"
+  max_events  = 10
+  %s
+}
+`,
+		os.Getenv("VSPHERE_DATACENTER"),
+		extra,
+	)
+}
@@ -0,0 +1,179 @@
+package vsphere
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+func dataSourceVSphereHostFirewall() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceVSphereHostFirewallRead,
+
+		Schema: map[string]*schema.Schema{
+			"host_system_id": {
+				Type:        schema.TypeString,
+				Description: "The managed object ID of the host to read the firewall configuration from.",
+				Required:    true,
+			},
+			"default_incoming_blocked": {
+				Type:        schema.TypeBool,
+				Description: "Whether or not incoming traffic is blocked by default.",
+				Computed:    true,
+			},
+			"default_outgoing_blocked": {
+				Type:        schema.TypeBool,
+				Description: "Whether or not outgoing traffic is blocked by default.",
+				Computed:    true,
+			},
+			"ruleset": {
+				Type:        schema.TypeList,
+				Description: "The firewall rulesets configured on the host.",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"key": {
+							Type:        schema.TypeString,
+							Description: "The key of the ruleset.",
+							Computed:    true,
+						},
+						"label": {
+							Type:        schema.TypeString,
+							Description: "The display label of the ruleset.",
+							Computed:    true,
+						},
+						"service": {
+							Type:        schema.TypeString,
+							Description: "The service the ruleset belongs to, if any.",
+							Computed:    true,
+						},
+						"enabled": {
+							Type:        schema.TypeBool,
+							Description: "Whether or not the ruleset is enabled.",
+							Computed:    true,
+						},
+						"required": {
+							Type:        schema.TypeBool,
+							Description: "Whether or not the ruleset is required by the host.",
+							Computed:    true,
+						},
+						"allow_all_ip": {
+							Type:        schema.TypeBool,
+							Description: "Whether or not connections are allowed from all IP addresses.",
+							Computed:    true,
+						},
+						"allowed_ip_addresses": {
+							Type:        schema.TypeList,
+							Description: "The IP addresses that are allowed to connect to this ruleset.",
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"allowed_ip_networks": {
+							Type:        schema.TypeList,
+							Description: "The networks, in CIDR notation, that are allowed to connect to this ruleset.",
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"rule": {
+							Type:        schema.TypeList,
+							Description: "The port rules that make up this ruleset.",
+							Computed:    true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"port": {
+										Type:        schema.TypeInt,
+										Description: "The port number, or the start of the port range.",
+										Computed:    true,
+									},
+									"end_port": {
+										Type:        schema.TypeInt,
+										Description: "The end of the port range, if this rule covers a range.",
+										Computed:    true,
+									},
+									"direction": {
+										Type:        schema.TypeString,
+										Description: "The traffic direction of the rule. Can be one of inbound or outbound.",
+										Computed:    true,
+									},
+									"port_type": {
+										Type:        schema.TypeString,
+										Description: "Whether the port is the source or destination port. Can be one of src or dst.",
+										Computed:    true,
+									},
+									"protocol": {
+										Type:        schema.TypeString,
+										Description: "The protocol of the rule, such as tcp or udp.",
+										Computed:    true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceVSphereHostFirewallRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*VSphereClient).vimClient
+	hsID := d.Get("host_system_id").(string)
+	fs, err := hostFirewallSystemFromHostSystemID(client, hsID)
+	if err != nil {
+		return fmt.Errorf("error loading host firewall system: %s", err)
+	}
+	info, err := hostFirewallInfo(fs)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(hsID)
+
+	if info.DefaultPolicy.IncomingBlocked != nil {
+		d.Set("default_incoming_blocked", *info.DefaultPolicy.IncomingBlocked)
+	}
+	if info.DefaultPolicy.OutgoingBlocked != nil {
+		d.Set("default_outgoing_blocked", *info.DefaultPolicy.OutgoingBlocked)
+	}
+	if err := d.Set("ruleset", flattenHostFirewallRulesets(info.Ruleset)); err != nil {
+		return fmt.Errorf("error saving results to state: %s", err)
+	}
+
+	return nil
+}
+
+// flattenHostFirewallRulesets converts a list of HostFirewallRuleset into
+// the structure used by the ruleset attribute of the vsphere_host_firewall
+// data source.
+func flattenHostFirewallRulesets(rulesets []types.HostFirewallRuleset) []interface{} {
+	var result []interface{}
+	for _, rs := range rulesets {
+		allowed := rs.AllowedHosts
+		if allowed == nil {
+			allowed = &types.HostFirewallRulesetIpList{AllIp: true}
+		}
+		var rules []interface{}
+		for _, r := range rs.Rule {
+			rules = append(rules, map[string]interface{}{
+				"port":      r.Port,
+				"end_port":  r.EndPort,
+				"direction": string(r.Direction),
+				"port_type": string(r.PortType),
+				"protocol":  r.Protocol,
+			})
+		}
+		result = append(result, map[string]interface{}{
+			"key":                  rs.Key,
+			"label":                rs.Label,
+			"service":              rs.Service,
+			"enabled":              rs.Enabled,
+			"required":             rs.Required,
+			"allow_all_ip":         allowed.AllIp,
+			"allowed_ip_addresses": allowed.IpAddress,
+			"allowed_ip_networks":  flattenHostFirewallRulesetIPNetworks(allowed.IpNetwork),
+			"rule":                 rules,
+		})
+	}
+	return result
+}
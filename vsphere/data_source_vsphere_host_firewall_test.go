@@ -0,0 +1,62 @@
+package vsphere
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccDataSourceVSphereHostFirewall_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccDataSourceVSphereHostFirewallPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceVSphereHostFirewallConfig(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr(
+						"data.vsphere_host_firewall.firewall",
+						"ruleset.#",
+						regexp.MustCompile("^[1-9][0-9]*$"),
+					),
+					resource.TestCheckResourceAttrPair(
+						"data.vsphere_host_firewall.firewall", "id",
+						"data.vsphere_host.esxi_host", "id",
+					),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceVSphereHostFirewallPreCheck(t *testing.T) {
+	if os.Getenv("VSPHERE_DATACENTER") == "" {
+		t.Skip("set VSPHERE_DATACENTER to run vsphere_host_firewall acceptance tests")
+	}
+	if os.Getenv("VSPHERE_ESXI_HOST") == "" {
+		t.Skip("set VSPHERE_ESXI_HOST to run vsphere_host_firewall acceptance tests")
+	}
+}
+
+func testAccDataSourceVSphereHostFirewallConfig() string {
+	return fmt.Sprintf(`
+data "vsphere_datacenter" "datacenter" {
+  name = "%s"
+}
+
+data "vsphere_host" "esxi_host" {
+  name          = "%s"
+  datacenter_id = "${data.vsphere_datacenter.datacenter.id}"
+}
+
+data "vsphere_host_firewall" "firewall" {
+  host_system_id = "${data.vsphere_host.esxi_host.id}"
+}
+`, os.Getenv("VSPHERE_DATACENTER"), os.Getenv("VSPHERE_ESXI_HOST"))
+}
@@ -0,0 +1,153 @@
+package vsphere
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+func dataSourceVSphereHostMultipath() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceVSphereHostMultipathRead,
+
+		Schema: map[string]*schema.Schema{
+			"host_system_id": {
+				Type:        schema.TypeString,
+				Description: "The managed object ID of the host to read multipath information from.",
+				Required:    true,
+			},
+			"filter": {
+				Type:         schema.TypeString,
+				Description:  "A regular expression to filter the LUNs against. Only LUNs with canonical names that match will be included.",
+				Optional:     true,
+				ValidateFunc: validation.ValidateRegexp,
+			},
+			"lun": {
+				Type:        schema.TypeList,
+				Description: "The multipathed LUNs found on the host, sorted by canonical name.",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"canonical_name": {
+							Type:        schema.TypeString,
+							Description: "The canonical name of the LUN.",
+							Computed:    true,
+						},
+						"policy": {
+							Type:        schema.TypeString,
+							Description: "The path selection policy of the LUN.",
+							Computed:    true,
+						},
+						"preferred_path": {
+							Type:        schema.TypeString,
+							Description: "The preferred path of the LUN, when using the VMW_PSP_FIXED policy.",
+							Computed:    true,
+						},
+						"storage_array_type_policy": {
+							Type:        schema.TypeString,
+							Description: "The storage array type policy (SATP) that has claimed the LUN.",
+							Computed:    true,
+						},
+						"path": {
+							Type:        schema.TypeList,
+							Description: "The paths to the LUN.",
+							Computed:    true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"name": {
+										Type:        schema.TypeString,
+										Description: "The name of the path.",
+										Computed:    true,
+									},
+									"adapter": {
+										Type:        schema.TypeString,
+										Description: "The key of the host bus adapter the path goes through.",
+										Computed:    true,
+									},
+									"state": {
+										Type:        schema.TypeString,
+										Description: "The state of the path. Can be one of active, standby, disabled, dead, or unknown.",
+										Computed:    true,
+									},
+									"is_working_path": {
+										Type:        schema.TypeBool,
+										Description: "Whether or not the path is currently being used for I/O.",
+										Computed:    true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceVSphereHostMultipathRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*VSphereClient).vimClient
+	hsID := d.Get("host_system_id").(string)
+	ss, err := hostStorageSystemFromHostSystemID(client, hsID)
+	if err != nil {
+		return fmt.Errorf("error loading host storage system: %s", err)
+	}
+	units, err := hostMultipathLogicalUnits(ss)
+	if err != nil {
+		return err
+	}
+
+	re, err := regexp.Compile(d.Get("filter").(string))
+	if err != nil {
+		return err
+	}
+
+	var matched []hostMultipathLogicalUnit
+	for _, lu := range units {
+		if re.MatchString(lu.CanonicalName) {
+			matched = append(matched, lu)
+		}
+	}
+	sort.Slice(matched, func(i, j int) bool { return matched[i].CanonicalName < matched[j].CanonicalName })
+
+	d.SetId(hsID)
+
+	if err := d.Set("lun", flattenHostMultipathLogicalUnits(matched)); err != nil {
+		return fmt.Errorf("error saving results to state: %s", err)
+	}
+
+	return nil
+}
+
+// flattenHostMultipathLogicalUnits converts a list of multipath logical
+// units into the structure used by the lun attribute of the
+// vsphere_host_multipath data source.
+func flattenHostMultipathLogicalUnits(units []hostMultipathLogicalUnit) []interface{} {
+	var result []interface{}
+	for _, lu := range units {
+		policy, prefer, satp := hostMultipathLogicalUnitPolicies(&lu)
+		var paths []interface{}
+		for _, p := range lu.Path {
+			var working bool
+			if p.IsWorkingPath != nil {
+				working = *p.IsWorkingPath
+			}
+			paths = append(paths, map[string]interface{}{
+				"name":            p.Name,
+				"adapter":         p.Adapter,
+				"state":           p.PathState,
+				"is_working_path": working,
+			})
+		}
+		result = append(result, map[string]interface{}{
+			"canonical_name":            lu.CanonicalName,
+			"policy":                    policy,
+			"preferred_path":            prefer,
+			"storage_array_type_policy": satp,
+			"path":                      paths,
+		})
+	}
+	return result
+}
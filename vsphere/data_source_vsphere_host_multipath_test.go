@@ -0,0 +1,62 @@
+package vsphere
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccDataSourceVSphereHostMultipath_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccDataSourceVSphereHostMultipathPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceVSphereHostMultipathConfig(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.vsphere_host_multipath.multipath", "lun.#", "1"),
+					resource.TestCheckResourceAttr(
+						"data.vsphere_host_multipath.multipath",
+						"lun.0.canonical_name",
+						os.Getenv("VSPHERE_DS_VMFS_DISK0"),
+					),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceVSphereHostMultipathPreCheck(t *testing.T) {
+	if os.Getenv("VSPHERE_DATACENTER") == "" {
+		t.Skip("set VSPHERE_DATACENTER to run vsphere_host_multipath acceptance tests")
+	}
+	if os.Getenv("VSPHERE_ESXI_HOST") == "" {
+		t.Skip("set VSPHERE_ESXI_HOST to run vsphere_host_multipath acceptance tests")
+	}
+	if os.Getenv("VSPHERE_DS_VMFS_DISK0") == "" {
+		t.Skip("set VSPHERE_DS_VMFS_DISK0 to run vsphere_host_multipath acceptance tests")
+	}
+}
+
+func testAccDataSourceVSphereHostMultipathConfig() string {
+	return fmt.Sprintf(`
+data "vsphere_datacenter" "datacenter" {
+  name = "%s"
+}
+
+data "vsphere_host" "esxi_host" {
+  name          = "%s"
+  datacenter_id = "${data.vsphere_datacenter.datacenter.id}"
+}
+
+data "vsphere_host_multipath" "multipath" {
+  host_system_id = "${data.vsphere_host.esxi_host.id}"
+  filter         = "^%s$"
+}
+`, os.Getenv("VSPHERE_DATACENTER"), os.Getenv("VSPHERE_ESXI_HOST"), os.Getenv("VSPHERE_DS_VMFS_DISK0"))
+}
@@ -0,0 +1,224 @@
+package vsphere
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+func dataSourceVSphereHostPciDevice() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceVSphereHostPciDeviceRead,
+
+		Schema: map[string]*schema.Schema{
+			"host_system_id": {
+				Type:        schema.TypeString,
+				Description: "The managed object ID of the host to list PCI devices for.",
+				Required:    true,
+			},
+			"name_regex": {
+				Type:         schema.TypeString,
+				Description:  "A regular expression used to filter devices by their device name.",
+				Optional:     true,
+				ValidateFunc: validation.ValidateRegexp,
+			},
+			"vendor_id": {
+				Type:        schema.TypeString,
+				Description: "Only list devices with this vendor ID, as a 4-digit hexadecimal string.",
+				Optional:    true,
+			},
+			"passthrough_capable": {
+				Type:        schema.TypeBool,
+				Description: "Only list devices that are capable of DirectPath I/O passthrough.",
+				Optional:    true,
+			},
+			"devices": {
+				Type:        schema.TypeList,
+				Description: "The PCI devices found on the host.",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Description: "The PCI ID of the device, in the form domain:bus:slot.function.",
+							Computed:    true,
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Description: "The name of the device.",
+							Computed:    true,
+						},
+						"vendor_name": {
+							Type:        schema.TypeString,
+							Description: "The name of the device vendor.",
+							Computed:    true,
+						},
+						"vendor_id": {
+							Type:        schema.TypeString,
+							Description: "The vendor ID of the device, as a hexadecimal string.",
+							Computed:    true,
+						},
+						"device_id": {
+							Type:        schema.TypeString,
+							Description: "The device ID of the device, as a hexadecimal string.",
+							Computed:    true,
+						},
+						"sub_vendor_id": {
+							Type:        schema.TypeString,
+							Description: "The subsystem vendor ID of the device, as a hexadecimal string.",
+							Computed:    true,
+						},
+						"sub_device_id": {
+							Type:        schema.TypeString,
+							Description: "The subsystem device ID of the device, as a hexadecimal string.",
+							Computed:    true,
+						},
+						"class_id": {
+							Type:        schema.TypeString,
+							Description: "The class ID of the device, as a hexadecimal string.",
+							Computed:    true,
+						},
+						"parent_bridge": {
+							Type:        schema.TypeString,
+							Description: "The PCI ID of the parent bridge of the device, if any.",
+							Computed:    true,
+						},
+						"passthrough_capable": {
+							Type:        schema.TypeBool,
+							Description: "Whether or not the device is capable of DirectPath I/O passthrough.",
+							Computed:    true,
+						},
+						"passthrough_enabled": {
+							Type:        schema.TypeBool,
+							Description: "Whether or not passthrough is configured for the device.",
+							Computed:    true,
+						},
+						"passthrough_active": {
+							Type:        schema.TypeBool,
+							Description: "Whether or not passthrough is currently active for the device.",
+							Computed:    true,
+						},
+						"sriov_capable": {
+							Type:        schema.TypeBool,
+							Description: "Whether or not the device supports SR-IOV.",
+							Computed:    true,
+						},
+						"sriov_enabled": {
+							Type:        schema.TypeBool,
+							Description: "Whether or not SR-IOV is configured for the device.",
+							Computed:    true,
+						},
+						"num_virtual_functions": {
+							Type:        schema.TypeInt,
+							Description: "The number of SR-IOV virtual functions currently active on the device.",
+							Computed:    true,
+						},
+						"max_virtual_functions": {
+							Type:        schema.TypeInt,
+							Description: "The maximum number of SR-IOV virtual functions supported by the device.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceVSphereHostPciDeviceRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*VSphereClient).vimClient
+	hsID := d.Get("host_system_id").(string)
+	devices, err := hostPciDevices(client, hsID)
+	if err != nil {
+		return fmt.Errorf("error loading PCI devices: %s", err)
+	}
+	ps, err := hostPciPassthruSystemFromHostSystemID(client, hsID)
+	if err != nil {
+		return fmt.Errorf("error loading host PCI passthrough system: %s", err)
+	}
+	infos, err := ps.Info()
+	if err != nil {
+		return fmt.Errorf("error reading PCI passthrough information: %s", err)
+	}
+	infoByID := make(map[string]types.BaseHostPciPassthruInfo)
+	for _, info := range infos {
+		infoByID[info.GetHostPciPassthruInfo().Id] = info
+	}
+
+	var re *regexp.Regexp
+	if v, ok := d.GetOk("name_regex"); ok {
+		re = regexp.MustCompile(v.(string))
+	}
+	vendorID := d.Get("vendor_id").(string)
+	capableOnly := d.Get("passthrough_capable").(bool)
+
+	var result []interface{}
+	for _, dev := range devices {
+		if re != nil && !re.MatchString(dev.DeviceName) {
+			continue
+		}
+		if vendorID != "" && vendorID != formatHostPciID(dev.VendorId) {
+			continue
+		}
+		m := flattenHostPciDevice(dev, infoByID[dev.Id])
+		if capableOnly && !m["passthrough_capable"].(bool) {
+			continue
+		}
+		result = append(result, m)
+	}
+
+	d.SetId(hsID)
+	if err := d.Set("devices", result); err != nil {
+		return fmt.Errorf("error saving results to state: %s", err)
+	}
+	return nil
+}
+
+// flattenHostPciDevice converts a HostPciDevice and its passthrough state, if
+// known, into the structure used by the devices attribute of the
+// vsphere_host_pci_device data source.
+func flattenHostPciDevice(dev types.HostPciDevice, info types.BaseHostPciPassthruInfo) map[string]interface{} {
+	m := map[string]interface{}{
+		"id":            dev.Id,
+		"name":          dev.DeviceName,
+		"vendor_name":   dev.VendorName,
+		"vendor_id":     formatHostPciID(dev.VendorId),
+		"device_id":     formatHostPciID(dev.DeviceId),
+		"sub_vendor_id": formatHostPciID(dev.SubVendorId),
+		"sub_device_id": formatHostPciID(dev.SubDeviceId),
+		"class_id":      formatHostPciID(dev.ClassId),
+		"parent_bridge": dev.ParentBridge,
+	}
+	m["passthrough_capable"] = false
+	m["passthrough_enabled"] = false
+	m["passthrough_active"] = false
+	m["sriov_capable"] = false
+	m["sriov_enabled"] = false
+	m["num_virtual_functions"] = 0
+	m["max_virtual_functions"] = 0
+	if info == nil {
+		return m
+	}
+	base := info.GetHostPciPassthruInfo()
+	m["passthrough_capable"] = base.PassthruCapable
+	m["passthrough_enabled"] = base.PassthruEnabled
+	m["passthrough_active"] = base.PassthruActive
+	if sriov, ok := info.(*types.HostSriovInfo); ok {
+		m["sriov_capable"] = sriov.SriovCapable
+		m["sriov_enabled"] = sriov.SriovEnabled
+		m["num_virtual_functions"] = int(sriov.NumVirtualFunction)
+		m["max_virtual_functions"] = int(sriov.MaxVirtualFunctionSupported)
+	}
+	return m
+}
+
+// formatHostPciID formats a PCI vendor, device, or class ID as a 4-digit
+// hexadecimal string. The API returns these as signed 16-bit integers, so
+// they are converted to unsigned first to avoid negative values for IDs
+// above 0x7fff.
+func formatHostPciID(id int16) string {
+	return fmt.Sprintf("%04x", uint16(id))
+}
@@ -0,0 +1,55 @@
+package vsphere
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccDataSourceVSphereHostPciDevice_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccDataSourceVSphereHostPciDevicePreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceVSphereHostPciDeviceConfig(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr("data.vsphere_host_pci_device.devices", "devices.#", regexp.MustCompile("^[1-9][0-9]*$")),
+					resource.TestMatchResourceAttr("data.vsphere_host_pci_device.devices", "devices.0.vendor_id", regexp.MustCompile("^[0-9a-f]{4}$")),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceVSphereHostPciDevicePreCheck(t *testing.T) {
+	if os.Getenv("VSPHERE_DATACENTER") == "" {
+		t.Skip("set VSPHERE_DATACENTER to run vsphere_host_pci_device acceptance tests")
+	}
+	if os.Getenv("VSPHERE_ESXI_HOST") == "" {
+		t.Skip("set VSPHERE_ESXI_HOST to run vsphere_host_pci_device acceptance tests")
+	}
+}
+
+func testAccDataSourceVSphereHostPciDeviceConfig() string {
+	return fmt.Sprintf(`
+data "vsphere_datacenter" "datacenter" {
+  name = "%s"
+}
+
+data "vsphere_host" "esxi_host" {
+  name          = "%s"
+  datacenter_id = "${data.vsphere_datacenter.datacenter.id}"
+}
+
+data "vsphere_host_pci_device" "devices" {
+  host_system_id = "${data.vsphere_host.esxi_host.id}"
+}
+`, os.Getenv("VSPHERE_DATACENTER"), os.Getenv("VSPHERE_ESXI_HOST"))
+}
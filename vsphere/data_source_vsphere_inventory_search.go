@@ -0,0 +1,248 @@
+package vsphere
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/structure"
+	"github.com/vmware/govmomi/property"
+	"github.com/vmware/govmomi/view"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+func dataSourceVSphereInventorySearch() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceVSphereInventorySearchRead,
+
+		Schema: map[string]*schema.Schema{
+			"type": {
+				Type:        schema.TypeString,
+				Description: "The managed object type to search for, such as VirtualMachine, HostSystem, or Datastore.",
+				Required:    true,
+			},
+			"root_id": {
+				Type:        schema.TypeString,
+				Description: "The managed object ID of the datacenter or folder to search in. Defaults to the root folder.",
+				Optional:    true,
+			},
+			"root_type": {
+				Type:         schema.TypeString,
+				Description:  "The managed object type of root_id. Can be one of Folder, Datacenter, ClusterComputeResource, HostSystem, or ResourcePool.",
+				Optional:     true,
+				Default:      "Folder",
+				ValidateFunc: validation.StringInSlice([]string{"Folder", "Datacenter", "ClusterComputeResource", "HostSystem", "ResourcePool"}, false),
+			},
+			"name_regex": {
+				Type:         schema.TypeString,
+				Description:  "Only return objects with names matching this regular expression.",
+				Optional:     true,
+				ValidateFunc: validation.ValidateRegexp,
+			},
+			"tag_ids": {
+				Type:        schema.TypeList,
+				Description: "Only return objects that have all of these tags attached.",
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"custom_attributes": {
+				Type:        schema.TypeMap,
+				Description: "Only return objects with these custom attribute values, keyed by custom attribute ID.",
+				Optional:    true,
+			},
+			"properties": {
+				Type:        schema.TypeMap,
+				Description: "Only return objects whose properties equal these values, keyed by property path, such as runtime.powerState.",
+				Optional:    true,
+			},
+			"ids": {
+				Type:        schema.TypeList,
+				Description: "The managed object IDs of the matching objects, sorted by name.",
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"names": {
+				Type:        schema.TypeList,
+				Description: "The names of the matching objects, in the same order as ids.",
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func dataSourceVSphereInventorySearchRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*VSphereClient).vimClient
+	objType := d.Get("type").(string)
+	root := client.ServiceContent.RootFolder
+	if v, ok := d.GetOk("root_id"); ok {
+		root = types.ManagedObjectReference{
+			Type:  d.Get("root_type").(string),
+			Value: v.(string),
+		}
+	}
+
+	var nameRe *regexp.Regexp
+	if v, ok := d.GetOk("name_regex"); ok {
+		nameRe = regexp.MustCompile(v.(string))
+	}
+	filter := make(property.Filter)
+	for k, v := range d.Get("properties").(map[string]interface{}) {
+		filter[k] = v.(string)
+	}
+	attrs := d.Get("custom_attributes").(map[string]interface{})
+
+	ps := append([]string{"name"}, filter.Keys()...)
+	if len(attrs) > 0 {
+		ps = append(ps, "customValue")
+	}
+	content, err := dataSourceVSphereInventorySearchRetrieve(meta, root, objType, ps)
+	if err != nil {
+		return err
+	}
+
+	var tagged map[string]bool
+	if tagIDs := structure.SliceInterfacesToStrings(d.Get("tag_ids").([]interface{})); len(tagIDs) > 0 {
+		tagged, err = dataSourceVSphereInventorySearchTagged(meta, objType, tagIDs)
+		if err != nil {
+			return err
+		}
+	}
+
+	type result struct {
+		id   string
+		name string
+	}
+	var results []result
+	for _, oc := range content {
+		props := make(map[string]types.DynamicProperty)
+		for _, p := range oc.PropSet {
+			props[p.Name] = p
+		}
+		name, _ := props["name"].Val.(string)
+		if nameRe != nil && !nameRe.MatchString(name) {
+			continue
+		}
+		if tagged != nil && !tagged[oc.Obj.Value] {
+			continue
+		}
+		if !dataSourceVSphereInventorySearchMatchProperties(filter, props) {
+			continue
+		}
+		if !dataSourceVSphereInventorySearchMatchCustomAttributes(attrs, props["customValue"].Val) {
+			continue
+		}
+		results = append(results, result{id: oc.Obj.Value, name: name})
+	}
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].name != results[j].name {
+			return results[i].name < results[j].name
+		}
+		return results[i].id < results[j].id
+	})
+
+	var ids, names []string
+	for _, r := range results {
+		ids = append(ids, r.id)
+		names = append(names, r.name)
+	}
+	if err := structure.SetBatch(d, map[string]interface{}{
+		"ids":   ids,
+		"names": names,
+	}); err != nil {
+		return err
+	}
+	d.SetId(time.Now().UTC().String())
+	return nil
+}
+
+// dataSourceVSphereInventorySearchRetrieve retrieves the supplied properties
+// for all objects of a type under the root object, using a container view.
+func dataSourceVSphereInventorySearchRetrieve(meta interface{}, root types.ManagedObjectReference, objType string, ps []string) ([]types.ObjectContent, error) {
+	client := meta.(*VSphereClient).vimClient
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	m := view.NewManager(client.Client)
+	v, err := m.CreateContainerView(ctx, root, []string{objType}, true)
+	if err != nil {
+		return nil, fmt.Errorf("error creating container view for %s %q: %s", root.Type, root.Value, err)
+	}
+	defer v.Destroy(ctx)
+	var content []types.ObjectContent
+	if err := v.Retrieve(ctx, []string{objType}, ps, &content); err != nil {
+		return nil, fmt.Errorf("error retrieving %s objects: %s", objType, err)
+	}
+	return content, nil
+}
+
+// dataSourceVSphereInventorySearchTagged returns the IDs of the objects of a
+// type that have all of the supplied tags attached.
+func dataSourceVSphereInventorySearchTagged(meta interface{}, objType string, tagIDs []string) (map[string]bool, error) {
+	tc, err := meta.(*VSphereClient).TagsClient()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	counts := make(map[string]int)
+	for _, tagID := range tagIDs {
+		objs, err := tc.ListAttachedObjects(ctx, tagID)
+		if err != nil {
+			return nil, fmt.Errorf("error listing objects for tag %q: %s", tagID, err)
+		}
+		for _, obj := range objs {
+			if obj.ID == nil || obj.Type == nil || *obj.Type != objType {
+				continue
+			}
+			counts[*obj.ID]++
+		}
+	}
+	tagged := make(map[string]bool)
+	for id, n := range counts {
+		if n == len(tagIDs) {
+			tagged[id] = true
+		}
+	}
+	return tagged, nil
+}
+
+// dataSourceVSphereInventorySearchMatchProperties returns true if all of the
+// properties in the filter are set and match.
+func dataSourceVSphereInventorySearchMatchProperties(filter property.Filter, props map[string]types.DynamicProperty) bool {
+	for key := range filter {
+		p, ok := props[key]
+		if !ok || !filter.MatchProperty(p) {
+			return false
+		}
+	}
+	return true
+}
+
+// dataSourceVSphereInventorySearchMatchCustomAttributes returns true if the
+// custom values of an object contain all of the supplied custom attribute
+// values.
+func dataSourceVSphereInventorySearchMatchCustomAttributes(attrs map[string]interface{}, val types.AnyType) bool {
+	if len(attrs) < 1 {
+		return true
+	}
+	values, ok := val.(types.ArrayOfCustomFieldValue)
+	if !ok {
+		return false
+	}
+	actual := make(map[string]string)
+	for _, bv := range values.CustomFieldValue {
+		if sv, ok := bv.(*types.CustomFieldStringValue); ok {
+			actual[fmt.Sprint(sv.Key)] = sv.Value
+		}
+	}
+	for k, v := range attrs {
+		if actual[k] != v.(string) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,104 @@
+package vsphere
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccDataSourceVSphereInventorySearch_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccDataSourceVSphereInventorySearchPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceVSphereInventorySearchConfigNameRegex(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.vsphere_inventory_search.search", "ids.#", "1"),
+					resource.TestCheckResourceAttrPair(
+						"data.vsphere_inventory_search.search", "ids.0",
+						"data.vsphere_host.host", "id",
+					),
+					resource.TestCheckResourceAttr("data.vsphere_inventory_search.search", "names.0", os.Getenv("VSPHERE_ESXI_HOST")),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceVSphereInventorySearch_properties(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccDataSourceVSphereInventorySearchPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceVSphereInventorySearchConfigProperties(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr("data.vsphere_inventory_search.search", "ids.#", regexp.MustCompile("^[1-9][0-9]*$")),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceVSphereInventorySearchPreCheck(t *testing.T) {
+	if os.Getenv("VSPHERE_DATACENTER") == "" {
+		t.Skip("set VSPHERE_DATACENTER to run vsphere_inventory_search acceptance tests")
+	}
+	if os.Getenv("VSPHERE_ESXI_HOST") == "" {
+		t.Skip("set VSPHERE_ESXI_HOST to run vsphere_inventory_search acceptance tests")
+	}
+}
+
+func testAccDataSourceVSphereInventorySearchConfigNameRegex() string {
+	return fmt.Sprintf(`
+data "vsphere_datacenter" "dc" {
+  name = "%s"
+}
+
+data "vsphere_host" "host" {
+  name          = "%s"
+  datacenter_id = "${data.vsphere_datacenter.dc.id}"
+}
+
+data "vsphere_inventory_search" "search" {
+  type       = "HostSystem"
+  root_id    = "${data.vsphere_datacenter.dc.id}"
+  root_type  = "Datacenter"
+  name_regex = "^%s$"
+}
+`,
+		os.Getenv("VSPHERE_DATACENTER"),
+		os.Getenv("VSPHERE_ESXI_HOST"),
+		regexp.QuoteMeta(os.Getenv("VSPHERE_ESXI_HOST")),
+	)
+}
+
+func testAccDataSourceVSphereInventorySearchConfigProperties() string {
+	return fmt.Sprintf(`
+data "vsphere_datacenter" "dc" {
+  name = "%s"
+}
+
+data "vsphere_inventory_search" "search" {
+  type      = "HostSystem"
+  root_id   = "${data.vsphere_datacenter.dc.id}"
+  root_type = "Datacenter"
+
+  properties {
+    "runtime.connectionState" = "connected"
+  }
+}
+`,
+		os.Getenv("VSPHERE_DATACENTER"),
+	)
+}
@@ -0,0 +1,99 @@
+package vsphere
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/structure"
+	"github.com/vmware/govmomi/property"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+func dataSourceVSphereManagedObject() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceVSphereManagedObjectRead,
+
+		Schema: map[string]*schema.Schema{
+			"type": {
+				Type:        schema.TypeString,
+				Description: "The managed object type of the object, such as HostSystem, Datastore, or ClusterComputeResource.",
+				Required:    true,
+			},
+			"moid": {
+				Type:        schema.TypeString,
+				Description: "The managed object ID of the object.",
+				Required:    true,
+			},
+			"properties": {
+				Type:        schema.TypeList,
+				Description: "The property paths to read, such as config.product.build or summary.url.",
+				Required:    true,
+				MinItems:    1,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"values": {
+				Type:        schema.TypeMap,
+				Description: "The values of the properties, rendered as JSON strings and keyed by property path. Array properties are rendered as JSON arrays, and unset properties are rendered as null.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func dataSourceVSphereManagedObjectRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*VSphereClient).vimClient
+	ref := types.ManagedObjectReference{
+		Type:  d.Get("type").(string),
+		Value: d.Get("moid").(string),
+	}
+	ps := structure.SliceInterfacesToStrings(d.Get("properties").([]interface{}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	var content []types.ObjectContent
+	pc := property.DefaultCollector(client.Client)
+	if err := pc.Retrieve(ctx, []types.ManagedObjectReference{ref}, ps, &content); err != nil {
+		return fmt.Errorf("error fetching properties for %s %q: %s", ref.Type, ref.Value, err)
+	}
+	if len(content) < 1 {
+		return fmt.Errorf("%s %q not found", ref.Type, ref.Value)
+	}
+
+	values := make(map[string]interface{})
+	for _, p := range ps {
+		values[p] = "null"
+	}
+	for _, dp := range content[0].PropSet {
+		b, err := json.Marshal(unwrapManagedObjectArray(dp.Val))
+		if err != nil {
+			return fmt.Errorf("error rendering property %q: %s", dp.Name, err)
+		}
+		values[dp.Name] = string(b)
+	}
+	if err := d.Set("values", values); err != nil {
+		return fmt.Errorf("error setting values: %s", err)
+	}
+	d.SetId(ref.Value)
+	return nil
+}
+
+// unwrapManagedObjectArray returns the slice inside of an ArrayOf* type, such
+// as ArrayOfManagedObjectReference or ArrayOfString, which the property
+// collector uses to return array properties. Other values are returned as-is.
+func unwrapManagedObjectArray(v interface{}) interface{} {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct || !strings.HasPrefix(rv.Type().Name(), "ArrayOf") {
+		return v
+	}
+	if rv.NumField() != 1 || rv.Field(0).Kind() != reflect.Slice {
+		return v
+	}
+	return rv.Field(0).Interface()
+}
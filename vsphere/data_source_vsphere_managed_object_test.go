@@ -0,0 +1,102 @@
+package vsphere
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+func TestUnwrapManagedObjectArray(t *testing.T) {
+	cases := map[string]struct {
+		Value    interface{}
+		Expected interface{}
+	}{
+		"string": {
+			Value:    "6.5.0",
+			Expected: "6.5.0",
+		},
+		"array of strings": {
+			Value:    types.ArrayOfString{String: []string{"a", "b"}},
+			Expected: []string{"a", "b"},
+		},
+		"array of references": {
+			Value: types.ArrayOfManagedObjectReference{
+				ManagedObjectReference: []types.ManagedObjectReference{
+					{Type: "Datastore", Value: "datastore-1"},
+				},
+			},
+			Expected: []types.ManagedObjectReference{
+				{Type: "Datastore", Value: "datastore-1"},
+			},
+		},
+		"data object": {
+			Value:    types.AboutInfo{Build: "123"},
+			Expected: types.AboutInfo{Build: "123"},
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			actual := unwrapManagedObjectArray(tc.Value)
+			if !reflect.DeepEqual(tc.Expected, actual) {
+				t.Fatalf("expected %#v, got %#v", tc.Expected, actual)
+			}
+		})
+	}
+}
+
+func TestAccDataSourceVSphereManagedObject_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccDataSourceVSphereManagedObjectPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceVSphereManagedObjectConfig(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.vsphere_managed_object.host", "values.name", fmt.Sprintf("%q", os.Getenv("VSPHERE_ESXI_HOST"))),
+					resource.TestMatchResourceAttr("data.vsphere_managed_object.host", "values.config.product.build", regexp.MustCompile(`^"[0-9]+"$`)),
+					resource.TestCheckResourceAttr("data.vsphere_managed_object.host", "values.runtime.connectionState", `"connected"`),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceVSphereManagedObjectPreCheck(t *testing.T) {
+	if os.Getenv("VSPHERE_DATACENTER") == "" {
+		t.Skip("set VSPHERE_DATACENTER to run vsphere_managed_object acceptance tests")
+	}
+	if os.Getenv("VSPHERE_ESXI_HOST") == "" {
+		t.Skip("set VSPHERE_ESXI_HOST to run vsphere_managed_object acceptance tests")
+	}
+}
+
+func testAccDataSourceVSphereManagedObjectConfig() string {
+	return fmt.Sprintf(`
+data "vsphere_datacenter" "dc" {
+  name = "%s"
+}
+
+data "vsphere_host" "host" {
+  name          = "%s"
+  datacenter_id = "${data.vsphere_datacenter.dc.id}"
+}
+
+data "vsphere_managed_object" "host" {
+  type       = "HostSystem"
+  moid       = "${data.vsphere_host.host.id}"
+  properties = ["name", "config.product.build", "runtime.connectionState"]
+}
+`,
+		os.Getenv("VSPHERE_DATACENTER"),
+		os.Getenv("VSPHERE_ESXI_HOST"),
+	)
+}
@@ -1,34 +1,111 @@
 package vsphere
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/hashicorp/terraform/helper/schema"
-	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/network"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/nsx"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/structure"
+	"github.com/vmware/govmomi"
+	"github.com/vmware/govmomi/find"
 	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
 )
 
-func dataSourceVSphereNetwork() *schema.Resource {
-	return &schema.Resource{
-		Read: dataSourceVSphereNetworkRead,
+// networkTypeAllowedValues are the managed object types of the networks that
+// can be looked up by the vsphere_network and vsphere_networks data sources.
+var networkTypeAllowedValues = []string{
+	"Network",
+	"DistributedVirtualPortgroup",
+	"OpaqueNetwork",
+}
 
-		Schema: map[string]*schema.Schema{
-			"name": {
-				Type:        schema.TypeString,
-				Description: "The name or path of the network.",
-				Required:    true,
-			},
-			"datacenter_id": {
-				Type:        schema.TypeString,
-				Description: "The managed object ID of the datacenter the network is in. This is required if the supplied path is not an absolute path containing a datacenter and there are multiple datacenters in your infrastructure.",
-				Optional:    true,
-			},
-			"type": {
-				Type:        schema.TypeString,
-				Description: "The managed object type of the network.",
-				Computed:    true,
+// schemaNetworkAttributes returns the schema for the attributes of a network
+// that can be used to filter networks, and are exported by the vsphere_network
+// and vsphere_networks data sources.
+func schemaNetworkAttributes() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"type": {
+			Type:         schema.TypeString,
+			Description:  "The managed object type of the network. Can be one of Network (a standard port group), DistributedVirtualPortgroup, or OpaqueNetwork.",
+			Optional:     true,
+			Computed:     true,
+			ValidateFunc: validation.StringInSlice(networkTypeAllowedValues, false),
+		},
+		"distributed_virtual_switch_uuid": {
+			Type:        schema.TypeString,
+			Description: "The UUID of the DVS that the network is a port group on.",
+			Optional:    true,
+			Computed:    true,
+		},
+		"vlan_id": {
+			Type:        schema.TypeInt,
+			Description: "The VLAN ID of the network.",
+			Optional:    true,
+			Computed:    true,
+		},
+		"vlan_range": {
+			Type:        schema.TypeList,
+			Description: "The VLAN ranges trunked by the network, for DVS port groups that use VLAN trunking.",
+			Computed:    true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"min_vlan": {
+						Type:        schema.TypeInt,
+						Description: "The minimum VLAN in the range.",
+						Computed:    true,
+					},
+					"max_vlan": {
+						Type:        schema.TypeInt,
+						Description: "The maximum VLAN in the range.",
+						Computed:    true,
+					},
+				},
 			},
 		},
+		"host_system_ids": {
+			Type:        schema.TypeList,
+			Description: "The managed object IDs of the hosts that can access the network.",
+			Computed:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+		},
+		"opaque_network_id": {
+			Type:        schema.TypeString,
+			Description: "The ID of the opaque network, such as the logical switch ID of an NSX-T segment.",
+			Optional:    true,
+			Computed:    true,
+		},
+		"opaque_network_type": {
+			Type:        schema.TypeString,
+			Description: "The type of the opaque network, such as nsx.LogicalSwitch.",
+			Computed:    true,
+		},
+	}
+}
+
+func dataSourceVSphereNetwork() *schema.Resource {
+	s := map[string]*schema.Schema{
+		"name": {
+			Type:        schema.TypeString,
+			Description: "The name or path of the network. Required unless opaque_network_id is specified.",
+			Optional:    true,
+		},
+		"datacenter_id": {
+			Type:        schema.TypeString,
+			Description: "The managed object ID of the datacenter the network is in. This is required if the supplied path is not an absolute path containing a datacenter and there are multiple datacenters in your infrastructure.",
+			Optional:    true,
+		},
+	}
+	structure.MergeSchema(s, schemaNetworkAttributes())
+
+	return &schema.Resource{
+		Read:   dataSourceVSphereNetworkRead,
+		Schema: s,
 	}
 }
 
@@ -36,6 +113,7 @@ func dataSourceVSphereNetworkRead(d *schema.ResourceData, meta interface{}) erro
 	client := meta.(*VSphereClient).vimClient
 
 	name := d.Get("name").(string)
+	opaqueID := d.Get("opaque_network_id").(string)
 	var dc *object.Datacenter
 	if dcID, ok := d.GetOk("datacenter_id"); ok {
 		var err error
@@ -44,12 +122,165 @@ func dataSourceVSphereNetworkRead(d *schema.ResourceData, meta interface{}) erro
 			return fmt.Errorf("cannot locate datacenter: %s", err)
 		}
 	}
-	net, err := network.FromPath(client, name, dc)
-	if err != nil {
-		return fmt.Errorf("error fetching network: %s", err)
+
+	var refs []types.ManagedObjectReference
+	switch {
+	case name != "":
+		finder := find.NewFinder(client.Client, false)
+		if dc != nil {
+			finder.SetDatacenter(dc)
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+		defer cancel()
+		nets, err := finder.NetworkList(ctx, name)
+		if err != nil {
+			return fmt.Errorf("error fetching network: %s", err)
+		}
+		for _, net := range nets {
+			refs = append(refs, net.Reference())
+		}
+	case opaqueID != "":
+		// Opaque networks are looked up by ID in the datacenter if one is
+		// supplied, as the same opaque network is visible in every datacenter
+		// the hosts it is attached to are in.
+		root := client.ServiceContent.RootFolder
+		if dc != nil {
+			root = dc.Reference()
+		}
+		nets, err := nsx.OpaqueNetworksFromNetworkID(client, root, opaqueID)
+		if err != nil {
+			return fmt.Errorf("error fetching opaque network: %s", err)
+		}
+		for _, net := range nets {
+			refs = append(refs, net.Reference())
+		}
+		name = opaqueID
+	default:
+		return errors.New("one of name or opaque_network_id must be specified")
+	}
+
+	var matches []map[string]interface{}
+	for _, ref := range refs {
+		attrs, err := networkAttributes(client, ref)
+		if err != nil {
+			return err
+		}
+		if networkAttributesMatch(d, attrs) {
+			matches = append(matches, attrs)
+		}
+	}
+	switch {
+	case len(matches) < 1:
+		return fmt.Errorf("no network %q found matching the supplied filters", name)
+	case len(matches) > 1:
+		var ids []string
+		for _, m := range matches {
+			ids = append(ids, m["id"].(string))
+		}
+		return fmt.Errorf("multiple networks %q found (%s), use datacenter_id, type, distributed_virtual_switch_uuid, vlan_id, or opaque_network_id to narrow the search", name, strings.Join(ids, ", "))
+	}
+
+	attrs := matches[0]
+	d.SetId(attrs["id"].(string))
+	delete(attrs, "id")
+	delete(attrs, "name")
+	return structure.SetBatch(d, attrs)
+}
+
+// networkAttributes returns the attributes of a network described in
+// schemaNetworkAttributes, along with its ID and name.
+func networkAttributes(client *govmomi.Client, ref types.ManagedObjectReference) (map[string]interface{}, error) {
+	pc := client.PropertyCollector()
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	var props mo.Network
+	if err := pc.RetrieveOne(ctx, ref, []string{"name", "host"}, &props); err != nil {
+		return nil, fmt.Errorf("error fetching properties for network %q: %s", ref.Value, err)
+	}
+	var hosts []string
+	for _, host := range props.Host {
+		hosts = append(hosts, host.Value)
+	}
+	attrs := map[string]interface{}{
+		"id":                              ref.Value,
+		"name":                            props.Name,
+		"type":                            ref.Type,
+		"distributed_virtual_switch_uuid": "",
+		"vlan_id":                         0,
+		"vlan_range":                      []interface{}{},
+		"host_system_ids":                 hosts,
+		"opaque_network_id":               "",
+		"opaque_network_type":             "",
 	}
 
-	d.SetId(net.Reference().Value)
-	d.Set("type", net.Reference().Type)
-	return nil
+	switch ref.Type {
+	case "DistributedVirtualPortgroup":
+		var pg mo.DistributedVirtualPortgroup
+		if err := pc.RetrieveOne(ctx, ref, []string{"config.distributedVirtualSwitch", "config.defaultPortConfig"}, &pg); err != nil {
+			return nil, fmt.Errorf("error fetching properties for port group %q: %s", ref.Value, err)
+		}
+		if pg.Config.DistributedVirtualSwitch != nil {
+			var dvs mo.DistributedVirtualSwitch
+			if err := pc.RetrieveOne(ctx, *pg.Config.DistributedVirtualSwitch, []string{"uuid"}, &dvs); err != nil {
+				return nil, fmt.Errorf("error fetching DVS for port group %q: %s", ref.Value, err)
+			}
+			attrs["distributed_virtual_switch_uuid"] = dvs.Uuid
+		}
+		if ps, ok := pg.Config.DefaultPortConfig.(*types.VMwareDVSPortSetting); ok {
+			switch vlan := ps.Vlan.(type) {
+			case *types.VmwareDistributedVirtualSwitchVlanIdSpec:
+				attrs["vlan_id"] = int(vlan.VlanId)
+			case *types.VmwareDistributedVirtualSwitchTrunkVlanSpec:
+				attrs["vlan_range"] = flattenSliceOfNumericRangeVlans(vlan.VlanId)
+			}
+		}
+	case "OpaqueNetwork":
+		var on mo.OpaqueNetwork
+		if err := pc.RetrieveOne(ctx, ref, []string{"summary"}, &on); err != nil {
+			return nil, fmt.Errorf("error fetching properties for opaque network %q: %s", ref.Value, err)
+		}
+		if summary, ok := on.Summary.(*types.OpaqueNetworkSummary); ok {
+			attrs["opaque_network_id"] = summary.OpaqueNetworkId
+			attrs["opaque_network_type"] = summary.OpaqueNetworkType
+		}
+	case "Network":
+		// The VLAN of a standard port group is configured on each host, so it is
+		// read from the first host the network is on.
+		if len(props.Host) > 0 {
+			var hns mo.HostSystem
+			if err := pc.RetrieveOne(ctx, props.Host[0], []string{"config.network.portgroup"}, &hns); err != nil {
+				return nil, fmt.Errorf("error fetching port groups for host %q: %s", props.Host[0].Value, err)
+			}
+			if hns.Config != nil && hns.Config.Network != nil {
+				for _, pg := range hns.Config.Network.Portgroup {
+					if pg.Spec.Name == props.Name {
+						attrs["vlan_id"] = int(pg.Spec.VlanId)
+					}
+				}
+			}
+		}
+	}
+	return attrs, nil
+}
+
+// networkAttributesMatch returns true if the attributes of a network match the
+// filters in schemaNetworkAttributes that are set in the ResourceData.
+func networkAttributesMatch(d *schema.ResourceData, attrs map[string]interface{}) bool {
+	if v, ok := d.GetOk("type"); ok && v.(string) != attrs["type"] {
+		return false
+	}
+	if v, ok := d.GetOk("distributed_virtual_switch_uuid"); ok && v.(string) != attrs["distributed_virtual_switch_uuid"] {
+		return false
+	}
+	// GetOkExists is used so that a filter on VLAN 0, an untagged network, is
+	// not ignored. Port groups that trunk VLAN ranges never match.
+	if v, ok := d.GetOkExists("vlan_id"); ok {
+		if v.(int) != attrs["vlan_id"] || len(attrs["vlan_range"].([]interface{})) > 0 {
+			return false
+		}
+	}
+	if v, ok := d.GetOk("opaque_network_id"); ok && v.(string) != attrs["opaque_network_id"] {
+		return false
+	}
+	return true
 }
@@ -94,6 +94,53 @@ func TestAccDataSourceVSphereNetwork_absolutePathEndingInSameName(t *testing.T)
 	})
 }
 
+func TestAccDataSourceVSphereNetwork_filterByDVS(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccDataSourceVSphereNetworkPreCheck(t)
+			testAccSkipIfEsxi(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceVSphereNetworkConfigFilterByDVS(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(
+						"data.vsphere_network.net", "id",
+						"vsphere_distributed_port_group.pg2", "id",
+					),
+					resource.TestCheckResourceAttrPair(
+						"data.vsphere_network.net", "distributed_virtual_switch_uuid",
+						"vsphere_distributed_virtual_switch.dvs2", "id",
+					),
+					resource.TestCheckResourceAttr("data.vsphere_network.net", "vlan_id", "1001"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceVSphereNetwork_opaqueNetworkID(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccDataSourceVSphereNetworkOpaquePreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceVSphereNetworkConfigOpaqueNetworkID(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.vsphere_network.net", "type", "OpaqueNetwork"),
+					resource.TestCheckResourceAttr("data.vsphere_network.net", "opaque_network_id", os.Getenv("VSPHERE_OPAQUE_NETWORK_ID")),
+					resource.TestCheckResourceAttrSet("data.vsphere_network.net", "opaque_network_type"),
+				),
+			},
+		},
+	})
+}
+
 func testAccDataSourceVSphereNetworkPreCheck(t *testing.T) {
 	if os.Getenv("VSPHERE_HOST_NIC0") == "" {
 		t.Skip("set VSPHERE_HOST_NIC0 to run vsphere_network acceptance tests")
@@ -112,6 +159,12 @@ func testAccDataSourceVSphereNetworkPreCheck(t *testing.T) {
 	}
 }
 
+func testAccDataSourceVSphereNetworkOpaquePreCheck(t *testing.T) {
+	if os.Getenv("VSPHERE_OPAQUE_NETWORK_ID") == "" {
+		t.Skip("set VSPHERE_OPAQUE_NETWORK_ID to run vsphere_network opaque network acceptance tests")
+	}
+}
+
 func testAccDataSourceVSphereNetworkConfigDVSPortgroup() string {
 	return fmt.Sprintf(`
 variable "datacenter" {
@@ -288,3 +341,67 @@ data "vsphere_network" "net" {
 		os.Getenv("VSPHERE_HOST_NIC1"),
 	)
 }
+
+func testAccDataSourceVSphereNetworkConfigFilterByDVS() string {
+	return fmt.Sprintf(`
+variable "datacenter" {
+  default = "%s"
+}
+
+data "vsphere_datacenter" "dc" {
+  name = "${var.datacenter}"
+}
+
+resource "vsphere_distributed_virtual_switch" "dvs1" {
+  name          = "terraform-test-dvs1"
+  datacenter_id = "${data.vsphere_datacenter.dc.id}"
+}
+
+resource "vsphere_distributed_virtual_switch" "dvs2" {
+  name          = "terraform-test-dvs2"
+  datacenter_id = "${data.vsphere_datacenter.dc.id}"
+}
+
+resource "vsphere_distributed_port_group" "pg1" {
+  name                            = "terraform-test-pg"
+  distributed_virtual_switch_uuid = "${vsphere_distributed_virtual_switch.dvs1.id}"
+  vlan_id                         = 1000
+}
+
+resource "vsphere_distributed_port_group" "pg2" {
+  name                            = "terraform-test-pg"
+  distributed_virtual_switch_uuid = "${vsphere_distributed_virtual_switch.dvs2.id}"
+  vlan_id                         = 1001
+}
+
+data "vsphere_network" "net" {
+  name                            = "${vsphere_distributed_port_group.pg2.name}"
+  datacenter_id                   = "${data.vsphere_datacenter.dc.id}"
+  distributed_virtual_switch_uuid = "${vsphere_distributed_virtual_switch.dvs2.id}"
+
+  depends_on = ["vsphere_distributed_port_group.pg1"]
+}
+`,
+		os.Getenv("VSPHERE_DATACENTER"),
+	)
+}
+
+func testAccDataSourceVSphereNetworkConfigOpaqueNetworkID() string {
+	return fmt.Sprintf(`
+variable "datacenter" {
+  default = "%s"
+}
+
+data "vsphere_datacenter" "dc" {
+  name = "${var.datacenter}"
+}
+
+data "vsphere_network" "net" {
+  opaque_network_id = "%s"
+  datacenter_id     = "${data.vsphere_datacenter.dc.id}"
+}
+`,
+		os.Getenv("VSPHERE_DATACENTER"),
+		os.Getenv("VSPHERE_OPAQUE_NETWORK_ID"),
+	)
+}
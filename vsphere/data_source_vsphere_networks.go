@@ -0,0 +1,146 @@
+package vsphere
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/network"
+)
+
+func dataSourceVSphereNetworks() *schema.Resource {
+	attrs := schemaNetworkAttributes()
+	return &schema.Resource{
+		Read: dataSourceVSphereNetworksRead,
+
+		Schema: map[string]*schema.Schema{
+			"datacenter_id": {
+				Type:        schema.TypeString,
+				Description: "The managed object ID of the datacenter to list the networks in.",
+				Required:    true,
+			},
+			"name_regex": {
+				Type:         schema.TypeString,
+				Description:  "Only return networks with names matching this regular expression.",
+				Optional:     true,
+				ValidateFunc: validation.ValidateRegexp,
+			},
+			"type": {
+				Type:         schema.TypeString,
+				Description:  "Only return networks of this managed object type. Can be one of Network, DistributedVirtualPortgroup, or OpaqueNetwork.",
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice(networkTypeAllowedValues, false),
+			},
+			"distributed_virtual_switch_uuid": {
+				Type:        schema.TypeString,
+				Description: "Only return port groups on the DVS with this UUID.",
+				Optional:    true,
+			},
+			"vlan_id": {
+				Type:        schema.TypeInt,
+				Description: "Only return networks with this VLAN ID.",
+				Optional:    true,
+			},
+			"opaque_network_id": {
+				Type:        schema.TypeString,
+				Description: "Only return opaque networks with this opaque network ID.",
+				Optional:    true,
+			},
+			"networks": {
+				Type:        schema.TypeList,
+				Description: "The networks in the datacenter that match the filters, sorted by name.",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Description: "The managed object ID of the network.",
+							Computed:    true,
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Description: "The name of the network.",
+							Computed:    true,
+						},
+						"type": {
+							Type:        schema.TypeString,
+							Description: "The managed object type of the network.",
+							Computed:    true,
+						},
+						"distributed_virtual_switch_uuid": {
+							Type:        schema.TypeString,
+							Description: "The UUID of the DVS that the network is a port group on.",
+							Computed:    true,
+						},
+						"vlan_id": {
+							Type:        schema.TypeInt,
+							Description: "The VLAN ID of the network.",
+							Computed:    true,
+						},
+						"opaque_network_id": {
+							Type:        schema.TypeString,
+							Description: "The ID of the opaque network, such as the logical switch ID of an NSX-T segment.",
+							Computed:    true,
+						},
+						"vlan_range":          attrs["vlan_range"],
+						"host_system_ids":     attrs["host_system_ids"],
+						"opaque_network_type": attrs["opaque_network_type"],
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceVSphereNetworksRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*VSphereClient).vimClient
+
+	dcID := d.Get("datacenter_id").(string)
+	dc, err := datacenterFromID(client, dcID)
+	if err != nil {
+		return fmt.Errorf("cannot locate datacenter: %s", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	folders, err := dc.Folders(ctx)
+	if err != nil {
+		return fmt.Errorf("error fetching folders for datacenter %q: %s", dcID, err)
+	}
+	refs, err := network.List(client, folders.NetworkFolder.Reference())
+	if err != nil {
+		return fmt.Errorf("error listing networks in datacenter %q: %s", dcID, err)
+	}
+
+	var nameRe *regexp.Regexp
+	if v, ok := d.GetOk("name_regex"); ok {
+		nameRe = regexp.MustCompile(v.(string))
+	}
+	var networks []map[string]interface{}
+	for _, ref := range refs {
+		attrs, err := networkAttributes(client, ref)
+		if err != nil {
+			return err
+		}
+		if nameRe != nil && !nameRe.MatchString(attrs["name"].(string)) {
+			continue
+		}
+		if networkAttributesMatch(d, attrs) {
+			networks = append(networks, attrs)
+		}
+	}
+	sort.Slice(networks, func(i, j int) bool {
+		if networks[i]["name"] != networks[j]["name"] {
+			return networks[i]["name"].(string) < networks[j]["name"].(string)
+		}
+		return networks[i]["id"].(string) < networks[j]["id"].(string)
+	})
+
+	if err := d.Set("networks", networks); err != nil {
+		return err
+	}
+	d.SetId(dcID)
+	return nil
+}
@@ -0,0 +1,122 @@
+package vsphere
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccDataSourceVSphereNetworks_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccSkipIfEsxi(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceVSphereNetworksConfig(`name_regex = "^terraform-test-pg[12]$"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.vsphere_networks.nets", "networks.#", "2"),
+					resource.TestCheckResourceAttrPair(
+						"data.vsphere_networks.nets", "networks.0.id",
+						"vsphere_distributed_port_group.pg1", "id",
+					),
+					resource.TestCheckResourceAttr("data.vsphere_networks.nets", "networks.0.type", "DistributedVirtualPortgroup"),
+					resource.TestCheckResourceAttr("data.vsphere_networks.nets", "networks.0.vlan_id", "1000"),
+					resource.TestCheckResourceAttrPair(
+						"data.vsphere_networks.nets", "networks.1.id",
+						"vsphere_distributed_port_group.pg2", "id",
+					),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceVSphereNetworks_untaggedVlan(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccSkipIfEsxi(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceVSphereNetworksConfig(`name_regex = "^terraform-test-pg"
+  vlan_id    = 0`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.vsphere_networks.nets", "networks.#", "1"),
+					resource.TestCheckResourceAttrPair(
+						"data.vsphere_networks.nets", "networks.0.id",
+						"vsphere_distributed_port_group.pg3", "id",
+					),
+					resource.TestCheckResourceAttr("data.vsphere_networks.nets", "networks.0.vlan_id", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceVSphereNetworksConfig(filter string) string {
+	return fmt.Sprintf(`
+variable "datacenter" {
+  default = "%s"
+}
+
+data "vsphere_datacenter" "dc" {
+  name = "${var.datacenter}"
+}
+
+resource "vsphere_distributed_virtual_switch" "dvs" {
+  name          = "terraform-test-dvs"
+  datacenter_id = "${data.vsphere_datacenter.dc.id}"
+}
+
+resource "vsphere_distributed_port_group" "pg1" {
+  name                            = "terraform-test-pg1"
+  distributed_virtual_switch_uuid = "${vsphere_distributed_virtual_switch.dvs.id}"
+  vlan_id                         = 1000
+}
+
+resource "vsphere_distributed_port_group" "pg2" {
+  name                            = "terraform-test-pg2"
+  distributed_virtual_switch_uuid = "${vsphere_distributed_virtual_switch.dvs.id}"
+  vlan_id                         = 1001
+}
+
+resource "vsphere_distributed_port_group" "pg3" {
+  name                            = "terraform-test-pg3"
+  distributed_virtual_switch_uuid = "${vsphere_distributed_virtual_switch.dvs.id}"
+}
+
+resource "vsphere_distributed_port_group" "pg4" {
+  name                            = "terraform-test-pg4"
+  distributed_virtual_switch_uuid = "${vsphere_distributed_virtual_switch.dvs.id}"
+
+  vlan_range {
+    min_vlan = 2000
+    max_vlan = 2099
+  }
+}
+
+data "vsphere_networks" "nets" {
+  datacenter_id                   = "${data.vsphere_datacenter.dc.id}"
+  distributed_virtual_switch_uuid = "${vsphere_distributed_virtual_switch.dvs.id}"
+  type                            = "DistributedVirtualPortgroup"
+  %s
+
+  depends_on = [
+    "vsphere_distributed_port_group.pg1",
+    "vsphere_distributed_port_group.pg2",
+    "vsphere_distributed_port_group.pg3",
+    "vsphere_distributed_port_group.pg4",
+  ]
+}
+`,
+		os.Getenv("VSPHERE_DATACENTER"),
+		filter,
+	)
+}
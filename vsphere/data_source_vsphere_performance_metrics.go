@@ -0,0 +1,213 @@
+package vsphere
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/structure"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+func dataSourceVSpherePerformanceMetrics() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceVSpherePerformanceMetricsRead,
+
+		Schema: map[string]*schema.Schema{
+			"entity_id": {
+				Type:        schema.TypeString,
+				Description: "The managed object ID of the entity to query metrics for.",
+				Required:    true,
+			},
+			"entity_type": {
+				Type:        schema.TypeString,
+				Description: "The managed object type of the entity, such as HostSystem, VirtualMachine, ClusterComputeResource, or Datastore.",
+				Required:    true,
+			},
+			"counters": {
+				Type:        schema.TypeList,
+				Description: "The names of the performance counters to query, such as cpu.usage.average.",
+				Required:    true,
+				MinItems:    1,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"instance": {
+				Type:        schema.TypeString,
+				Description: "The instance to query. Leave empty for the aggregate value, or use * for all instances.",
+				Optional:    true,
+			},
+			"interval": {
+				Type:         schema.TypeInt,
+				Description:  "The sampling interval, in seconds. Use 20 for real-time statistics, or the interval of a historical statistics level, such as 300.",
+				Optional:     true,
+				Default:      20,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"sample_count": {
+				Type:         schema.TypeInt,
+				Description:  "The number of most recent samples to query.",
+				Optional:     true,
+				Default:      15,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"metrics": {
+				Type:        schema.TypeList,
+				Description: "The values of the queried counters.",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"counter": {
+							Type:        schema.TypeString,
+							Description: "The name of the counter.",
+							Computed:    true,
+						},
+						"instance": {
+							Type:        schema.TypeString,
+							Description: "The instance of the counter. Empty for the aggregate value.",
+							Computed:    true,
+						},
+						"unit": {
+							Type:        schema.TypeString,
+							Description: "The unit of the counter, such as percent or kiloBytes.",
+							Computed:    true,
+						},
+						"values": {
+							Type:        schema.TypeList,
+							Description: "The sampled values, oldest first.",
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeInt},
+						},
+						"latest": {
+							Type:        schema.TypeInt,
+							Description: "The most recent sampled value.",
+							Computed:    true,
+						},
+						"average": {
+							Type:        schema.TypeFloat,
+							Description: "The average of the sampled values.",
+							Computed:    true,
+						},
+						"minimum": {
+							Type:        schema.TypeInt,
+							Description: "The smallest sampled value.",
+							Computed:    true,
+						},
+						"maximum": {
+							Type:        schema.TypeInt,
+							Description: "The largest sampled value.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceVSpherePerformanceMetricsRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*VSphereClient).vimClient
+	names := structure.SliceInterfacesToStrings(d.Get("counters").([]interface{}))
+	counters, err := perfCountersByName(client, names)
+	if err != nil {
+		return err
+	}
+	entity := types.ManagedObjectReference{
+		Type:  d.Get("entity_type").(string),
+		Value: d.Get("entity_id").(string),
+	}
+	spec := types.PerfQuerySpec{
+		Entity:     entity,
+		MaxSample:  int32(d.Get("sample_count").(int)),
+		IntervalId: int32(d.Get("interval").(int)),
+		Format:     string(types.PerfFormatNormal),
+	}
+	namesByKey := make(map[int32]string)
+	for _, name := range names {
+		info := counters[name]
+		namesByKey[info.Key] = name
+		spec.MetricId = append(spec.MetricId, types.PerfMetricId{
+			CounterId: info.Key,
+			Instance:  d.Get("instance").(string),
+		})
+	}
+
+	res, err := queryPerf(client, []types.PerfQuerySpec{spec})
+	if err != nil {
+		return fmt.Errorf("error querying performance metrics for %s %q: %s", entity.Type, entity.Value, err)
+	}
+	var series []*types.PerfMetricIntSeries
+	for _, base := range res {
+		em, ok := base.(*types.PerfEntityMetric)
+		if !ok {
+			continue
+		}
+		for _, v := range em.Value {
+			if s, ok := v.(*types.PerfMetricIntSeries); ok {
+				series = append(series, s)
+			}
+		}
+	}
+	// Order the series by the order the counters were supplied in, then by
+	// instance.
+	order := make(map[string]int)
+	for i, name := range names {
+		order[name] = i
+	}
+	sort.SliceStable(series, func(i, j int) bool {
+		oi, oj := order[namesByKey[series[i].Id.CounterId]], order[namesByKey[series[j].Id.CounterId]]
+		if oi != oj {
+			return oi < oj
+		}
+		return series[i].Id.Instance < series[j].Id.Instance
+	})
+
+	var metrics []interface{}
+	for _, s := range series {
+		name := namesByKey[s.Id.CounterId]
+		m := flattenPerfMetricIntSeries(s.Value)
+		m["counter"] = name
+		m["instance"] = s.Id.Instance
+		m["unit"] = counters[name].UnitInfo.GetElementDescription().Key
+		metrics = append(metrics, m)
+	}
+	if err := d.Set("metrics", metrics); err != nil {
+		return fmt.Errorf("error setting metrics: %s", err)
+	}
+	d.SetId(time.Now().UTC().String())
+	return nil
+}
+
+// flattenPerfMetricIntSeries returns the values of a metric series and their
+// aggregates. Values of -1, which vSphere uses for samples with no data, are
+// skipped.
+func flattenPerfMetricIntSeries(samples []int64) map[string]interface{} {
+	var values []int
+	var sum, min, max int64
+	for _, v := range samples {
+		if v < 0 {
+			continue
+		}
+		if len(values) == 0 || v < min {
+			min = v
+		}
+		if len(values) == 0 || v > max {
+			max = v
+		}
+		sum += v
+		values = append(values, int(v))
+	}
+	m := map[string]interface{}{
+		"values":  values,
+		"latest":  0,
+		"average": 0.0,
+		"minimum": int(min),
+		"maximum": int(max),
+	}
+	if len(values) > 0 {
+		m["latest"] = values[len(values)-1]
+		m["average"] = float64(sum) / float64(len(values))
+	}
+	return m
+}
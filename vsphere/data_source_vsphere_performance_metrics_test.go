@@ -0,0 +1,80 @@
+package vsphere
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccDataSourceVSpherePerformanceMetrics_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccDataSourceVSpherePerformanceMetricsPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceVSpherePerformanceMetricsConfig(`["cpu.usage.average", "mem.usage.average"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.vsphere_performance_metrics.metrics", "metrics.#", "2"),
+					resource.TestCheckResourceAttr("data.vsphere_performance_metrics.metrics", "metrics.0.counter", "cpu.usage.average"),
+					resource.TestCheckResourceAttr("data.vsphere_performance_metrics.metrics", "metrics.0.unit", "percent"),
+					resource.TestMatchResourceAttr("data.vsphere_performance_metrics.metrics", "metrics.0.values.#", regexp.MustCompile("^[1-9][0-9]*$")),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceVSpherePerformanceMetrics_unknownCounter(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccDataSourceVSpherePerformanceMetricsPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccDataSourceVSpherePerformanceMetricsConfig(`["cpu.bogus.average"]`),
+				ExpectError: regexp.MustCompile("unknown performance counters: cpu.bogus.average"),
+			},
+		},
+	})
+}
+
+func testAccDataSourceVSpherePerformanceMetricsPreCheck(t *testing.T) {
+	if os.Getenv("VSPHERE_DATACENTER") == "" {
+		t.Skip("set VSPHERE_DATACENTER to run vsphere_performance_metrics acceptance tests")
+	}
+	if os.Getenv("VSPHERE_ESXI_HOST") == "" {
+		t.Skip("set VSPHERE_ESXI_HOST to run vsphere_performance_metrics acceptance tests")
+	}
+}
+
+func testAccDataSourceVSpherePerformanceMetricsConfig(counters string) string {
+	return fmt.Sprintf(`
+data "vsphere_datacenter" "dc" {
+  name = "%s"
+}
+
+data "vsphere_host" "host" {
+  name          = "%s"
+  datacenter_id = "${data.vsphere_datacenter.dc.id}"
+}
+
+data "vsphere_performance_metrics" "metrics" {
+  entity_id    = "${data.vsphere_host.host.id}"
+  entity_type  = "HostSystem"
+  counters     = %s
+  sample_count = 5
+}
+`,
+		os.Getenv("VSPHERE_DATACENTER"),
+		os.Getenv("VSPHERE_ESXI_HOST"),
+		counters,
+	)
+}
@@ -0,0 +1,93 @@
+package vsphere
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+func dataSourceVSphereRole() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceVSphereRoleRead,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:          schema.TypeString,
+				Description:   "The name of the role, such as Admin or ReadOnly.",
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"label"},
+			},
+			"label": {
+				Type:          schema.TypeString,
+				Description:   "The display label of the role, such as Administrator or Read-only.",
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"name"},
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Description: "The description of the role.",
+				Computed:    true,
+			},
+			"system": {
+				Type:        schema.TypeBool,
+				Description: "Whether or not the role is a built-in system role.",
+				Computed:    true,
+			},
+			"role_privileges": {
+				Type:        schema.TypeList,
+				Description: "The privileges granted by the role.",
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func dataSourceVSphereRoleRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*VSphereClient).vimClient
+	name := d.Get("name").(string)
+	label := d.Get("label").(string)
+	if name == "" && label == "" {
+		return errors.New("one of name or label must be specified")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	roles, err := authorizationManager(client).RoleList(ctx)
+	if err != nil {
+		return fmt.Errorf("error fetching role list: %s", err)
+	}
+	var role *types.AuthorizationRole
+	for i := range roles {
+		r := &roles[i]
+		if name != "" && r.Name == name {
+			role = r
+			break
+		}
+		if label != "" && r.Info != nil && r.Info.GetDescription().Label == label {
+			role = r
+			break
+		}
+	}
+	if role == nil {
+		return fmt.Errorf("role not found")
+	}
+
+	d.SetId(strconv.Itoa(int(role.RoleId)))
+	d.Set("name", role.Name)
+	d.Set("system", role.System)
+	if role.Info != nil {
+		d.Set("label", role.Info.GetDescription().Label)
+		d.Set("description", role.Info.GetDescription().Summary)
+	}
+	if err := d.Set("role_privileges", role.Privilege); err != nil {
+		return fmt.Errorf("error saving results to state: %s", err)
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package vsphere
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccDataSourceVSphereRole_byName(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceVSphereRoleConfigName(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.vsphere_role.role", "id", "-2"),
+					resource.TestCheckResourceAttr("data.vsphere_role.role", "system", "true"),
+					resource.TestMatchResourceAttr("data.vsphere_role.role", "label", regexp.MustCompile(".+")),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceVSphereRole_byLabel(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceVSphereRoleConfigLabel(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.vsphere_role.role", "name", "ReadOnly"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceVSphereRoleConfigName() string {
+	return `
+data "vsphere_role" "role" {
+  name = "ReadOnly"
+}
+`
+}
+
+func testAccDataSourceVSphereRoleConfigLabel() string {
+	return `
+data "vsphere_role" "role" {
+  label = "Read-only"
+}
+`
+}
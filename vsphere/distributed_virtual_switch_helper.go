@@ -2,9 +2,14 @@ package vsphere
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
 
 	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/network"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/structure"
 	"github.com/vmware/govmomi"
 	"github.com/vmware/govmomi/find"
 	"github.com/vmware/govmomi/object"
@@ -144,3 +149,260 @@ func enableDVSNetworkResourceManagement(client *govmomi.Client, dvs *object.Vmwa
 
 	return nil
 }
+
+// updateDVSLacpGroupConfig exposes the UpdateDVSLacpGroupConfig_Task method
+// of the VmwareDistributedVirtualSwitch MO, which manages the link
+// aggregation groups on a DVS running the multipleLag LACP API.
+func updateDVSLacpGroupConfig(client *govmomi.Client, dvs *object.VmwareDistributedVirtualSwitch, specs []types.VMwareDvsLacpGroupSpec) error {
+	req := &types.UpdateDVSLacpGroupConfig_Task{
+		This:          dvs.Reference(),
+		LacpGroupSpec: specs,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	resp, err := methods.UpdateDVSLacpGroupConfig_Task(ctx, client, req)
+	if err != nil {
+		return err
+	}
+	task := object.NewTask(client.Client, resp.Returnval)
+	tctx, tcancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer tcancel()
+	if err := task.Wait(tctx); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// updateDVSHealthCheckConfig exposes the UpdateDVSHealthCheckConfig_Task
+// method of the DistributedVirtualSwitch MO, which manages the VLAN, MTU, and
+// teaming health checks on a DVS.
+func updateDVSHealthCheckConfig(client *govmomi.Client, dvs *object.VmwareDistributedVirtualSwitch, config []types.BaseDVSHealthCheckConfig) error {
+	req := &types.UpdateDVSHealthCheckConfig_Task{
+		This:              dvs.Reference(),
+		HealthCheckConfig: config,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	resp, err := methods.UpdateDVSHealthCheckConfig_Task(ctx, client, req)
+	if err != nil {
+		return err
+	}
+	task := object.NewTask(client.Client, resp.Returnval)
+	tctx, tcancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer tcancel()
+	if err := task.Wait(tctx); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// reconfigureDVSVmVnicNetworkResourcePool exposes the
+// DvsReconfigureVmVnicNetworkResourcePool_Task method of the
+// DistributedVirtualSwitch MO, which manages the network resource pools used
+// by virtual machine network adapters under network I/O control version 3.
+func reconfigureDVSVmVnicNetworkResourcePool(client *govmomi.Client, dvs *object.VmwareDistributedVirtualSwitch, specs []types.DvsVmVnicResourcePoolConfigSpec) error {
+	req := &types.DvsReconfigureVmVnicNetworkResourcePool_Task{
+		This:       dvs.Reference(),
+		ConfigSpec: specs,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	resp, err := methods.DvsReconfigureVmVnicNetworkResourcePool_Task(ctx, client, req)
+	if err != nil {
+		return err
+	}
+	task := object.NewTask(client.Client, resp.Returnval)
+	tctx, tcancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer tcancel()
+	if err := task.Wait(tctx); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// dvsUplinkPorts returns the uplink ports on a DVS, optionally restricted to
+// the ports of the supplied hosts.
+func dvsUplinkPorts(dvs *object.VmwareDistributedVirtualSwitch, hosts ...types.ManagedObjectReference) ([]types.DistributedVirtualPort, error) {
+	criteria := &types.DistributedVirtualSwitchPortCriteria{
+		UplinkPort: structure.BoolPtr(true),
+		Host:       hosts,
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	return dvs.FetchDVPorts(ctx, criteria)
+}
+
+// dvsUplinkPortNames returns a map of the keys of all uplink ports on a DVS
+// to their uplink names.
+func dvsUplinkPortNames(dvs *object.VmwareDistributedVirtualSwitch) (map[string]string, error) {
+	ports, err := dvsUplinkPorts(dvs)
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[string]string)
+	for _, port := range ports {
+		names[port.Key] = port.Config.Name
+	}
+	return names, nil
+}
+
+// dvsPortFromKey fetches a port on a DVS by its key. nil is returned if the
+// port cannot be found.
+func dvsPortFromKey(dvs *object.VmwareDistributedVirtualSwitch, key string) (*types.DistributedVirtualPort, error) {
+	criteria := &types.DistributedVirtualSwitchPortCriteria{
+		PortKey: []string{key},
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	ports, err := dvs.FetchDVPorts(ctx, criteria)
+	if err != nil {
+		return nil, err
+	}
+	for _, port := range ports {
+		if port.Key == key {
+			return &port, nil
+		}
+	}
+	return nil, nil
+}
+
+// reconfigureDVSPorts exposes the ReconfigureDVPort_Task method of the
+// DistributedVirtualSwitch MO, which changes the settings of individual
+// ports.
+func reconfigureDVSPorts(client *govmomi.Client, dvs *object.VmwareDistributedVirtualSwitch, specs []types.DVPortConfigSpec) error {
+	req := &types.ReconfigureDVPort_Task{
+		This: dvs.Reference(),
+		Port: specs,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	resp, err := methods.ReconfigureDVPort_Task(ctx, client, req)
+	if err != nil {
+		return err
+	}
+	task := object.NewTask(client.Client, resp.Returnval)
+	tctx, tcancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer tcancel()
+	if err := task.Wait(tctx); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// exportDVSEntities exposes the DVSManagerExportEntity_Task method of the
+// DistributedVirtualSwitchManager MO, which backs up the configuration of the
+// selected DVS and port groups.
+func exportDVSEntities(client *govmomi.Client, selection []types.BaseSelectionSet) ([]types.EntityBackupConfig, error) {
+	req := &types.DVSManagerExportEntity_Task{
+		This:         *client.ServiceContent.DvSwitchManager,
+		SelectionSet: selection,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	resp, err := methods.DVSManagerExportEntity_Task(ctx, client, req)
+	if err != nil {
+		return nil, err
+	}
+	task := object.NewTask(client.Client, resp.Returnval)
+	tctx, tcancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer tcancel()
+	info, err := task.WaitForResult(tctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return info.Result.(types.ArrayOfEntityBackupConfig).EntityBackupConfig, nil
+}
+
+// importDVSEntities exposes the DVSManagerImportEntity_Task method of the
+// DistributedVirtualSwitchManager MO, which restores the configuration of a
+// DVS and port groups from a backup.
+func importDVSEntities(client *govmomi.Client, backup []types.EntityBackupConfig, importType types.EntityImportType) (*types.DistributedVirtualSwitchManagerImportResult, error) {
+	req := &types.DVSManagerImportEntity_Task{
+		This:         *client.ServiceContent.DvSwitchManager,
+		EntityBackup: backup,
+		ImportType:   string(importType),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	resp, err := methods.DVSManagerImportEntity_Task(ctx, client, req)
+	if err != nil {
+		return nil, err
+	}
+	task := object.NewTask(client.Client, resp.Returnval)
+	tctx, tcancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer tcancel()
+	info, err := task.WaitForResult(tctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	result := info.Result.(types.DistributedVirtualSwitchManagerImportResult)
+	if len(result.ImportFault) > 0 {
+		var errs []string
+		for _, f := range result.ImportFault {
+			errs = append(errs, fmt.Sprintf("%s %q: %s", f.EntityType, f.Key, f.Fault.LocalizedMessage))
+		}
+		return nil, fmt.Errorf("errors importing entities: %s", strings.Join(errs, ", "))
+	}
+	return &result, nil
+}
+
+// dvsPortgroupKeys returns the keys of all non-uplink port groups on a DVS.
+func dvsPortgroupKeys(client *govmomi.Client, dvs *object.VmwareDistributedVirtualSwitch) ([]string, error) {
+	props, err := dvsProperties(dvs)
+	if err != nil {
+		return nil, err
+	}
+	if len(props.Portgroup) < 1 {
+		return nil, nil
+	}
+	var pgs []mo.DistributedVirtualPortgroup
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	if err := client.PropertyCollector().Retrieve(ctx, props.Portgroup, []string{"key", "config.uplink"}, &pgs); err != nil {
+		return nil, err
+	}
+	var keys []string
+	for _, pg := range pgs {
+		if pg.Config.Uplink != nil && *pg.Config.Uplink {
+			continue
+		}
+		keys = append(keys, pg.Key)
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+// readDVSBackupFile reads a DVS configuration backup written by
+// writeDVSBackupFile.
+func readDVSBackupFile(path string) ([]types.EntityBackupConfig, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var backup []types.EntityBackupConfig
+	if err := json.Unmarshal(b, &backup); err != nil {
+		return nil, fmt.Errorf("error parsing backup file %q: %s", path, err)
+	}
+	return backup, nil
+}
+
+// writeDVSBackupFile writes a DVS configuration backup to a local file.
+func writeDVSBackupFile(path string, backup []types.EntityBackupConfig) error {
+	b, err := json.MarshalIndent(backup, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, b, 0600)
+}
@@ -15,6 +15,29 @@ var lacpAPIVersionAllowedValues = []string{
 	string(types.VMwareDvsLacpApiVersionMultipleLag),
 }
 
+var lacpGroupLoadBalanceAlgorithmAllowedValues = []string{
+	string(types.VMwareDvsLacpLoadBalanceAlgorithmSrcMac),
+	string(types.VMwareDvsLacpLoadBalanceAlgorithmDestMac),
+	string(types.VMwareDvsLacpLoadBalanceAlgorithmSrcDestMac),
+	string(types.VMwareDvsLacpLoadBalanceAlgorithmDestIpVlan),
+	string(types.VMwareDvsLacpLoadBalanceAlgorithmSrcIpVlan),
+	string(types.VMwareDvsLacpLoadBalanceAlgorithmSrcDestIpVlan),
+	string(types.VMwareDvsLacpLoadBalanceAlgorithmDestTcpUdpPort),
+	string(types.VMwareDvsLacpLoadBalanceAlgorithmSrcTcpUdpPort),
+	string(types.VMwareDvsLacpLoadBalanceAlgorithmSrcDestTcpUdpPort),
+	string(types.VMwareDvsLacpLoadBalanceAlgorithmDestIpTcpUdpPort),
+	string(types.VMwareDvsLacpLoadBalanceAlgorithmSrcIpTcpUdpPort),
+	string(types.VMwareDvsLacpLoadBalanceAlgorithmSrcDestIpTcpUdpPort),
+	string(types.VMwareDvsLacpLoadBalanceAlgorithmDestIpTcpUdpPortVlan),
+	string(types.VMwareDvsLacpLoadBalanceAlgorithmSrcIpTcpUdpPortVlan),
+	string(types.VMwareDvsLacpLoadBalanceAlgorithmSrcDestIpTcpUdpPortVlan),
+	string(types.VMwareDvsLacpLoadBalanceAlgorithmDestIp),
+	string(types.VMwareDvsLacpLoadBalanceAlgorithmSrcIp),
+	string(types.VMwareDvsLacpLoadBalanceAlgorithmSrcDestIp),
+	string(types.VMwareDvsLacpLoadBalanceAlgorithmVlan),
+	string(types.VMwareDvsLacpLoadBalanceAlgorithmSrcPortId),
+}
+
 var multicastFilteringModeAllowedValues = []string{
 	string(types.VMwareDvsMulticastFilteringModeLegacyFiltering),
 	string(types.VMwareDvsMulticastFilteringModeSnooping),
@@ -87,10 +110,65 @@ func schemaVMwareDVSConfigSpec() map[string]*schema.Schema {
 						Description:  "The managed object ID of the host this specification applies to.",
 						ValidateFunc: validation.NoZeroValues,
 					},
+					"uplinks": {
+						Type:        schema.TypeList,
+						Description: "The names of the uplinks to assign the devices to, in the same order as devices.",
+						Optional:    true,
+						Elem:        &schema.Schema{Type: schema.TypeString},
+					},
+					"vmknic_migration": {
+						Type:        schema.TypeList,
+						Description: "A VMkernel network adapter to migrate to a port group on the DVS, in the same operation that assigns the devices.",
+						Optional:    true,
+						Elem: &schema.Resource{
+							Schema: map[string]*schema.Schema{
+								"device": {
+									Type:         schema.TypeString,
+									Required:     true,
+									Description:  "The name of the VMkernel network adapter, such as vmk0.",
+									ValidateFunc: validation.NoZeroValues,
+								},
+								"portgroup_key": {
+									Type:         schema.TypeString,
+									Required:     true,
+									Description:  "The key of the DVS port group to migrate the adapter to.",
+									ValidateFunc: validation.NoZeroValues,
+								},
+							},
+						},
+					},
 				},
 			},
 		},
 
+		// VMwareDVSHealthCheckConfig
+		"teaming_health_check_enabled": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Computed:    true,
+			Description: "Enables the teaming and failover health check, which checks that the teaming policy on the DVS matches the configuration of the physical switch.",
+		},
+		"teaming_health_check_interval": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Computed:     true,
+			Description:  "The interval, in minutes, between teaming and failover health checks.",
+			ValidateFunc: validation.IntAtLeast(1),
+		},
+		"vlan_mtu_health_check_enabled": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Computed:    true,
+			Description: "Enables the VLAN and MTU health check, which checks that the VLANs and MTU on the DVS match the configuration of the physical switch.",
+		},
+		"vlan_mtu_health_check_interval": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Computed:     true,
+			Description:  "The interval, in minutes, between VLAN and MTU health checks.",
+			ValidateFunc: validation.IntAtLeast(1),
+		},
+
 		// VMwareIpfixConfig (Netflow)
 		"netflow_active_flow_timeout": {
 			Type:         schema.TypeInt,
@@ -135,6 +213,83 @@ func schemaVMwareDVSConfigSpec() map[string]*schema.Schema {
 			ValidateFunc: validation.IntAtLeast(0),
 		},
 
+		// VMwareDVSPvlanMapEntry
+		"pvlan_mapping": {
+			Type:        schema.TypeSet,
+			Optional:    true,
+			Description: "A private VLAN (PVLAN) mapping.",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"primary_vlan_id": {
+						Type:         schema.TypeInt,
+						Required:     true,
+						Description:  "The primary VLAN ID. The VLAN IDs of 0 and 4095 are reserved and cannot be used in this property.",
+						ValidateFunc: validation.IntBetween(1, 4094),
+					},
+					"secondary_vlan_id": {
+						Type:         schema.TypeInt,
+						Required:     true,
+						Description:  "The secondary VLAN ID. The VLAN IDs of 0 and 4095 are reserved and cannot be used in this property.",
+						ValidateFunc: validation.IntBetween(1, 4094),
+					},
+					"pvlan_type": {
+						Type:         schema.TypeString,
+						Required:     true,
+						Description:  "The private VLAN type. Valid values are promiscuous, community and isolated.",
+						ValidateFunc: validation.StringInSlice(privateVLANTypeAllowedValues, false),
+					},
+				},
+			},
+		},
+
+		// VMwareDvsLacpGroupConfig
+		"lacp_group": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			Description: "A link aggregation group on the DVS. Requires lacp_api_version to be multipleLag.",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"name": {
+						Type:         schema.TypeString,
+						Required:     true,
+						Description:  "The name of the link aggregation group. This name can be used in active_uplinks and standby_uplinks.",
+						ValidateFunc: validation.NoZeroValues,
+					},
+					"uplink_count": {
+						Type:         schema.TypeInt,
+						Required:     true,
+						Description:  "The number of uplink ports in the link aggregation group.",
+						ValidateFunc: validation.IntBetween(1, 32),
+					},
+					"mode": {
+						Type:         schema.TypeString,
+						Optional:     true,
+						Default:      string(types.VMwareUplinkLacpModeActive),
+						Description:  "The LACP mode of the link aggregation group. Can be one of active or passive.",
+						ValidateFunc: validation.StringInSlice(vmwareUplinkLacpPolicyModeAllowedValues, false),
+					},
+					"load_balancing_algorithm": {
+						Type:         schema.TypeString,
+						Optional:     true,
+						Default:      string(types.VMwareDvsLacpLoadBalanceAlgorithmSrcDestIpTcpUdpPortVlan),
+						Description:  "The load balancing algorithm of the link aggregation group, such as srcDestIpTcpUdpPortVlan or srcMac.",
+						ValidateFunc: validation.StringInSlice(lacpGroupLoadBalanceAlgorithmAllowedValues, false),
+					},
+					"key": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The key of the link aggregation group.",
+					},
+					"uplinks": {
+						Type:        schema.TypeList,
+						Computed:    true,
+						Description: "The names of the uplink ports in the link aggregation group.",
+						Elem:        &schema.Schema{Type: schema.TypeString},
+					},
+				},
+			},
+		},
+
 		// LinkDiscoveryProtocolConfig
 		"link_discovery_operation": &schema.Schema{
 			Type:         schema.TypeString,
@@ -261,23 +416,156 @@ func expandDistributedVirtualSwitchHostMemberConfigSpec(d map[string]interface{}
 	return obj
 }
 
+// distributedVirtualSwitchHostMemberUsesNetworkUpdate returns true if the
+// devices of a host entry need to be assigned through a host network update,
+// instead of through the DVS configuration. This is the case when the devices
+// are mapped to specific uplinks, or VMkernel network adapters need to be
+// migrated along with them.
+func distributedVirtualSwitchHostMemberUsesNetworkUpdate(d map[string]interface{}) bool {
+	return len(d["uplinks"].([]interface{})) > 0 || len(d["vmknic_migration"].([]interface{})) > 0
+}
+
+// expandHostNetworkConfigForDVSHostMember reads certain keys from a host
+// entry Set object map and returns a HostNetworkConfig that assigns the
+// devices of the entry to their uplinks on the DVS and migrates any
+// VMkernel network adapters, in a single update.
+//
+// uplinkKeys maps the uplink names of the DVS to the keys of the uplink ports
+// of the host. vswitches are the standard virtual switches on the host - the
+// devices are removed from any of these that currently use them.
+func expandHostNetworkConfigForDVSHostMember(d map[string]interface{}, dvsUUID string, uplinkKeys map[string]string, vswitches []types.HostVirtualSwitch) (*types.HostNetworkConfig, error) {
+	devices := structure.SliceInterfacesToStrings(d["devices"].([]interface{}))
+	uplinks := structure.SliceInterfacesToStrings(d["uplinks"].([]interface{}))
+	if len(uplinks) > 0 && len(uplinks) != len(devices) {
+		return nil, fmt.Errorf("host %q: uplinks must have the same number of entries as devices", d["host_system_id"])
+	}
+
+	backing := &types.DistributedVirtualSwitchHostMemberPnicBacking{}
+	for i, device := range devices {
+		spec := types.DistributedVirtualSwitchHostMemberPnicSpec{
+			PnicDevice: device,
+		}
+		if len(uplinks) > 0 {
+			key, ok := uplinkKeys[uplinks[i]]
+			if !ok {
+				return nil, fmt.Errorf("host %q: unknown uplink %q", d["host_system_id"], uplinks[i])
+			}
+			spec.UplinkPortKey = key
+		}
+		backing.PnicSpec = append(backing.PnicSpec, spec)
+	}
+	config := &types.HostNetworkConfig{
+		ProxySwitch: []types.HostProxySwitchConfig{
+			{
+				ChangeOperation: string(types.HostConfigChangeOperationEdit),
+				Uuid:            dvsUUID,
+				Spec: &types.HostProxySwitchSpec{
+					Backing: backing,
+				},
+			},
+		},
+	}
+
+	for _, vswitch := range vswitches {
+		spec, changed := expandHostVirtualSwitchSpecWithoutNics(vswitch.Spec, devices)
+		if !changed {
+			continue
+		}
+		config.Vswitch = append(config.Vswitch, types.HostVirtualSwitchConfig{
+			ChangeOperation: string(types.HostConfigChangeOperationEdit),
+			Name:            vswitch.Name,
+			Spec:            spec,
+		})
+	}
+
+	for _, v := range d["vmknic_migration"].([]interface{}) {
+		m := v.(map[string]interface{})
+		config.Vnic = append(config.Vnic, types.HostVirtualNicConfig{
+			ChangeOperation: string(types.HostConfigChangeOperationEdit),
+			Device:          m["device"].(string),
+			Spec: &types.HostVirtualNicSpec{
+				DistributedVirtualPort: &types.DistributedVirtualSwitchPortConnection{
+					SwitchUuid:   dvsUUID,
+					PortgroupKey: m["portgroup_key"].(string),
+				},
+			},
+		})
+	}
+	return config, nil
+}
+
+// expandHostVirtualSwitchSpecWithoutNics returns a copy of a standard virtual
+// switch spec with the supplied NICs removed from its bridge and its teaming
+// policy. The second return value is false if the switch does not use any of
+// the NICs.
+func expandHostVirtualSwitchSpecWithoutNics(spec types.HostVirtualSwitchSpec, nics []string) (*types.HostVirtualSwitchSpec, bool) {
+	bridge, ok := spec.Bridge.(*types.HostVirtualSwitchBondBridge)
+	if !ok {
+		return nil, false
+	}
+	remove := make(map[string]bool)
+	for _, nic := range nics {
+		remove[nic] = true
+	}
+	filter := func(s []string) []string {
+		var r []string
+		for _, v := range s {
+			if !remove[v] {
+				r = append(r, v)
+			}
+		}
+		return r
+	}
+
+	remaining := filter(bridge.NicDevice)
+	if len(remaining) == len(bridge.NicDevice) {
+		return nil, false
+	}
+	if len(remaining) > 0 {
+		nb := *bridge
+		nb.NicDevice = remaining
+		if nb.Beacon != nil && len(remaining) < 2 {
+			nb.Beacon = nil
+		}
+		spec.Bridge = &nb
+	} else {
+		spec.Bridge = nil
+	}
+	if spec.Policy != nil && spec.Policy.NicTeaming != nil && spec.Policy.NicTeaming.NicOrder != nil {
+		policy := *spec.Policy
+		teaming := *policy.NicTeaming
+		teaming.NicOrder = &types.HostNicOrderPolicy{
+			ActiveNic:  filter(teaming.NicOrder.ActiveNic),
+			StandbyNic: filter(teaming.NicOrder.StandbyNic),
+		}
+		policy.NicTeaming = &teaming
+		spec.Policy = &policy
+	}
+	return &spec, true
+}
+
 // flattenDistributedVirtualSwitchHostMemberConfigSpec reads various fields
 // from a DistributedVirtualSwitchHostMemberConfigSpec and returns a Set object
 // map.
 //
 // This is the flatten counterpart to
 // expandDistributedVirtualSwitchHostMemberConfigSpec.
-func flattenDistributedVirtualSwitchHostMember(obj types.DistributedVirtualSwitchHostMember) map[string]interface{} {
+//
+// uplinkNames maps the keys of uplink ports to their names, and is used to
+// flatten the uplinks that the devices are assigned to.
+func flattenDistributedVirtualSwitchHostMember(obj types.DistributedVirtualSwitchHostMember, uplinkNames map[string]string) map[string]interface{} {
 	d := make(map[string]interface{})
 	d["host_system_id"] = obj.Config.Host.Value
 
-	var devices []string
+	var devices, uplinks []string
 	backing := obj.Config.Backing.(*types.DistributedVirtualSwitchHostMemberPnicBacking)
 	for _, spec := range backing.PnicSpec {
 		devices = append(devices, spec.PnicDevice)
+		uplinks = append(uplinks, uplinkNames[spec.UplinkPortKey])
 	}
 
 	d["devices"] = devices
+	d["uplinks"] = uplinks
 
 	return d
 }
@@ -333,6 +621,16 @@ func expandSliceOfDistributedVirtualSwitchHostMemberConfigSpec(d *schema.Resourc
 		} else {
 			spec.Operation = string(types.ConfigSpecOperationEdit)
 		}
+		// Devices that are assigned through a host network update are left out
+		// of the DVS configuration. New hosts are added without any devices,
+		// and existing hosts keep their current devices until the update.
+		if distributedVirtualSwitchHostMemberUsesNetworkUpdate(nm) {
+			if found {
+				spec.Backing = nil
+			} else {
+				spec.Backing = &types.DistributedVirtualSwitchHostMemberPnicBacking{}
+			}
+		}
 		specs = append(specs, spec)
 	}
 
@@ -345,10 +643,26 @@ func expandSliceOfDistributedVirtualSwitchHostMemberConfigSpec(d *schema.Resourc
 //
 // This is the flatten counterpart to
 // expandSliceOfDistributedVirtualSwitchHostMemberConfigSpec.
-func flattenSliceOfDistributedVirtualSwitchHostMember(d *schema.ResourceData, members []types.DistributedVirtualSwitchHostMember) error {
+//
+// Uplinks are only flattened for hosts that have them set in the
+// configuration, and VMkernel network adapter migrations are carried over
+// from the configuration as they describe an operation rather than state.
+func flattenSliceOfDistributedVirtualSwitchHostMember(d *schema.ResourceData, members []types.DistributedVirtualSwitchHostMember, uplinkNames map[string]string) error {
+	current := make(map[string]map[string]interface{})
+	for _, v := range d.Get("host").(*schema.Set).List() {
+		m := v.(map[string]interface{})
+		current[m["host_system_id"].(string)] = m
+	}
 	var hosts []map[string]interface{}
 	for _, m := range members {
-		hosts = append(hosts, flattenDistributedVirtualSwitchHostMember(m))
+		host := flattenDistributedVirtualSwitchHostMember(m, uplinkNames)
+		if c, ok := current[m.Config.Host.Value]; !ok || len(c["uplinks"].([]interface{})) < 1 {
+			delete(host, "uplinks")
+		}
+		if c, ok := current[m.Config.Host.Value]; ok {
+			host["vmknic_migration"] = c["vmknic_migration"]
+		}
+		hosts = append(hosts, host)
 	}
 	if err := d.Set("host", hosts); err != nil {
 		return err
@@ -384,6 +698,49 @@ func flattenVMwareIpfixConfig(d *schema.ResourceData, obj *types.VMwareIpfixConf
 	return nil
 }
 
+// expandSliceOfVMwareDVSHealthCheckConfig reads certain ResourceData keys and
+// returns the health check configuration for a DVS.
+func expandSliceOfVMwareDVSHealthCheckConfig(d *schema.ResourceData) []types.BaseDVSHealthCheckConfig {
+	return []types.BaseDVSHealthCheckConfig{
+		&types.VMwareDVSVlanMtuHealthCheckConfig{
+			VMwareDVSHealthCheckConfig: types.VMwareDVSHealthCheckConfig{
+				DVSHealthCheckConfig: types.DVSHealthCheckConfig{
+					Enable:   structure.GetBool(d, "vlan_mtu_health_check_enabled"),
+					Interval: int32(d.Get("vlan_mtu_health_check_interval").(int)),
+				},
+			},
+		},
+		&types.VMwareDVSTeamingHealthCheckConfig{
+			VMwareDVSHealthCheckConfig: types.VMwareDVSHealthCheckConfig{
+				DVSHealthCheckConfig: types.DVSHealthCheckConfig{
+					Enable:   structure.GetBool(d, "teaming_health_check_enabled"),
+					Interval: int32(d.Get("teaming_health_check_interval").(int)),
+				},
+			},
+		},
+	}
+}
+
+// flattenSliceOfVMwareDVSHealthCheckConfig reads the health check
+// configuration of a DVS into the passed in ResourceData.
+func flattenSliceOfVMwareDVSHealthCheckConfig(d *schema.ResourceData, objs []types.BaseDVSHealthCheckConfig) error {
+	for _, obj := range objs {
+		var prefix string
+		switch obj.(type) {
+		case *types.VMwareDVSVlanMtuHealthCheckConfig:
+			prefix = "vlan_mtu"
+		case *types.VMwareDVSTeamingHealthCheckConfig:
+			prefix = "teaming"
+		default:
+			continue
+		}
+		config := obj.GetDVSHealthCheckConfig()
+		structure.SetBoolPtr(d, prefix+"_health_check_enabled", config.Enable)
+		d.Set(prefix+"_health_check_interval", config.Interval)
+	}
+	return nil
+}
+
 // schemaDvsHostInfrastructureTrafficResource returns the respective schema
 // keys for the various kinds of network I/O control traffic classes. The
 // schema items are generated dynamically off of the list of available traffic
@@ -530,6 +887,196 @@ func flattenDVSNameArrayUplinkPortPolicy(d *schema.ResourceData, obj *types.DVSN
 	return nil
 }
 
+// expandVMwareDVSPvlanMapEntry reads certain keys from a Set object map and
+// returns a VMwareDVSPvlanMapEntry.
+func expandVMwareDVSPvlanMapEntry(d map[string]interface{}) types.VMwareDVSPvlanMapEntry {
+	return types.VMwareDVSPvlanMapEntry{
+		PrimaryVlanId:   int32(d["primary_vlan_id"].(int)),
+		SecondaryVlanId: int32(d["secondary_vlan_id"].(int)),
+		PvlanType:       d["pvlan_type"].(string),
+	}
+}
+
+// flattenVMwareDVSPvlanMapEntry reads various fields from a
+// VMwareDVSPvlanMapEntry into a map.
+func flattenVMwareDVSPvlanMapEntry(obj types.VMwareDVSPvlanMapEntry) map[string]interface{} {
+	return map[string]interface{}{
+		"primary_vlan_id":   int(obj.PrimaryVlanId),
+		"secondary_vlan_id": int(obj.SecondaryVlanId),
+		"pvlan_type":        obj.PvlanType,
+	}
+}
+
+// expandSliceOfVMwareDVSPvlanConfigSpec compares the old and new values of
+// the pvlan_mapping set and returns the specs required to reconcile the
+// private VLAN map on the DVS.
+func expandSliceOfVMwareDVSPvlanConfigSpec(d *schema.ResourceData) []types.VMwareDVSPvlanConfigSpec {
+	o, n := d.GetChange("pvlan_mapping")
+	return expandSliceOfVMwareDVSPvlanConfigSpecFromEntries(
+		expandSliceOfVMwareDVSPvlanMapEntry(o.(*schema.Set)),
+		expandSliceOfVMwareDVSPvlanMapEntry(n.(*schema.Set)),
+	)
+}
+
+// expandSliceOfVMwareDVSPvlanMapEntry expands a pvlan_mapping set into a slice
+// of VMwareDVSPvlanMapEntry.
+func expandSliceOfVMwareDVSPvlanMapEntry(s *schema.Set) []types.VMwareDVSPvlanMapEntry {
+	var entries []types.VMwareDVSPvlanMapEntry
+	for _, v := range s.List() {
+		entries = append(entries, expandVMwareDVSPvlanMapEntry(v.(map[string]interface{})))
+	}
+	return entries
+}
+
+// expandSliceOfVMwareDVSPvlanConfigSpecFromEntries returns the specs required
+// to change a private VLAN map from oldEntries to newEntries. The private VLAN
+// map only supports adding and removing entries, so an entry whose primary
+// VLAN ID or type changes is removed and then added again.
+//
+// Promiscuous entries are the primary VLANs that the other entries depend on,
+// so they are removed last and added first.
+func expandSliceOfVMwareDVSPvlanConfigSpecFromEntries(oldEntries, newEntries []types.VMwareDVSPvlanMapEntry) []types.VMwareDVSPvlanConfigSpec {
+	oldSet := make(map[types.VMwareDVSPvlanMapEntry]bool)
+	for _, entry := range oldEntries {
+		oldSet[entry] = true
+	}
+	newSet := make(map[types.VMwareDVSPvlanMapEntry]bool)
+	for _, entry := range newEntries {
+		newSet[entry] = true
+	}
+
+	var removes, promiscuousRemoves, promiscuousAdds, adds []types.VMwareDVSPvlanConfigSpec
+	for _, entry := range oldEntries {
+		if newSet[entry] {
+			continue
+		}
+		spec := types.VMwareDVSPvlanConfigSpec{
+			PvlanEntry: entry,
+			Operation:  string(types.ConfigSpecOperationRemove),
+		}
+		if entry.PvlanType == string(types.VmwareDistributedVirtualSwitchPvlanPortTypePromiscuous) {
+			promiscuousRemoves = append(promiscuousRemoves, spec)
+			continue
+		}
+		removes = append(removes, spec)
+	}
+	for _, entry := range newEntries {
+		if oldSet[entry] {
+			continue
+		}
+		spec := types.VMwareDVSPvlanConfigSpec{
+			PvlanEntry: entry,
+			Operation:  string(types.ConfigSpecOperationAdd),
+		}
+		if entry.PvlanType == string(types.VmwareDistributedVirtualSwitchPvlanPortTypePromiscuous) {
+			promiscuousAdds = append(promiscuousAdds, spec)
+			continue
+		}
+		adds = append(adds, spec)
+	}
+
+	var specs []types.VMwareDVSPvlanConfigSpec
+	for _, s := range [][]types.VMwareDVSPvlanConfigSpec{removes, promiscuousRemoves, promiscuousAdds, adds} {
+		specs = append(specs, s...)
+	}
+	return specs
+}
+
+// flattenSliceOfVMwareDVSPvlanMapEntry sets the pvlan_mapping key in the
+// supplied ResourceData from the private VLAN map of a DVS.
+func flattenSliceOfVMwareDVSPvlanMapEntry(d *schema.ResourceData, entries []types.VMwareDVSPvlanMapEntry) error {
+	var s []interface{}
+	for _, entry := range entries {
+		s = append(s, flattenVMwareDVSPvlanMapEntry(entry))
+	}
+	if err := d.Set("pvlan_mapping", s); err != nil {
+		return fmt.Errorf("error setting pvlan_mapping: %s", err)
+	}
+	return nil
+}
+
+// expandVMwareDvsLacpGroupConfig reads certain keys from a list object map
+// and returns a VMwareDvsLacpGroupConfig.
+func expandVMwareDvsLacpGroupConfig(d map[string]interface{}) types.VMwareDvsLacpGroupConfig {
+	return types.VMwareDvsLacpGroupConfig{
+		Name:                 d["name"].(string),
+		UplinkNum:            int32(d["uplink_count"].(int)),
+		Mode:                 d["mode"].(string),
+		LoadbalanceAlgorithm: d["load_balancing_algorithm"].(string),
+	}
+}
+
+// flattenVMwareDvsLacpGroupConfig reads various fields from a
+// VMwareDvsLacpGroupConfig into a map.
+func flattenVMwareDvsLacpGroupConfig(obj types.VMwareDvsLacpGroupConfig) map[string]interface{} {
+	return map[string]interface{}{
+		"name":                     obj.Name,
+		"uplink_count":             int(obj.UplinkNum),
+		"mode":                     obj.Mode,
+		"load_balancing_algorithm": obj.LoadbalanceAlgorithm,
+		"key":                      obj.Key,
+		"uplinks":                  obj.UplinkName,
+	}
+}
+
+// expandSliceOfVMwareDvsLacpGroupSpec compares the link aggregation groups in
+// the ResourceData against the groups currently on the DVS. It returns the
+// specs required to edit and add groups, in that order, and separately the
+// specs required to remove groups, as removals need to wait until the uplink
+// order no longer references the removed groups. Groups are matched by name.
+func expandSliceOfVMwareDvsLacpGroupSpec(d *schema.ResourceData, current []types.VMwareDvsLacpGroupConfig) ([]types.VMwareDvsLacpGroupSpec, []types.VMwareDvsLacpGroupSpec) {
+	existing := make(map[string]types.VMwareDvsLacpGroupConfig)
+	for _, c := range current {
+		existing[c.Name] = c
+	}
+	wanted := make(map[string]bool)
+	var edits, adds []types.VMwareDvsLacpGroupSpec
+	for _, v := range d.Get("lacp_group").([]interface{}) {
+		obj := expandVMwareDvsLacpGroupConfig(v.(map[string]interface{}))
+		wanted[obj.Name] = true
+		c, ok := existing[obj.Name]
+		if !ok {
+			adds = append(adds, types.VMwareDvsLacpGroupSpec{
+				LacpGroupConfig: obj,
+				Operation:       string(types.ConfigSpecOperationAdd),
+			})
+			continue
+		}
+		if c.UplinkNum == obj.UplinkNum && c.Mode == obj.Mode && c.LoadbalanceAlgorithm == obj.LoadbalanceAlgorithm {
+			continue
+		}
+		obj.Key = c.Key
+		edits = append(edits, types.VMwareDvsLacpGroupSpec{
+			LacpGroupConfig: obj,
+			Operation:       string(types.ConfigSpecOperationEdit),
+		})
+	}
+	var removes []types.VMwareDvsLacpGroupSpec
+	for _, c := range current {
+		if wanted[c.Name] {
+			continue
+		}
+		removes = append(removes, types.VMwareDvsLacpGroupSpec{
+			LacpGroupConfig: types.VMwareDvsLacpGroupConfig{Key: c.Key},
+			Operation:       string(types.ConfigSpecOperationRemove),
+		})
+	}
+	return append(edits, adds...), removes
+}
+
+// flattenSliceOfVMwareDvsLacpGroupConfig sets the lacp_group key in the
+// supplied ResourceData from the link aggregation groups on a DVS.
+func flattenSliceOfVMwareDvsLacpGroupConfig(d *schema.ResourceData, groups []types.VMwareDvsLacpGroupConfig) error {
+	var s []interface{}
+	for _, group := range groups {
+		s = append(s, flattenVMwareDvsLacpGroupConfig(group))
+	}
+	if err := d.Set("lacp_group", s); err != nil {
+		return fmt.Errorf("error setting lacp_group: %s", err)
+	}
+	return nil
+}
+
 // expandVMwareDVSConfigSpec reads certain ResourceData keys and
 // returns a VMwareDVSConfigSpec.
 func expandVMwareDVSConfigSpec(d *schema.ResourceData) *types.VMwareDVSConfigSpec {
@@ -549,6 +1096,7 @@ func expandVMwareDVSConfigSpec(d *schema.ResourceData) *types.VMwareDVSConfigSpe
 		MaxMtu: int32(d.Get("max_mtu").(int)),
 		LinkDiscoveryProtocolConfig: expandLinkDiscoveryProtocolConfig(d),
 		IpfixConfig:                 expandVMwareIpfixConfig(d),
+		PvlanConfigSpec:             expandSliceOfVMwareDVSPvlanConfigSpec(d),
 		LacpApiVersion:              d.Get("lacp_api_version").(string),
 		MulticastFilteringMode:      d.Get("multicast_filtering_mode").(string),
 	}
@@ -561,7 +1109,9 @@ func expandVMwareDVSConfigSpec(d *schema.ResourceData) *types.VMwareDVSConfigSpe
 // This is the flatten counterpart to expandVMwareDVSConfigSpec, as the
 // configuration info from a DVS comes back as this type instead of a specific
 // ConfigSpec.
-func flattenVMwareDVSConfigInfo(d *schema.ResourceData, obj *types.VMwareDVSConfigInfo) error {
+//
+// uplinkNames maps the keys of the uplink ports on the DVS to their names.
+func flattenVMwareDVSConfigInfo(d *schema.ResourceData, obj *types.VMwareDVSConfigInfo, uplinkNames map[string]string) error {
 	d.Set("name", obj.Name)
 	d.Set("config_version", obj.ConfigVersion)
 	d.Set("description", obj.Description)
@@ -584,7 +1134,7 @@ func flattenVMwareDVSConfigInfo(d *schema.ResourceData, obj *types.VMwareDVSConf
 	if err := flattenVMwareDVSPortSetting(d, obj.DefaultPortConfig.(*types.VMwareDVSPortSetting)); err != nil {
 		return err
 	}
-	if err := flattenSliceOfDistributedVirtualSwitchHostMember(d, obj.Host); err != nil {
+	if err := flattenSliceOfDistributedVirtualSwitchHostMember(d, obj.Host, uplinkNames); err != nil {
 		return err
 	}
 	if err := flattenSliceOfDvsHostInfrastructureTrafficResource(d, obj.InfrastructureTrafficResourceConfig); err != nil {
@@ -599,6 +1149,15 @@ func flattenVMwareDVSConfigInfo(d *schema.ResourceData, obj *types.VMwareDVSConf
 	if err := flattenVMwareIpfixConfig(d, obj.IpfixConfig); err != nil {
 		return err
 	}
+	if err := flattenSliceOfVMwareDVSHealthCheckConfig(d, obj.HealthCheckConfig); err != nil {
+		return err
+	}
+	if err := flattenSliceOfVMwareDVSPvlanMapEntry(d, obj.PvlanConfig); err != nil {
+		return err
+	}
+	if err := flattenSliceOfVMwareDvsLacpGroupConfig(d, obj.LacpGroupConfig); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -0,0 +1,307 @@
+package vsphere
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+// testHostVirtualSwitchSpec returns a standard virtual switch spec with the
+// supplied NICs in its bridge, and the supplied active and standby NICs in
+// its teaming policy. A beacon is configured when the bridge has more than one
+// NIC.
+func testHostVirtualSwitchSpec(bridge, active, standby []string) types.HostVirtualSwitchSpec {
+	b := &types.HostVirtualSwitchBondBridge{
+		NicDevice: bridge,
+	}
+	if len(bridge) > 1 {
+		b.Beacon = &types.HostVirtualSwitchBeaconConfig{Interval: 1}
+	}
+	return types.HostVirtualSwitchSpec{
+		NumPorts: 128,
+		Bridge:   b,
+		Policy: &types.HostNetworkPolicy{
+			NicTeaming: &types.HostNicTeamingPolicy{
+				Policy: "loadbalance_srcid",
+				NicOrder: &types.HostNicOrderPolicy{
+					ActiveNic:  active,
+					StandbyNic: standby,
+				},
+			},
+		},
+	}
+}
+
+func TestExpandHostVirtualSwitchSpecWithoutNics(t *testing.T) {
+	cases := map[string]struct {
+		Spec            types.HostVirtualSwitchSpec
+		Nics            []string
+		ExpectedChanged bool
+		ExpectedBridge  types.BaseHostVirtualSwitchBridge
+		ExpectedActive  []string
+		ExpectedStandby []string
+	}{
+		"switch does not use the NICs": {
+			Spec:            testHostVirtualSwitchSpec([]string{"vmnic0"}, []string{"vmnic0"}, nil),
+			Nics:            []string{"vmnic1"},
+			ExpectedChanged: false,
+		},
+		"switch without a bridge": {
+			Spec:            types.HostVirtualSwitchSpec{NumPorts: 128},
+			Nics:            []string{"vmnic0"},
+			ExpectedChanged: false,
+		},
+		"NIC removed from bridge and NIC order": {
+			Spec:            testHostVirtualSwitchSpec([]string{"vmnic0", "vmnic1", "vmnic2"}, []string{"vmnic0", "vmnic1"}, []string{"vmnic2"}),
+			Nics:            []string{"vmnic1"},
+			ExpectedChanged: true,
+			ExpectedBridge: &types.HostVirtualSwitchBondBridge{
+				NicDevice: []string{"vmnic0", "vmnic2"},
+				Beacon:    &types.HostVirtualSwitchBeaconConfig{Interval: 1},
+			},
+			ExpectedActive:  []string{"vmnic0"},
+			ExpectedStandby: []string{"vmnic2"},
+		},
+		"beacon dropped below two NICs": {
+			Spec:            testHostVirtualSwitchSpec([]string{"vmnic0", "vmnic1"}, []string{"vmnic0"}, []string{"vmnic1"}),
+			Nics:            []string{"vmnic1"},
+			ExpectedChanged: true,
+			ExpectedBridge: &types.HostVirtualSwitchBondBridge{
+				NicDevice: []string{"vmnic0"},
+			},
+			ExpectedActive: []string{"vmnic0"},
+		},
+		"bridge removed with the last NIC": {
+			Spec:            testHostVirtualSwitchSpec([]string{"vmnic0", "vmnic1"}, []string{"vmnic0", "vmnic1"}, nil),
+			Nics:            []string{"vmnic0", "vmnic1"},
+			ExpectedChanged: true,
+			ExpectedBridge:  nil,
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			original := testDeepCopyHostVirtualSwitchSpec(tc.Spec)
+			actual, changed := expandHostVirtualSwitchSpecWithoutNics(tc.Spec, tc.Nics)
+			if !reflect.DeepEqual(original, tc.Spec) {
+				t.Fatalf("input spec was modified: expected %#v, got %#v", original, tc.Spec)
+			}
+			if changed != tc.ExpectedChanged {
+				t.Fatalf("expected changed to be %t, got %t", tc.ExpectedChanged, changed)
+			}
+			if !changed {
+				if actual != nil {
+					t.Fatalf("expected nil spec, got %#v", actual)
+				}
+				return
+			}
+			if !reflect.DeepEqual(tc.ExpectedBridge, actual.Bridge) {
+				t.Fatalf("expected bridge %#v, got %#v", tc.ExpectedBridge, actual.Bridge)
+			}
+			order := actual.Policy.NicTeaming.NicOrder
+			if !reflect.DeepEqual(tc.ExpectedActive, order.ActiveNic) {
+				t.Fatalf("expected active NICs %#v, got %#v", tc.ExpectedActive, order.ActiveNic)
+			}
+			if !reflect.DeepEqual(tc.ExpectedStandby, order.StandbyNic) {
+				t.Fatalf("expected standby NICs %#v, got %#v", tc.ExpectedStandby, order.StandbyNic)
+			}
+			if actual.Policy.NicTeaming.Policy != "loadbalance_srcid" || actual.NumPorts != 128 {
+				t.Fatalf("expected other settings to be preserved, got %#v", actual)
+			}
+		})
+	}
+}
+
+// testDeepCopyHostVirtualSwitchSpec copies the parts of a standard virtual
+// switch spec that expandHostVirtualSwitchSpecWithoutNics rewrites, so that
+// the input can be checked for modifications afterwards.
+func testDeepCopyHostVirtualSwitchSpec(spec types.HostVirtualSwitchSpec) types.HostVirtualSwitchSpec {
+	if b, ok := spec.Bridge.(*types.HostVirtualSwitchBondBridge); ok {
+		nb := *b
+		nb.NicDevice = append([]string(nil), b.NicDevice...)
+		if b.Beacon != nil {
+			beacon := *b.Beacon
+			nb.Beacon = &beacon
+		}
+		spec.Bridge = &nb
+	}
+	if spec.Policy != nil {
+		policy := *spec.Policy
+		if policy.NicTeaming != nil {
+			teaming := *policy.NicTeaming
+			if teaming.NicOrder != nil {
+				teaming.NicOrder = &types.HostNicOrderPolicy{
+					ActiveNic:  append([]string(nil), teaming.NicOrder.ActiveNic...),
+					StandbyNic: append([]string(nil), teaming.NicOrder.StandbyNic...),
+				}
+			}
+			policy.NicTeaming = &teaming
+		}
+		spec.Policy = &policy
+	}
+	return spec
+}
+
+func TestExpandHostNetworkConfigForDVSHostMember(t *testing.T) {
+	uplinkKeys := map[string]string{
+		"uplink1": "10",
+		"uplink2": "11",
+	}
+	vswitches := []types.HostVirtualSwitch{
+		{
+			Name: "vSwitch0",
+			Spec: testHostVirtualSwitchSpec([]string{"vmnic0", "vmnic1"}, []string{"vmnic0", "vmnic1"}, nil),
+		},
+		{
+			Name: "vSwitch1",
+			Spec: testHostVirtualSwitchSpec([]string{"vmnic2"}, []string{"vmnic2"}, nil),
+		},
+	}
+	d := map[string]interface{}{
+		"host_system_id": "host-1",
+		"devices":        []interface{}{"vmnic1"},
+		"uplinks":        []interface{}{"uplink2"},
+		"vmknic_migration": []interface{}{
+			map[string]interface{}{
+				"device":        "vmk1",
+				"portgroup_key": "dvportgroup-1",
+			},
+		},
+	}
+
+	config, err := expandHostNetworkConfigForDVSHostMember(d, "dvs-uuid", uplinkKeys, vswitches)
+	if err != nil {
+		t.Fatalf("bad: %s", err)
+	}
+
+	expectedBacking := &types.DistributedVirtualSwitchHostMemberPnicBacking{
+		PnicSpec: []types.DistributedVirtualSwitchHostMemberPnicSpec{
+			{PnicDevice: "vmnic1", UplinkPortKey: "11"},
+		},
+	}
+	if len(config.ProxySwitch) != 1 || config.ProxySwitch[0].Uuid != "dvs-uuid" {
+		t.Fatalf("expected one proxy switch edit for dvs-uuid, got %#v", config.ProxySwitch)
+	}
+	if !reflect.DeepEqual(expectedBacking, config.ProxySwitch[0].Spec.Backing) {
+		t.Fatalf("expected backing %#v, got %#v", expectedBacking, config.ProxySwitch[0].Spec.Backing)
+	}
+
+	if len(config.Vswitch) != 1 || config.Vswitch[0].Name != "vSwitch0" {
+		t.Fatalf("expected only vSwitch0 to be edited, got %#v", config.Vswitch)
+	}
+	bridge := config.Vswitch[0].Spec.Bridge.(*types.HostVirtualSwitchBondBridge)
+	if !reflect.DeepEqual([]string{"vmnic0"}, bridge.NicDevice) {
+		t.Fatalf("expected vSwitch0 bridge to be [vmnic0], got %#v", bridge.NicDevice)
+	}
+
+	expectedVnic := []types.HostVirtualNicConfig{
+		{
+			ChangeOperation: string(types.HostConfigChangeOperationEdit),
+			Device:          "vmk1",
+			Spec: &types.HostVirtualNicSpec{
+				DistributedVirtualPort: &types.DistributedVirtualSwitchPortConnection{
+					SwitchUuid:   "dvs-uuid",
+					PortgroupKey: "dvportgroup-1",
+				},
+			},
+		},
+	}
+	if !reflect.DeepEqual(expectedVnic, config.Vnic) {
+		t.Fatalf("expected VMkernel adapter config %#v, got %#v", expectedVnic, config.Vnic)
+	}
+}
+
+func TestExpandHostNetworkConfigForDVSHostMemberErrors(t *testing.T) {
+	cases := map[string]struct {
+		Devices []interface{}
+		Uplinks []interface{}
+	}{
+		"uplink count mismatch": {
+			Devices: []interface{}{"vmnic0", "vmnic1"},
+			Uplinks: []interface{}{"uplink1"},
+		},
+		"unknown uplink": {
+			Devices: []interface{}{"vmnic0"},
+			Uplinks: []interface{}{"uplink9"},
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			d := map[string]interface{}{
+				"host_system_id":   "host-1",
+				"devices":          tc.Devices,
+				"uplinks":          tc.Uplinks,
+				"vmknic_migration": []interface{}{},
+			}
+			if _, err := expandHostNetworkConfigForDVSHostMember(d, "dvs-uuid", map[string]string{"uplink1": "10"}, nil); err == nil {
+				t.Fatal("expected error, got none")
+			}
+		})
+	}
+}
+
+func TestExpandSliceOfVMwareDVSPvlanConfigSpecFromEntries(t *testing.T) {
+	promiscuous := func(id int32) types.VMwareDVSPvlanMapEntry {
+		return types.VMwareDVSPvlanMapEntry{PrimaryVlanId: id, SecondaryVlanId: id, PvlanType: "promiscuous"}
+	}
+	secondary := func(primary, id int32, pvlanType string) types.VMwareDVSPvlanMapEntry {
+		return types.VMwareDVSPvlanMapEntry{PrimaryVlanId: primary, SecondaryVlanId: id, PvlanType: pvlanType}
+	}
+	spec := func(op types.ConfigSpecOperation, entry types.VMwareDVSPvlanMapEntry) types.VMwareDVSPvlanConfigSpec {
+		return types.VMwareDVSPvlanConfigSpec{PvlanEntry: entry, Operation: string(op)}
+	}
+
+	cases := map[string]struct {
+		Old      []types.VMwareDVSPvlanMapEntry
+		New      []types.VMwareDVSPvlanMapEntry
+		Expected []types.VMwareDVSPvlanConfigSpec
+	}{
+		"no change": {
+			Old: []types.VMwareDVSPvlanMapEntry{promiscuous(1000), secondary(1000, 1001, "isolated")},
+			New: []types.VMwareDVSPvlanMapEntry{promiscuous(1000), secondary(1000, 1001, "isolated")},
+		},
+		"add primary and secondary": {
+			New: []types.VMwareDVSPvlanMapEntry{secondary(1000, 1001, "isolated"), promiscuous(1000)},
+			Expected: []types.VMwareDVSPvlanConfigSpec{
+				spec(types.ConfigSpecOperationAdd, promiscuous(1000)),
+				spec(types.ConfigSpecOperationAdd, secondary(1000, 1001, "isolated")),
+			},
+		},
+		"remove primary and secondary": {
+			Old: []types.VMwareDVSPvlanMapEntry{promiscuous(1000), secondary(1000, 1001, "isolated")},
+			Expected: []types.VMwareDVSPvlanConfigSpec{
+				spec(types.ConfigSpecOperationRemove, secondary(1000, 1001, "isolated")),
+				spec(types.ConfigSpecOperationRemove, promiscuous(1000)),
+			},
+		},
+		"type change": {
+			Old: []types.VMwareDVSPvlanMapEntry{promiscuous(1000), secondary(1000, 1001, "isolated")},
+			New: []types.VMwareDVSPvlanMapEntry{promiscuous(1000), secondary(1000, 1001, "community")},
+			Expected: []types.VMwareDVSPvlanConfigSpec{
+				spec(types.ConfigSpecOperationRemove, secondary(1000, 1001, "isolated")),
+				spec(types.ConfigSpecOperationAdd, secondary(1000, 1001, "community")),
+			},
+		},
+		"primary VLAN change": {
+			Old: []types.VMwareDVSPvlanMapEntry{promiscuous(1000), secondary(1000, 1001, "isolated")},
+			New: []types.VMwareDVSPvlanMapEntry{promiscuous(2000), secondary(2000, 1001, "isolated")},
+			Expected: []types.VMwareDVSPvlanConfigSpec{
+				spec(types.ConfigSpecOperationRemove, secondary(1000, 1001, "isolated")),
+				spec(types.ConfigSpecOperationRemove, promiscuous(1000)),
+				spec(types.ConfigSpecOperationAdd, promiscuous(2000)),
+				spec(types.ConfigSpecOperationAdd, secondary(2000, 1001, "isolated")),
+			},
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			actual := expandSliceOfVMwareDVSPvlanConfigSpecFromEntries(tc.Old, tc.New)
+			if !reflect.DeepEqual(tc.Expected, actual) {
+				t.Fatalf("expected %#v, got %#v", tc.Expected, actual)
+			}
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"reflect"
 	"time"
 
 	"github.com/vmware/govmomi"
@@ -126,8 +127,6 @@ func (w *virtualMachineCustomizationWaiter) wait(client *govmomi.Client, vm *obj
 // This is highly recommended when you expect the list of events to be large,
 // as there is no limit on returned events.
 func selectEventsForReference(client *govmomi.Client, ref types.ManagedObjectReference, eventTypes []string) ([]types.BaseEvent, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
-	defer cancel()
 	filter := types.EventFilterSpec{
 		Entity: &types.EventFilterSpecByEntity{
 			Entity:    ref,
@@ -135,6 +134,35 @@ func selectEventsForReference(client *govmomi.Client, ref types.ManagedObjectRef
 		},
 		EventTypeId: eventTypes,
 	}
+	return selectEventsForFilter(client, filter)
+}
+
+// selectEventsForFilter queries events matching the supplied filter. The
+// events are returned in ascending order of their key, which is the order
+// they were logged in.
+//
+// The server caps the number of returned events at 1000, regardless of the
+// MaxCount set in the filter.
+func selectEventsForFilter(client *govmomi.Client, filter types.EventFilterSpec) ([]types.BaseEvent, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
 	mgr := event.NewManager(client.Client)
-	return mgr.QueryEvents(ctx, filter)
+	events, err := mgr.QueryEvents(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	event.Sort(events)
+	return events, nil
+}
+
+// eventTypeID returns the type ID of an event. This is the event type ID for
+// extended events, or the name of the event type for all others.
+func eventTypeID(be types.BaseEvent) string {
+	switch e := be.(type) {
+	case *types.EventEx:
+		return e.EventTypeId
+	case *types.ExtendedEvent:
+		return e.EventTypeId
+	}
+	return reflect.TypeOf(be).Elem().Name()
 }
@@ -0,0 +1,67 @@
+package vsphere
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/hostsystem"
+	"github.com/vmware/govmomi"
+	"github.com/vmware/govmomi/property"
+	"github.com/vmware/govmomi/vim25/methods"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+// hostAutoStartManager wraps the AutoStartManager managed object of a host,
+// which controls the order that virtual machines are started and stopped in
+// when the host boots or shuts down. govmomi does not ship a higher-level
+// object for this manager.
+type hostAutoStartManager struct {
+	client *govmomi.Client
+	ref    types.ManagedObjectReference
+}
+
+// hostAutoStartManagerFromHostSystemID locates the AutoStartManager from a
+// specified HostSystem managed object ID.
+func hostAutoStartManagerFromHostSystemID(client *govmomi.Client, hsID string) (*hostAutoStartManager, error) {
+	hs, err := hostsystem.FromID(client, hsID)
+	if err != nil {
+		return nil, err
+	}
+	props, err := hostsystem.Properties(hs)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching host properties: %s", err)
+	}
+	if props.ConfigManager.AutoStartManager == nil {
+		return nil, fmt.Errorf("host %q does not support virtual machine autostart", hs.Name())
+	}
+	return &hostAutoStartManager{
+		client: client,
+		ref:    *props.ConfigManager.AutoStartManager,
+	}, nil
+}
+
+// Config returns the current autostart configuration of the host.
+func (m *hostAutoStartManager) Config() (*types.HostAutoStartManagerConfig, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	var props mo.HostAutoStartManager
+	pc := property.DefaultCollector(m.client.Client)
+	if err := pc.RetrieveOne(ctx, m.ref, []string{"config"}, &props); err != nil {
+		return nil, err
+	}
+	return &props.Config, nil
+}
+
+// Reconfigure sends the supplied autostart configuration to the host. Only
+// the virtual machines included in the spec are changed.
+func (m *hostAutoStartManager) Reconfigure(spec types.HostAutoStartManagerConfig) error {
+	req := types.ReconfigureAutostart{
+		This: m.ref,
+		Spec: spec,
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	_, err := methods.ReconfigureAutostart(ctx, m.client, &req)
+	return err
+}
@@ -0,0 +1,68 @@
+package vsphere
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/hostsystem"
+	"github.com/vmware/govmomi"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/methods"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+// hostFirewallSystemFromHostSystemID locates a HostFirewallSystem from a
+// specified HostSystem managed object ID.
+func hostFirewallSystemFromHostSystemID(client *govmomi.Client, hsID string) (*object.HostFirewallSystem, error) {
+	hs, err := hostsystem.FromID(client, hsID)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	return hs.ConfigManager().FirewallSystem(ctx)
+}
+
+// hostFirewallInfo returns the current firewall configuration for the
+// supplied HostFirewallSystem.
+func hostFirewallInfo(fs *object.HostFirewallSystem) (*types.HostFirewallInfo, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	info, err := fs.Info(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching host firewall properties: %s", err)
+	}
+	return info, nil
+}
+
+// hostFirewallRulesetFromKey locates a firewall ruleset on the supplied
+// HostFirewallSystem by its key. nil is returned if the ruleset cannot be
+// found.
+func hostFirewallRulesetFromKey(fs *object.HostFirewallSystem, key string) (*types.HostFirewallRuleset, error) {
+	info, err := hostFirewallInfo(fs)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, rs := range info.Ruleset {
+		if rs.Key == key {
+			return &rs, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// updateHostFirewallRuleset updates the allowed hosts for a specific
+// ruleset on the supplied HostFirewallSystem.
+func updateHostFirewallRuleset(fs *object.HostFirewallSystem, key string, spec types.HostFirewallRulesetRulesetSpec) error {
+	req := types.UpdateRuleset{
+		This: fs.Reference(),
+		Id:   key,
+		Spec: spec,
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	_, err := methods.UpdateRuleset(ctx, fs.Client(), &req)
+	return err
+}
@@ -0,0 +1,87 @@
+package vsphere
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/hostsystem"
+	"github.com/vmware/govmomi"
+	"github.com/vmware/govmomi/vim25/methods"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+// hostIscsiManager wraps the IscsiManager managed object of a host, which
+// handles port binding for iSCSI adapters. govmomi does not ship a
+// higher-level object for this manager.
+type hostIscsiManager struct {
+	client *govmomi.Client
+	ref    types.ManagedObjectReference
+}
+
+// hostIscsiManagerFromHostSystemID locates the IscsiManager from a specified
+// HostSystem managed object ID.
+func hostIscsiManagerFromHostSystemID(client *govmomi.Client, hsID string) (*hostIscsiManager, error) {
+	hs, err := hostsystem.FromID(client, hsID)
+	if err != nil {
+		return nil, err
+	}
+	props, err := hostsystem.Properties(hs)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching host properties: %s", err)
+	}
+	if props.ConfigManager.IscsiManager == nil {
+		return nil, fmt.Errorf("host %q does not support iSCSI port binding", hs.Name())
+	}
+	return &hostIscsiManager{
+		client: client,
+		ref:    *props.ConfigManager.IscsiManager,
+	}, nil
+}
+
+// BoundVnics returns the VMkernel adapters bound to the supplied iSCSI
+// adapter.
+func (m *hostIscsiManager) BoundVnics(device string) ([]string, error) {
+	req := types.QueryBoundVnics{
+		This:         m.ref,
+		IScsiHbaName: device,
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	res, err := methods.QueryBoundVnics(ctx, m.client, &req)
+	if err != nil {
+		return nil, err
+	}
+	var vnics []string
+	for _, info := range res.Returnval {
+		vnics = append(vnics, info.VnicDevice)
+	}
+	return vnics, nil
+}
+
+// BindVnic binds a VMkernel adapter to the supplied iSCSI adapter.
+func (m *hostIscsiManager) BindVnic(device, vnic string) error {
+	req := types.BindVnic{
+		This:         m.ref,
+		IScsiHbaName: device,
+		VnicDevice:   vnic,
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	_, err := methods.BindVnic(ctx, m.client, &req)
+	return err
+}
+
+// UnbindVnic removes a VMkernel adapter binding from the supplied iSCSI
+// adapter.
+func (m *hostIscsiManager) UnbindVnic(device, vnic string) error {
+	req := types.UnbindVnic{
+		This:         m.ref,
+		IScsiHbaName: device,
+		VnicDevice:   vnic,
+		Force:        false,
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	_, err := methods.UnbindVnic(ctx, m.client, &req)
+	return err
+}
@@ -0,0 +1,87 @@
+package vsphere
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/vmware/govmomi"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/methods"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+// hostLocalAccountManager returns the HostLocalAccountManager of a directly
+// connected ESXi host.
+func hostLocalAccountManager(client *govmomi.Client) (*object.HostAccountManager, error) {
+	ref := client.ServiceContent.AccountManager
+	if ref == nil {
+		return nil, fmt.Errorf("local account management is not supported on this endpoint")
+	}
+	return object.NewHostAccountManager(client.Client, *ref), nil
+}
+
+// hostLocalUserFromName looks up a local user on a directly connected ESXi
+// host, using the user directory. nil is returned if the user does not exist.
+func hostLocalUserFromName(client *govmomi.Client, name string) (*types.UserSearchResult, error) {
+	ref := client.ServiceContent.UserDirectory
+	if ref == nil {
+		return nil, fmt.Errorf("user directory is not supported on this endpoint")
+	}
+	req := types.RetrieveUserGroups{
+		This:       *ref,
+		SearchStr:  name,
+		ExactMatch: true,
+		FindUsers:  true,
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	res, err := methods.RetrieveUserGroups(ctx, client, &req)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range res.Returnval {
+		user, ok := r.(*types.UserSearchResult)
+		if !ok || user.Group {
+			continue
+		}
+		if user.Principal == name {
+			return user, nil
+		}
+	}
+	return nil, nil
+}
+
+// createHostLocalUser creates a local user on a directly connected ESXi host.
+func createHostLocalUser(client *govmomi.Client, spec *types.HostAccountSpec) error {
+	m, err := hostLocalAccountManager(client)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	return m.Create(ctx, spec)
+}
+
+// updateHostLocalUser updates the password and description of a local user
+// on a directly connected ESXi host.
+func updateHostLocalUser(client *govmomi.Client, spec *types.HostAccountSpec) error {
+	m, err := hostLocalAccountManager(client)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	return m.Update(ctx, spec)
+}
+
+// removeHostLocalUser removes a local user from a directly connected ESXi
+// host.
+func removeHostLocalUser(client *govmomi.Client, name string) error {
+	m, err := hostLocalAccountManager(client)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	return m.Remove(ctx, name)
+}
@@ -52,6 +52,19 @@ func hostVSwitchFromName(client *govmomi.Client, ns *object.HostNetworkSystem, n
 	return nil, fmt.Errorf("could not find virtual switch %s", name)
 }
 
+// hostVSwitchList returns all of the standard virtual switches on the
+// supplied HostNetworkSystem.
+func hostVSwitchList(client *govmomi.Client, ns *object.HostNetworkSystem) ([]types.HostVirtualSwitch, error) {
+	var mns mo.HostNetworkSystem
+	pc := client.PropertyCollector()
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	if err := pc.RetrieveOne(ctx, ns.Reference(), []string{"networkInfo.vswitch"}, &mns); err != nil {
+		return nil, fmt.Errorf("error fetching host network properties: %s", err)
+	}
+	return mns.NetworkInfo.Vswitch, nil
+}
+
 // hostPortGroupFromName locates a port group on the supplied HostNetworkSystem
 // by name.
 func hostPortGroupFromName(client *govmomi.Client, ns *object.HostNetworkSystem, name string) (*types.HostPortGroup, error) {
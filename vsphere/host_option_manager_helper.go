@@ -0,0 +1,91 @@
+package vsphere
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/hostsystem"
+	"github.com/vmware/govmomi"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+// hostOptionManagerFromHostSystemID locates the advanced option manager from
+// a specified HostSystem managed object ID.
+func hostOptionManagerFromHostSystemID(client *govmomi.Client, hsID string) (*object.OptionManager, error) {
+	hs, err := hostsystem.FromID(client, hsID)
+	if err != nil {
+		return nil, err
+	}
+	props, err := hostsystem.Properties(hs)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching host properties: %s", err)
+	}
+	if props.ConfigManager.AdvancedOption == nil {
+		return nil, fmt.Errorf("host %q does not support advanced options", hs.Name())
+	}
+	return object.NewOptionManager(client.Client, *props.ConfigManager.AdvancedOption), nil
+}
+
+// hostAdvancedOptions fetches the current values of the supplied advanced
+// options, keyed by option name.
+func hostAdvancedOptions(m *object.OptionManager, keys []string) (map[string]interface{}, error) {
+	result := make(map[string]interface{})
+	for _, key := range keys {
+		ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+		opts, err := m.Query(ctx, key)
+		cancel()
+		if err != nil {
+			return nil, fmt.Errorf("error querying advanced option %q: %s", key, err)
+		}
+		for _, opt := range opts {
+			o := opt.GetOptionValue()
+			if o.Key == key {
+				result[key] = o.Value
+			}
+		}
+	}
+	return result, nil
+}
+
+// updateHostAdvancedOptions sets the supplied advanced options on the host.
+// Values are converted to the type of the current value of each option, as
+// the host rejects values of the wrong type.
+func updateHostAdvancedOptions(m *object.OptionManager, values map[string]interface{}) error {
+	var keys []string
+	for k := range values {
+		keys = append(keys, k)
+	}
+	current, err := hostAdvancedOptions(m, keys)
+	if err != nil {
+		return err
+	}
+	var opts []types.BaseOptionValue
+	for k, v := range values {
+		cv, err := convertHostAdvancedOptionValue(current[k], v)
+		if err != nil {
+			return fmt.Errorf("error converting value for advanced option %q: %s", k, err)
+		}
+		opts = append(opts, &types.OptionValue{Key: k, Value: cv})
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	return m.Update(ctx, opts)
+}
+
+// convertHostAdvancedOptionValue converts v to the same type as current.
+func convertHostAdvancedOptionValue(current, v interface{}) (interface{}, error) {
+	s := fmt.Sprintf("%v", v)
+	switch current.(type) {
+	case int32:
+		n, err := strconv.ParseInt(s, 10, 32)
+		return int32(n), err
+	case int64:
+		return strconv.ParseInt(s, 10, 64)
+	case bool:
+		return strconv.ParseBool(s)
+	default:
+		return s, nil
+	}
+}
@@ -0,0 +1,122 @@
+package vsphere
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/hostsystem"
+	"github.com/vmware/govmomi"
+	"github.com/vmware/govmomi/property"
+	"github.com/vmware/govmomi/vim25/methods"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+// hostPciPassthruSystem wraps the PciPassthruSystem managed object of a host,
+// which controls DirectPath I/O and SR-IOV for PCI devices. govmomi does not
+// ship a higher-level object for this system.
+type hostPciPassthruSystem struct {
+	client *govmomi.Client
+	ref    types.ManagedObjectReference
+}
+
+// hostPciPassthruSystemFromHostSystemID locates the PciPassthruSystem from a
+// specified HostSystem managed object ID.
+func hostPciPassthruSystemFromHostSystemID(client *govmomi.Client, hsID string) (*hostPciPassthruSystem, error) {
+	hs, err := hostsystem.FromID(client, hsID)
+	if err != nil {
+		return nil, err
+	}
+	props, err := hostsystem.Properties(hs)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching host properties: %s", err)
+	}
+	if props.ConfigManager.PciPassthruSystem == nil {
+		return nil, fmt.Errorf("host %q does not support PCI passthrough", hs.Name())
+	}
+	return &hostPciPassthruSystem{
+		client: client,
+		ref:    *props.ConfigManager.PciPassthruSystem,
+	}, nil
+}
+
+// hostPciDevices returns the PCI devices present in the hardware of the
+// supplied host.
+func hostPciDevices(client *govmomi.Client, hsID string) ([]types.HostPciDevice, error) {
+	hs, err := hostsystem.FromID(client, hsID)
+	if err != nil {
+		return nil, err
+	}
+	props, err := hostsystem.Properties(hs)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching host properties: %s", err)
+	}
+	if props.Hardware == nil {
+		return nil, fmt.Errorf("hardware information not available for host %q", hs.Name())
+	}
+	return props.Hardware.PciDevice, nil
+}
+
+// Info returns the passthrough state of all PCI devices on the host.
+func (s *hostPciPassthruSystem) Info() ([]types.BaseHostPciPassthruInfo, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	var props mo.HostPciPassthruSystem
+	pc := property.DefaultCollector(s.client.Client)
+	if err := pc.RetrieveOne(ctx, s.ref, []string{"pciPassthruInfo"}, &props); err != nil {
+		return nil, err
+	}
+	return props.PciPassthruInfo, nil
+}
+
+// InfoForDevice returns the passthrough state of a single PCI device, or nil
+// if the device was not found.
+func (s *hostPciPassthruSystem) InfoForDevice(id string) (types.BaseHostPciPassthruInfo, error) {
+	infos, err := s.Info()
+	if err != nil {
+		return nil, err
+	}
+	for _, info := range infos {
+		if info.GetHostPciPassthruInfo().Id == id {
+			return info, nil
+		}
+	}
+	return nil, nil
+}
+
+// Refresh refreshes the passthrough state of the PCI devices on the host.
+func (s *hostPciPassthruSystem) Refresh() error {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	_, err := methods.Refresh(ctx, s.client, &types.Refresh{This: s.ref})
+	return err
+}
+
+// UpdatePassthruConfig sends the supplied passthrough configuration to the
+// host. Most changes only take effect once the host has been rebooted.
+func (s *hostPciPassthruSystem) UpdatePassthruConfig(config []types.BaseHostPciPassthruConfig) error {
+	req := types.UpdatePassthruConfig{
+		This:   s.ref,
+		Config: config,
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	_, err := methods.UpdatePassthruConfig(ctx, s.client, &req)
+	return err
+}
+
+// hostPciPassthruInfoRebootRequired returns true if the configured
+// passthrough state of a device differs from its active state, meaning the
+// host needs to be rebooted for the configuration to take effect.
+func hostPciPassthruInfoRebootRequired(info types.BaseHostPciPassthruInfo) bool {
+	base := info.GetHostPciPassthruInfo()
+	if sriov, ok := info.(*types.HostSriovInfo); ok && sriov.SriovCapable {
+		if sriov.SriovEnabled != sriov.SriovActive {
+			return true
+		}
+		if sriov.NumVirtualFunctionRequested != sriov.NumVirtualFunction {
+			return true
+		}
+	}
+	return base.PassthruEnabled != base.PassthruActive
+}
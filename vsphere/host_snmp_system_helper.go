@@ -0,0 +1,64 @@
+package vsphere
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/hostsystem"
+	"github.com/vmware/govmomi"
+	"github.com/vmware/govmomi/property"
+	"github.com/vmware/govmomi/vim25/methods"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+// hostSnmpSystem wraps the SnmpSystem managed object of a host. govmomi does
+// not ship a higher-level object for this system.
+type hostSnmpSystem struct {
+	client *govmomi.Client
+	ref    types.ManagedObjectReference
+}
+
+// hostSnmpSystemFromHostSystemID locates the SnmpSystem from a specified
+// HostSystem managed object ID.
+func hostSnmpSystemFromHostSystemID(client *govmomi.Client, hsID string) (*hostSnmpSystem, error) {
+	hs, err := hostsystem.FromID(client, hsID)
+	if err != nil {
+		return nil, err
+	}
+	props, err := hostsystem.Properties(hs)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching host properties: %s", err)
+	}
+	if props.ConfigManager.SnmpSystem == nil {
+		return nil, fmt.Errorf("host %q does not support SNMP", hs.Name())
+	}
+	return &hostSnmpSystem{
+		client: client,
+		ref:    *props.ConfigManager.SnmpSystem,
+	}, nil
+}
+
+// Configuration returns the current SNMP agent configuration of the host.
+func (s *hostSnmpSystem) Configuration() (*types.HostSnmpConfigSpec, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	var props mo.HostSnmpSystem
+	pc := property.DefaultCollector(s.client.Client)
+	if err := pc.RetrieveOne(ctx, s.ref, []string{"configuration"}, &props); err != nil {
+		return nil, err
+	}
+	return &props.Configuration, nil
+}
+
+// Reconfigure sends the supplied SNMP agent configuration to the host.
+func (s *hostSnmpSystem) Reconfigure(spec types.HostSnmpConfigSpec) error {
+	req := types.ReconfigureSnmpAgent{
+		This: s.ref,
+		Spec: spec,
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	_, err := methods.ReconfigureSnmpAgent(ctx, s.client, &req)
+	return err
+}
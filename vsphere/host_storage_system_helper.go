@@ -2,10 +2,17 @@ package vsphere
 
 import (
 	"context"
+	"fmt"
+	"strconv"
+	"strings"
 
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/esxcli"
 	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/hostsystem"
 	"github.com/vmware/govmomi"
 	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/methods"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
 )
 
 // hostStorageSystemFromHostSystemID locates a HostStorageSystem from a
@@ -19,3 +26,256 @@ func hostStorageSystemFromHostSystemID(client *govmomi.Client, hsID string) (*ob
 	defer cancel()
 	return hs.ConfigManager().StorageSystem(ctx)
 }
+
+// hostStorageSystemProperties is a convenience method that wraps fetching
+// the HostStorageSystem MO from its higher-level object.
+func hostStorageSystemProperties(ss *object.HostStorageSystem) (*mo.HostStorageSystem, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	var props mo.HostStorageSystem
+	if err := ss.Properties(ctx, ss.Reference(), nil, &props); err != nil {
+		return nil, fmt.Errorf("error querying storage system properties: %s", err)
+	}
+	return &props, nil
+}
+
+// hostSoftwareInternetScsiHba locates the software iSCSI adapter on the
+// supplied HostStorageSystem. nil is returned if the adapter is not present,
+// which is usually the case if software iSCSI has not been enabled yet.
+func hostSoftwareInternetScsiHba(ss *object.HostStorageSystem) (*types.HostInternetScsiHba, error) {
+	props, err := hostStorageSystemProperties(ss)
+	if err != nil {
+		return nil, err
+	}
+	if props.StorageDeviceInfo == nil {
+		return nil, nil
+	}
+	for _, hba := range props.StorageDeviceInfo.HostBusAdapter {
+		if iscsi, ok := hba.(*types.HostInternetScsiHba); ok && iscsi.IsSoftwareBased {
+			return iscsi, nil
+		}
+	}
+	return nil, nil
+}
+
+// updateHostSoftwareInternetScsiEnabled enables or disables software iSCSI on
+// the supplied HostStorageSystem.
+func updateHostSoftwareInternetScsiEnabled(ss *object.HostStorageSystem, enabled bool) error {
+	req := types.UpdateSoftwareInternetScsiEnabled{
+		This:    ss.Reference(),
+		Enabled: enabled,
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	_, err := methods.UpdateSoftwareInternetScsiEnabled(ctx, ss.Client(), &req)
+	return err
+}
+
+// addHostInternetScsiSendTargets adds send targets to an iSCSI adapter.
+func addHostInternetScsiSendTargets(ss *object.HostStorageSystem, device string, targets []types.HostInternetScsiHbaSendTarget) error {
+	req := types.AddInternetScsiSendTargets{
+		This:           ss.Reference(),
+		IScsiHbaDevice: device,
+		Targets:        targets,
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	_, err := methods.AddInternetScsiSendTargets(ctx, ss.Client(), &req)
+	return err
+}
+
+// removeHostInternetScsiSendTargets removes send targets from an iSCSI
+// adapter.
+func removeHostInternetScsiSendTargets(ss *object.HostStorageSystem, device string, targets []types.HostInternetScsiHbaSendTarget) error {
+	req := types.RemoveInternetScsiSendTargets{
+		This:           ss.Reference(),
+		IScsiHbaDevice: device,
+		Targets:        targets,
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	_, err := methods.RemoveInternetScsiSendTargets(ctx, ss.Client(), &req)
+	return err
+}
+
+// addHostInternetScsiStaticTargets adds static targets to an iSCSI adapter.
+func addHostInternetScsiStaticTargets(ss *object.HostStorageSystem, device string, targets []types.HostInternetScsiHbaStaticTarget) error {
+	req := types.AddInternetScsiStaticTargets{
+		This:           ss.Reference(),
+		IScsiHbaDevice: device,
+		Targets:        targets,
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	_, err := methods.AddInternetScsiStaticTargets(ctx, ss.Client(), &req)
+	return err
+}
+
+// removeHostInternetScsiStaticTargets removes static targets from an iSCSI
+// adapter.
+func removeHostInternetScsiStaticTargets(ss *object.HostStorageSystem, device string, targets []types.HostInternetScsiHbaStaticTarget) error {
+	req := types.RemoveInternetScsiStaticTargets{
+		This:           ss.Reference(),
+		IScsiHbaDevice: device,
+		Targets:        targets,
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	_, err := methods.RemoveInternetScsiStaticTargets(ctx, ss.Client(), &req)
+	return err
+}
+
+// updateHostInternetScsiAuthenticationProperties updates the adapter-level
+// CHAP settings of an iSCSI adapter.
+func updateHostInternetScsiAuthenticationProperties(ss *object.HostStorageSystem, device string, props types.HostInternetScsiHbaAuthenticationProperties) error {
+	req := types.UpdateInternetScsiAuthenticationProperties{
+		This:                     ss.Reference(),
+		IScsiHbaDevice:           device,
+		AuthenticationProperties: props,
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	_, err := methods.UpdateInternetScsiAuthenticationProperties(ctx, ss.Client(), &req)
+	return err
+}
+
+// rescanHostStorage rescans all host bus adapters for new storage devices,
+// and then rescans for new VMFS volumes.
+func rescanHostStorage(ss *object.HostStorageSystem) error {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	if err := ss.RescanAllHba(ctx); err != nil {
+		return fmt.Errorf("error rescanning host bus adapters: %s", err)
+	}
+	if err := ss.RescanVmfs(ctx); err != nil {
+		return fmt.Errorf("error rescanning VMFS volumes: %s", err)
+	}
+	return nil
+}
+
+// hostMultipathLogicalUnit bundles a multipath logical unit with the
+// canonical name of the SCSI LUN that it represents.
+type hostMultipathLogicalUnit struct {
+	CanonicalName string
+	types.HostMultipathInfoLogicalUnit
+}
+
+// hostMultipathLogicalUnits returns the multipath logical units on the
+// supplied HostStorageSystem, along with the canonical names of their LUNs.
+func hostMultipathLogicalUnits(ss *object.HostStorageSystem) ([]hostMultipathLogicalUnit, error) {
+	props, err := hostStorageSystemProperties(ss)
+	if err != nil {
+		return nil, err
+	}
+	if props.StorageDeviceInfo == nil || props.StorageDeviceInfo.MultipathInfo == nil {
+		return nil, nil
+	}
+
+	names := make(map[string]string)
+	for _, sl := range props.StorageDeviceInfo.ScsiLun {
+		lun := sl.GetScsiLun()
+		names[lun.Key] = lun.CanonicalName
+	}
+
+	var units []hostMultipathLogicalUnit
+	for _, lu := range props.StorageDeviceInfo.MultipathInfo.Lun {
+		units = append(units, hostMultipathLogicalUnit{
+			CanonicalName:                names[lu.Lun],
+			HostMultipathInfoLogicalUnit: lu,
+		})
+	}
+	return units, nil
+}
+
+// hostMultipathLogicalUnitFromCanonicalName locates a multipath logical unit
+// on the supplied HostStorageSystem by the canonical name of its LUN, such as
+// the ones returned by the vsphere_vmfs_disks data source. nil is returned if
+// the LUN cannot be found.
+func hostMultipathLogicalUnitFromCanonicalName(ss *object.HostStorageSystem, name string) (*hostMultipathLogicalUnit, error) {
+	units, err := hostMultipathLogicalUnits(ss)
+	if err != nil {
+		return nil, err
+	}
+	for _, lu := range units {
+		if lu.CanonicalName == name {
+			return &lu, nil
+		}
+	}
+	return nil, nil
+}
+
+// setHostMultipathLunPolicy sets the path selection policy of a multipath
+// logical unit.
+func setHostMultipathLunPolicy(ss *object.HostStorageSystem, lunID string, policy types.BaseHostMultipathInfoLogicalUnitPolicy) error {
+	req := types.SetMultipathLunPolicy{
+		This:   ss.Reference(),
+		LunId:  lunID,
+		Policy: policy,
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	_, err := methods.SetMultipathLunPolicy(ctx, ss.Client(), &req)
+	return err
+}
+
+// hostMultipathRoundRobinConfig is the device configuration of the Round
+// Robin path selection policy of a LUN. LimitType is one of default, iops, or
+// bytes.
+type hostMultipathRoundRobinConfig struct {
+	LimitType string
+	IOPS      int
+	Bytes     int
+}
+
+// hostEsxcliExecutor returns an esxcli executor for the host with the
+// supplied managed object ID.
+func hostEsxcliExecutor(client *govmomi.Client, hsID string) (*esxcli.Executor, error) {
+	hs, err := hostsystem.FromID(client, hsID)
+	if err != nil {
+		return nil, err
+	}
+	return esxcli.NewExecutor(client.Client, hs)
+}
+
+// getHostMultipathRoundRobinConfig reads the Round Robin device
+// configuration of a LUN. This is not exposed by the vSphere API, so it is
+// read through esxcli.
+func getHostMultipathRoundRobinConfig(e *esxcli.Executor, device string) (*hostMultipathRoundRobinConfig, error) {
+	res, err := e.Run("storage.nmp.psp.roundrobin.deviceconfig.get", map[string]string{
+		"device": device,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(res.Values) < 1 {
+		return nil, fmt.Errorf("no Round Robin configuration returned for device %q", device)
+	}
+	v := res.Values[0]
+	config := &hostMultipathRoundRobinConfig{
+		LimitType: strings.ToLower(v["LimitType"]),
+	}
+	if config.IOPS, err = strconv.Atoi(v["IOOperationLimit"]); err != nil {
+		return nil, fmt.Errorf("error parsing IOPS limit %q: %s", v["IOOperationLimit"], err)
+	}
+	if config.Bytes, err = strconv.Atoi(v["ByteLimit"]); err != nil {
+		return nil, fmt.Errorf("error parsing byte limit %q: %s", v["ByteLimit"], err)
+	}
+	return config, nil
+}
+
+// setHostMultipathRoundRobinConfig sets the Round Robin device configuration
+// of a LUN through esxcli. The LUN must already use the Round Robin policy.
+func setHostMultipathRoundRobinConfig(e *esxcli.Executor, device string, config *hostMultipathRoundRobinConfig) error {
+	args := map[string]string{
+		"device": device,
+		"type":   config.LimitType,
+	}
+	switch config.LimitType {
+	case "iops":
+		args["iops"] = strconv.Itoa(config.IOPS)
+	case "bytes":
+		args["bytes"] = strconv.Itoa(config.Bytes)
+	}
+	_, err := e.Run("storage.nmp.psp.roundrobin.deviceconfig.set", args)
+	return err
+}
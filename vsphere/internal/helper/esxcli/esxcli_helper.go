@@ -0,0 +1,193 @@
+package esxcli
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/provider"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+// esxcliVersion is the version of the esxcli API that methods are run
+// against.
+const esxcliVersion = "urn:vim25/5.0"
+
+// Executor runs esxcli methods on a host through the vSphere API, without the
+// need for SSH access to the host. It works on both vCenter and direct ESXi
+// connections.
+type Executor struct {
+	client *vim25.Client
+	host   *object.HostSystem
+	mme    types.ManagedObjectReference
+	moids  map[string]string
+}
+
+// Values is a single record returned by an esxcli method, keyed by field
+// name. Field names are the ones in the esxcli XML output, such as
+// IOOperationLimit.
+type Values map[string]string
+
+// Response is the response to an esxcli method. Methods that return records
+// populate Values, and methods that return a single value, such as most set
+// methods, populate String.
+type Response struct {
+	Values []Values
+	String string
+}
+
+// NewExecutor returns an Executor for the supplied host.
+func NewExecutor(client *vim25.Client, host *object.HostSystem) (*Executor, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), provider.DefaultAPITimeout)
+	defer cancel()
+
+	mme, err := retrieveManagedMethodExecuter(ctx, client, host.Reference())
+	if err != nil {
+		return nil, fmt.Errorf("error fetching method executer for host %q: %s", host.Reference().Value, err)
+	}
+	dtm, err := retrieveDynamicTypeManager(ctx, client, host.Reference())
+	if err != nil {
+		return nil, fmt.Errorf("error fetching type manager for host %q: %s", host.Reference().Value, err)
+	}
+	instances, err := dynamicTypeMgrQueryMoInstances(ctx, client, *dtm)
+	if err != nil {
+		return nil, fmt.Errorf("error listing esxcli namespaces for host %q: %s", host.Reference().Value, err)
+	}
+
+	e := &Executor{
+		client: client,
+		host:   host,
+		mme:    *mme,
+		moids:  make(map[string]string),
+	}
+	for _, instance := range instances {
+		e.moids[instance.MoType] = instance.ID
+	}
+	return e, nil
+}
+
+// Run runs an esxcli method, such as
+// storage.nmp.psp.roundrobin.deviceconfig.get, with the supplied arguments.
+// Arguments are keyed by their long option name, without the leading dashes.
+func (e *Executor) Run(method string, args map[string]string) (*Response, error) {
+	i := strings.LastIndex(method, ".")
+	if i < 0 {
+		return nil, fmt.Errorf("invalid esxcli method %q", method)
+	}
+	ns := "vim.EsxCLI." + method[:i]
+	moid, ok := e.moids[ns]
+	if !ok {
+		return nil, fmt.Errorf("esxcli namespace %q not found on host %q", method[:i], e.host.Reference().Value)
+	}
+
+	req := &executeSoapRequest{
+		This:    e.mme,
+		Moid:    moid,
+		Version: esxcliVersion,
+		Method:  "vim.EsxCLI." + method,
+	}
+	var names []string
+	for name := range args {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		val, err := encodeArgument(name, args[name])
+		if err != nil {
+			return nil, err
+		}
+		req.Argument = append(req.Argument, ReflectManagedMethodExecuterSoapArgument{
+			Name: name,
+			Val:  val,
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), provider.DefaultAPITimeout)
+	defer cancel()
+	res, err := executeSoap(ctx, e.client, req)
+	if err != nil {
+		return nil, err
+	}
+	if res == nil {
+		return &Response{}, nil
+	}
+	if res.Fault != nil {
+		msg := res.Fault.FaultMsg
+		if res.Fault.FaultDetail != "" {
+			msg = fmt.Sprintf("%s: %s", msg, res.Fault.FaultDetail)
+		}
+		return nil, fmt.Errorf("esxcli %s failed: %s", method, msg)
+	}
+	return ParseResponse(res.Response)
+}
+
+// encodeArgument encodes an argument value in the form expected by
+// ExecuteSoap, which is the value wrapped in an element with the name of the
+// argument.
+func encodeArgument(name, value string) (string, error) {
+	var b bytes.Buffer
+	if err := xml.EscapeText(&b, []byte(value)); err != nil {
+		return "", fmt.Errorf("error encoding esxcli argument %q: %s", name, err)
+	}
+	return fmt.Sprintf("<%s>%s</%s>", name, b.String(), name), nil
+}
+
+// responseNode is a generic XML element, used to decode the response of an
+// esxcli method.
+type responseNode struct {
+	XMLName xml.Name
+	Attrs   []xml.Attr     `xml:",any,attr"`
+	Content string         `xml:",chardata"`
+	Nodes   []responseNode `xml:",any"`
+}
+
+// ParseResponse decodes the XML response of an esxcli method. The response
+// is either a single value, a single record, or a list of records. Fields of
+// a record that are themselves lists or records are not decoded.
+func ParseResponse(s string) (*Response, error) {
+	r := &Response{}
+	if strings.TrimSpace(s) == "" {
+		return r, nil
+	}
+	var root responseNode
+	if err := xml.Unmarshal([]byte(s), &root); err != nil {
+		return nil, fmt.Errorf("error decoding esxcli response: %s", err)
+	}
+
+	switch {
+	case isList(root):
+		for _, node := range root.Nodes {
+			r.Values = append(r.Values, recordValues(node))
+		}
+	case len(root.Nodes) > 0:
+		r.Values = append(r.Values, recordValues(root))
+	default:
+		r.String = strings.TrimSpace(root.Content)
+	}
+	return r, nil
+}
+
+// isList returns true if a node is a list of records, which esxcli marks with
+// an ArrayOf type, such as ArrayOfDataObject.
+func isList(node responseNode) bool {
+	for _, attr := range node.Attrs {
+		if attr.Name.Local == "type" && strings.HasPrefix(attr.Value, "ArrayOf") {
+			return true
+		}
+	}
+	return false
+}
+
+// recordValues returns the children of a node as Values.
+func recordValues(node responseNode) Values {
+	v := make(Values)
+	for _, child := range node.Nodes {
+		v[child.XMLName.Local] = strings.TrimSpace(child.Content)
+	}
+	return v
+}
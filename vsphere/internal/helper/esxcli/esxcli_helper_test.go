@@ -0,0 +1,66 @@
+package esxcli
+
+import (
+	"reflect"
+	"testing"
+)
+
+type testParseResponse struct {
+	Name string
+
+	response string
+	expected *Response
+}
+
+func (tc *testParseResponse) Test(t *testing.T) {
+	actual, err := ParseResponse(tc.response)
+	if err != nil {
+		t.Fatalf("bad: %s", err)
+	}
+	if !reflect.DeepEqual(tc.expected, actual) {
+		t.Fatalf("expected %#v, got %#v", tc.expected, actual)
+	}
+}
+
+func TestParseResponse(t *testing.T) {
+	cases := []testParseResponse{
+		{
+			Name:     "empty",
+			response: "",
+			expected: &Response{},
+		},
+		{
+			Name:     "single value",
+			response: `<obj xmlns:xsd="http://www.w3.org/2001/XMLSchema" xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xmlns="urn:vim25" versionId="5.0" xsi:type="xsd:boolean">true</obj>`,
+			expected: &Response{String: "true"},
+		},
+		{
+			Name:     "record",
+			response: `<obj xmlns:xsd="http://www.w3.org/2001/XMLSchema" xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xmlns="urn:vim25" versionId="5.0" xsi:type="VimEsxCLIstoragenmppsproundrobindeviceconfiggetDeviceConfig"><ByteLimit>10485760</ByteLimit><Device>naa.6000</Device><IOOperationLimit>1</IOOperationLimit><LimitType>Iops</LimitType><UseActiveUnoptimizedPaths>false</UseActiveUnoptimizedPaths></obj>`,
+			expected: &Response{
+				Values: []Values{
+					{
+						"ByteLimit":                 "10485760",
+						"Device":                    "naa.6000",
+						"IOOperationLimit":          "1",
+						"LimitType":                 "Iops",
+						"UseActiveUnoptimizedPaths": "false",
+					},
+				},
+			},
+		},
+		{
+			Name:     "list",
+			response: `<obj xmlns:xsd="http://www.w3.org/2001/XMLSchema" xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xmlns="urn:vim25" versionId="5.0" xsi:type="ArrayOfDataObject"><DataObject xsi:type="VimEsxCLIstoragenmpdevicelistNmpDevice"><Device>naa.6000</Device></DataObject><DataObject xsi:type="VimEsxCLIstoragenmpdevicelistNmpDevice"><Device>naa.6001</Device></DataObject></obj>`,
+			expected: &Response{
+				Values: []Values{
+					{"Device": "naa.6000"},
+					{"Device": "naa.6001"},
+				},
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.Name, tc.Test)
+	}
+}
@@ -0,0 +1,171 @@
+package esxcli
+
+import (
+	"context"
+
+	"github.com/vmware/govmomi/vim25/soap"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+// The types and methods in this file are part of the internal vSphere API
+// that backs esxcli, and are not part of the public vim25 WSDL. They are
+// adapted from the internal package of govmomi, which uses them to implement
+// the esxcli command in govc.
+
+// ReflectManagedMethodExecuterSoapArgument is an argument to an esxcli
+// method.
+type ReflectManagedMethodExecuterSoapArgument struct {
+	types.DynamicData
+
+	Name string `xml:"name"`
+	Val  string `xml:"val"`
+}
+
+// ReflectManagedMethodExecuterSoapFault is the fault returned by an esxcli
+// method, if any.
+type ReflectManagedMethodExecuterSoapFault struct {
+	types.DynamicData
+
+	FaultMsg    string `xml:"faultMsg"`
+	FaultDetail string `xml:"faultDetail,omitempty"`
+}
+
+// ReflectManagedMethodExecuterSoapResult is the result of an esxcli method.
+type ReflectManagedMethodExecuterSoapResult struct {
+	types.DynamicData
+
+	Response string                                 `xml:"response,omitempty"`
+	Fault    *ReflectManagedMethodExecuterSoapFault `xml:"fault,omitempty"`
+}
+
+// DynamicTypeMgrMoInstance describes an instance of a managed object known to
+// the dynamic type manager of a host, such as an esxcli namespace.
+type DynamicTypeMgrMoInstance struct {
+	types.DynamicData
+
+	ID     string `xml:"id"`
+	MoType string `xml:"moType"`
+}
+
+type retrieveManagedMethodExecuterRequest struct {
+	This types.ManagedObjectReference `xml:"_this"`
+}
+
+type retrieveManagedMethodExecuterResponse struct {
+	Returnval *types.ManagedObjectReference `xml:"urn:vim25 returnval"`
+}
+
+type retrieveManagedMethodExecuterBody struct {
+	Req    *retrieveManagedMethodExecuterRequest  `xml:"urn:vim25 RetrieveManagedMethodExecuter,omitempty"`
+	Res    *retrieveManagedMethodExecuterResponse `xml:"urn:vim25 RetrieveManagedMethodExecuterResponse,omitempty"`
+	Fault_ *soap.Fault                            `xml:"http://schemas.xmlsoap.org/soap/envelope/ Fault,omitempty"`
+}
+
+func (b *retrieveManagedMethodExecuterBody) Fault() *soap.Fault { return b.Fault_ }
+
+// retrieveManagedMethodExecuter returns the ReflectManagedMethodExecuter of a
+// host, which executes esxcli methods.
+func retrieveManagedMethodExecuter(ctx context.Context, r soap.RoundTripper, host types.ManagedObjectReference) (*types.ManagedObjectReference, error) {
+	var reqBody, resBody retrieveManagedMethodExecuterBody
+
+	reqBody.Req = &retrieveManagedMethodExecuterRequest{This: host}
+
+	if err := r.RoundTrip(ctx, &reqBody, &resBody); err != nil {
+		return nil, err
+	}
+
+	return resBody.Res.Returnval, nil
+}
+
+type retrieveDynamicTypeManagerRequest struct {
+	This types.ManagedObjectReference `xml:"_this"`
+}
+
+type retrieveDynamicTypeManagerResponse struct {
+	Returnval *types.ManagedObjectReference `xml:"urn:vim25 returnval"`
+}
+
+type retrieveDynamicTypeManagerBody struct {
+	Req    *retrieveDynamicTypeManagerRequest  `xml:"urn:vim25 RetrieveDynamicTypeManager,omitempty"`
+	Res    *retrieveDynamicTypeManagerResponse `xml:"urn:vim25 RetrieveDynamicTypeManagerResponse,omitempty"`
+	Fault_ *soap.Fault                         `xml:"http://schemas.xmlsoap.org/soap/envelope/ Fault,omitempty"`
+}
+
+func (b *retrieveDynamicTypeManagerBody) Fault() *soap.Fault { return b.Fault_ }
+
+// retrieveDynamicTypeManager returns the InternalDynamicTypeManager of a
+// host, which lists the esxcli namespaces on the host.
+func retrieveDynamicTypeManager(ctx context.Context, r soap.RoundTripper, host types.ManagedObjectReference) (*types.ManagedObjectReference, error) {
+	var reqBody, resBody retrieveDynamicTypeManagerBody
+
+	reqBody.Req = &retrieveDynamicTypeManagerRequest{This: host}
+
+	if err := r.RoundTrip(ctx, &reqBody, &resBody); err != nil {
+		return nil, err
+	}
+
+	return resBody.Res.Returnval, nil
+}
+
+type dynamicTypeMgrQueryMoInstancesRequest struct {
+	This types.ManagedObjectReference `xml:"_this"`
+}
+
+type dynamicTypeMgrQueryMoInstancesResponse struct {
+	Returnval []DynamicTypeMgrMoInstance `xml:"urn:vim25 returnval"`
+}
+
+type dynamicTypeMgrQueryMoInstancesBody struct {
+	Req    *dynamicTypeMgrQueryMoInstancesRequest  `xml:"urn:vim25 DynamicTypeMgrQueryMoInstances,omitempty"`
+	Res    *dynamicTypeMgrQueryMoInstancesResponse `xml:"urn:vim25 DynamicTypeMgrQueryMoInstancesResponse,omitempty"`
+	Fault_ *soap.Fault                             `xml:"http://schemas.xmlsoap.org/soap/envelope/ Fault,omitempty"`
+}
+
+func (b *dynamicTypeMgrQueryMoInstancesBody) Fault() *soap.Fault { return b.Fault_ }
+
+// dynamicTypeMgrQueryMoInstances lists the managed object instances known to
+// an InternalDynamicTypeManager.
+func dynamicTypeMgrQueryMoInstances(ctx context.Context, r soap.RoundTripper, dtm types.ManagedObjectReference) ([]DynamicTypeMgrMoInstance, error) {
+	var reqBody, resBody dynamicTypeMgrQueryMoInstancesBody
+
+	reqBody.Req = &dynamicTypeMgrQueryMoInstancesRequest{This: dtm}
+
+	if err := r.RoundTrip(ctx, &reqBody, &resBody); err != nil {
+		return nil, err
+	}
+
+	return resBody.Res.Returnval, nil
+}
+
+type executeSoapRequest struct {
+	This     types.ManagedObjectReference               `xml:"_this"`
+	Moid     string                                     `xml:"moid"`
+	Version  string                                     `xml:"version"`
+	Method   string                                     `xml:"method"`
+	Argument []ReflectManagedMethodExecuterSoapArgument `xml:"argument,omitempty"`
+}
+
+type executeSoapResponse struct {
+	Returnval *ReflectManagedMethodExecuterSoapResult `xml:"urn:vim25 returnval"`
+}
+
+type executeSoapBody struct {
+	Req    *executeSoapRequest  `xml:"urn:vim25 ExecuteSoap,omitempty"`
+	Res    *executeSoapResponse `xml:"urn:vim25 ExecuteSoapResponse,omitempty"`
+	Fault_ *soap.Fault          `xml:"http://schemas.xmlsoap.org/soap/envelope/ Fault,omitempty"`
+}
+
+func (b *executeSoapBody) Fault() *soap.Fault { return b.Fault_ }
+
+// executeSoap runs a method through a ReflectManagedMethodExecuter.
+func executeSoap(ctx context.Context, r soap.RoundTripper, req *executeSoapRequest) (*ReflectManagedMethodExecuterSoapResult, error) {
+	var reqBody, resBody executeSoapBody
+
+	reqBody.Req = req
+
+	if err := r.RoundTrip(ctx, &reqBody, &resBody); err != nil {
+		return nil, err
+	}
+
+	return resBody.Res.Returnval, nil
+}
@@ -103,6 +103,13 @@ func NameOrID(client *govmomi.Client, id string) string {
 // block until this is the case, depending on whether or not DRS is on or off
 // for the host's cluster. This parameter is ignored on direct ESXi.
 func EnterMaintenanceMode(host *object.HostSystem, timeout int, evacuate bool) error {
+	return EnterMaintenanceModeWithSpec(host, timeout, evacuate, nil)
+}
+
+// EnterMaintenanceModeWithSpec works like EnterMaintenanceMode, but also
+// takes a HostMaintenanceSpec, which can be used to control how vSAN data is
+// migrated off of the host.
+func EnterMaintenanceModeWithSpec(host *object.HostSystem, timeout int, evacuate bool, spec *types.HostMaintenanceSpec) error {
 	if err := viapi.VimValidateVirtualCenter(host.Client()); err != nil {
 		evacuate = false
 	}
@@ -111,7 +118,7 @@ func EnterMaintenanceMode(host *object.HostSystem, timeout int, evacuate bool) e
 
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*time.Duration(timeout))
 	defer cancel()
-	task, err := host.EnterMaintenanceMode(ctx, int32(timeout), evacuate, nil)
+	task, err := host.EnterMaintenanceMode(ctx, int32(timeout), evacuate, spec)
 	if err != nil {
 		return err
 	}
@@ -136,3 +143,13 @@ func ExitMaintenanceMode(host *object.HostSystem, timeout int) error {
 
 	return task.Wait(ctx)
 }
+
+// InMaintenanceMode checks the runtime state of a host to determine if it is
+// currently in maintenance mode.
+func InMaintenanceMode(host *object.HostSystem) (bool, error) {
+	props, err := Properties(host)
+	if err != nil {
+		return false, err
+	}
+	return props.Runtime.InMaintenanceMode, nil
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/vmware/govmomi/object"
 	"github.com/vmware/govmomi/view"
 	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
 )
 
 // FromPath loads a network via its path.
@@ -78,6 +79,36 @@ func FromID(client *govmomi.Client, id string) (object.NetworkReference, error)
 	return nil, fmt.Errorf("could not find network with ID %q", id)
 }
 
+// List returns the managed object references of all networks under the
+// supplied root object, such as the network folder of a datacenter.
+func List(client *govmomi.Client, root types.ManagedObjectReference) ([]types.ManagedObjectReference, error) {
+	m := view.NewManager(client.Client)
+
+	vctx, vcancel := context.WithTimeout(context.Background(), provider.DefaultAPITimeout)
+	defer vcancel()
+	v, err := m.CreateContainerView(vctx, root, []string{"Network"}, true)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		dctx, dcancel := context.WithTimeout(context.Background(), provider.DefaultAPITimeout)
+		defer dcancel()
+		v.Destroy(dctx)
+	}()
+
+	var networks []mo.Network
+	if err := v.Retrieve(vctx, []string{"Network"}, []string{"name"}, &networks); err != nil {
+		return nil, err
+	}
+
+	var refs []types.ManagedObjectReference
+	for _, net := range networks {
+		refs = append(refs, net.Reference())
+	}
+	return refs, nil
+}
+
 // ReferenceProperties is a convenience method that wraps fetching the Network
 // MO from a NetworkReference.
 //
@@ -22,44 +22,72 @@ import (
 // network backing to the managed object reference that represents the opaque
 // network in vCenter.
 func OpaqueNetworkFromNetworkID(client *govmomi.Client, id string) (*object.OpaqueNetwork, error) {
-	// We use the same ContainerView logic that we use with networkFromID, but we
-	// go a step further and limit it to opaque networks only.
-	m := view.NewManager(client.Client)
-
-	vctx, vcancel := context.WithTimeout(context.Background(), provider.DefaultAPITimeout)
-	defer vcancel()
-	v, err := m.CreateContainerView(vctx, client.ServiceContent.RootFolder, []string{"OpaqueNetwork"}, true)
+	nets, err := OpaqueNetworksFromNetworkID(client, client.ServiceContent.RootFolder, id)
 	if err != nil {
 		return nil, err
 	}
+	if len(nets) < 1 {
+		return nil, fmt.Errorf("could not find opaque network with ID %q", id)
+	}
+	return nets[0], nil
+}
 
-	defer func() {
-		dctx, dcancel := context.WithTimeout(context.Background(), provider.DefaultAPITimeout)
-		defer dcancel()
-		v.Destroy(dctx)
-	}()
-
-	var networks []mo.OpaqueNetwork
-	err = v.Retrieve(vctx, []string{"OpaqueNetwork"}, nil, &networks)
+// OpaqueNetworksFromNetworkID returns all opaque networks under the supplied
+// root object with the supplied opaque network ID.
+//
+// The same opaque network, such as an NSX-T segment, is represented by a
+// separate managed object in each datacenter it is visible in, so more than
+// one network may be returned when searching from the root folder.
+func OpaqueNetworksFromNetworkID(client *govmomi.Client, root types.ManagedObjectReference, id string) ([]*object.OpaqueNetwork, error) {
+	networks, err := List(client, root)
 	if err != nil {
 		return nil, err
 	}
 
+	var result []*object.OpaqueNetwork
 	for _, net := range networks {
 		if net.Summary.(*types.OpaqueNetworkSummary).OpaqueNetworkId == id {
 			ref := net.Reference()
 			finder := find.NewFinder(client.Client, false)
 			fctx, fcancel := context.WithTimeout(context.Background(), provider.DefaultAPITimeout)
-			defer fcancel()
 			nref, err := finder.ObjectReference(fctx, ref)
+			fcancel()
 			if err != nil {
 				return nil, err
 			}
-			// Should be safe to return here, as we have already asserted that this type
+			// Should be safe to assert here, as we have already asserted that this type
 			// should be a OpaqueNetwork by using ContainerView, along with relying
 			// on several fields that only an opaque network would have.
-			return nref.(*object.OpaqueNetwork), nil
+			result = append(result, nref.(*object.OpaqueNetwork))
 		}
 	}
-	return nil, fmt.Errorf("could not find opaque network with ID %q", id)
+	return result, nil
+}
+
+// List returns all opaque networks under the supplied root object, along with
+// their summaries.
+func List(client *govmomi.Client, root types.ManagedObjectReference) ([]mo.OpaqueNetwork, error) {
+	// We use the same ContainerView logic that we use with networkFromID, but we
+	// go a step further and limit it to opaque networks only.
+	m := view.NewManager(client.Client)
+
+	vctx, vcancel := context.WithTimeout(context.Background(), provider.DefaultAPITimeout)
+	defer vcancel()
+	v, err := m.CreateContainerView(vctx, root, []string{"OpaqueNetwork"}, true)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		dctx, dcancel := context.WithTimeout(context.Background(), provider.DefaultAPITimeout)
+		defer dcancel()
+		v.Destroy(dctx)
+	}()
+
+	var networks []mo.OpaqueNetwork
+	err = v.Retrieve(vctx, []string{"OpaqueNetwork"}, []string{"name", "summary"}, &networks)
+	if err != nil {
+		return nil, err
+	}
+	return networks, nil
 }
@@ -5,6 +5,7 @@ import (
 	"math"
 	"reflect"
 	"strconv"
+	"time"
 
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/vmware/govmomi/vim25/types"
@@ -584,6 +585,20 @@ func SetBatch(d *schema.ResourceData, attrs map[string]interface{}) error {
 	return nil
 }
 
+// ParseTimePtr parses an RFC3339 time string and returns a *time.Time. nil is
+// returned if the string is empty or cannot be parsed, so the string should be
+// validated already, such as with validation.ValidateRFC3339TimeString.
+func ParseTimePtr(s string) *time.Time {
+	if s == "" {
+		return nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return nil
+	}
+	return &t
+}
+
 // MoRefSorter is a sorting wrapper for a slice of MangedObjectReference.
 type MoRefSorter []types.ManagedObjectReference
 
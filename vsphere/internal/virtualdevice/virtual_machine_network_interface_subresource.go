@@ -85,6 +85,11 @@ func NetworkInterfaceSubresourceSchema() map[string]*schema.Schema {
 			Description:  "The ID of the network to connect this network interface to.",
 			ValidateFunc: validation.NoZeroValues,
 		},
+		"port_key": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "The key of a specific port on the distributed port group to connect this network interface to. Only valid when network_id is a DVS port group with static binding.",
+		},
 		"adapter_type": {
 			Type:         schema.TypeString,
 			Optional:     true,
@@ -581,16 +586,7 @@ func (r *NetworkInterfaceSubresource) Create(l object.VirtualDeviceList) ([]type
 		return nil, err
 	}
 
-	// govmomi has helpers that allow the easy fetching of a network's backing
-	// info, once we actually know what that backing is. Set all of that stuff up
-	// now.
-	net, err := network.FromID(r.client, r.Get("network_id").(string))
-	if err != nil {
-		return nil, err
-	}
-	bctx, bcancel := context.WithTimeout(context.Background(), provider.DefaultAPITimeout)
-	defer bcancel()
-	backing, err := net.EthernetCardBackingInfo(bctx)
+	backing, err := r.ethernetCardBackingInfo()
 	if err != nil {
 		return nil, err
 	}
@@ -678,17 +674,33 @@ func (r *NetworkInterfaceSubresource) Read(l object.VirtualDeviceList) error {
 		}
 		netID = backing.Network.Value
 	case *types.VirtualEthernetCardOpaqueNetworkBackingInfo:
-		onet, err := nsx.OpaqueNetworkFromNetworkID(r.client, backing.OpaqueNetworkId)
+		// The same opaque network can be visible in more than one datacenter, in
+		// which case the network currently in state is kept if it is one of
+		// them.
+		onets, err := nsx.OpaqueNetworksFromNetworkID(r.client, r.client.ServiceContent.RootFolder, backing.OpaqueNetworkId)
 		if err != nil {
 			return err
 		}
-		netID = onet.Reference().Value
+		if len(onets) < 1 {
+			return fmt.Errorf("could not find opaque network with ID %q", backing.OpaqueNetworkId)
+		}
+		netID = onets[0].Reference().Value
+		for _, onet := range onets {
+			if onet.Reference().Value == r.Get("network_id").(string) {
+				netID = onet.Reference().Value
+			}
+		}
 	case *types.VirtualEthernetCardDistributedVirtualPortBackingInfo:
 		pg, err := dvportgroup.FromKey(r.client, backing.Port.SwitchUuid, backing.Port.PortgroupKey)
 		if err != nil {
 			return err
 		}
 		netID = pg.Reference().Value
+		// port_key is only tracked if it was set in configuration, as vSphere
+		// assigns a port to every network interface on a DVS port group.
+		if r.Get("port_key").(string) != "" {
+			r.Set("port_key", backing.Port.PortKey)
+		}
 	default:
 		return fmt.Errorf("unknown network interface backing %T", card.Backing)
 	}
@@ -782,14 +794,8 @@ func (r *NetworkInterfaceSubresource) Update(l object.VirtualDeviceList) ([]type
 	card := device.GetVirtualEthernetCard()
 
 	// Has the backing changed?
-	if r.HasChange("network_id") {
-		net, err := network.FromID(r.client, r.Get("network_id").(string))
-		if err != nil {
-			return nil, err
-		}
-		bctx, bcancel := context.WithTimeout(context.Background(), provider.DefaultAPITimeout)
-		defer bcancel()
-		backing, err := net.EthernetCardBackingInfo(bctx)
+	if r.HasChange("network_id") || r.HasChange("port_key") {
+		backing, err := r.ethernetCardBackingInfo()
 		if err != nil {
 			return nil, err
 		}
@@ -908,6 +914,35 @@ func (r *NetworkInterfaceSubresource) restrictResourceAllocationSettings() error
 	return nil
 }
 
+// ethernetCardBackingInfo returns the backing for the network in network_id.
+// If port_key is set, the backing is pinned to that port on the DVS port
+// group.
+func (r *NetworkInterfaceSubresource) ethernetCardBackingInfo() (types.BaseVirtualDeviceBackingInfo, error) {
+	// govmomi has helpers that allow the easy fetching of a network's backing
+	// info, once we actually know what that backing is. Set all of that stuff up
+	// now.
+	net, err := network.FromID(r.client, r.Get("network_id").(string))
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), provider.DefaultAPITimeout)
+	defer cancel()
+	backing, err := net.EthernetCardBackingInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	portKey := r.Get("port_key").(string)
+	if portKey == "" {
+		return backing, nil
+	}
+	dvpBacking, ok := backing.(*types.VirtualEthernetCardDistributedVirtualPortBackingInfo)
+	if !ok {
+		return nil, fmt.Errorf("port_key can only be used with networks that are DVS port groups")
+	}
+	dvpBacking.Port.PortKey = portKey
+	return dvpBacking, nil
+}
+
 // assignEthernetCard is a subset of the logic that goes into AssignController
 // right now but with an unit offset of 7. This is based on what we have
 // observed on vSphere in terms of reserved PCI unit numbers (the first NIC
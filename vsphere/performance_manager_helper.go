@@ -0,0 +1,89 @@
+package vsphere
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/vmware/govmomi"
+	"github.com/vmware/govmomi/property"
+	"github.com/vmware/govmomi/vim25/methods"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+// performanceManagerReference returns the reference to the
+// PerformanceManager of the connected vCenter or ESXi host. govmomi does not
+// ship a higher-level object for this manager.
+func performanceManagerReference(client *govmomi.Client) (types.ManagedObjectReference, error) {
+	if client.ServiceContent.PerfManager == nil {
+		return types.ManagedObjectReference{}, fmt.Errorf("performance manager is not available on this connection")
+	}
+	return *client.ServiceContent.PerfManager, nil
+}
+
+// perfCounterName returns the full name of a performance counter, in the
+// form group.name.rollup, such as cpu.usage.average.
+func perfCounterName(info types.PerfCounterInfo) string {
+	return strings.Join([]string{
+		info.GroupInfo.GetElementDescription().Key,
+		info.NameInfo.GetElementDescription().Key,
+		string(info.RollupType),
+	}, ".")
+}
+
+// perfCountersByName returns the performance counters with the supplied
+// names, keyed by name. An error is returned if any of the counters are
+// unknown.
+func perfCountersByName(client *govmomi.Client, names []string) (map[string]types.PerfCounterInfo, error) {
+	ref, err := performanceManagerReference(client)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	var props mo.PerformanceManager
+	pc := property.DefaultCollector(client.Client)
+	if err := pc.RetrieveOne(ctx, ref, []string{"perfCounter"}, &props); err != nil {
+		return nil, fmt.Errorf("error fetching performance counters: %s", err)
+	}
+	all := make(map[string]types.PerfCounterInfo)
+	for _, info := range props.PerfCounter {
+		all[perfCounterName(info)] = info
+	}
+	counters := make(map[string]types.PerfCounterInfo)
+	var unknown []string
+	for _, name := range names {
+		info, ok := all[name]
+		if !ok {
+			unknown = append(unknown, name)
+			continue
+		}
+		counters[name] = info
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return nil, fmt.Errorf("unknown performance counters: %s", strings.Join(unknown, ", "))
+	}
+	return counters, nil
+}
+
+// queryPerf queries the PerformanceManager with the supplied query specs.
+func queryPerf(client *govmomi.Client, specs []types.PerfQuerySpec) ([]types.BasePerfEntityMetricBase, error) {
+	ref, err := performanceManagerReference(client)
+	if err != nil {
+		return nil, err
+	}
+	req := types.QueryPerf{
+		This:      ref,
+		QuerySpec: specs,
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	res, err := methods.QueryPerf(ctx, client.Client, &req)
+	if err != nil {
+		return nil, err
+	}
+	return res.Returnval, nil
+}
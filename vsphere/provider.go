@@ -88,6 +88,7 @@ func Provider() terraform.ResourceProvider {
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
+			"vsphere_alarm":                                   resourceVSphereAlarm(),
 			"vsphere_compute_cluster":                         resourceVSphereComputeCluster(),
 			"vsphere_compute_cluster_host_group":              resourceVSphereComputeClusterHostGroup(),
 			"vsphere_compute_cluster_vm_affinity_rule":        resourceVSphereComputeClusterVMAffinityRule(),
@@ -99,22 +100,37 @@ func Provider() terraform.ResourceProvider {
 			"vsphere_datacenter":                              resourceVSphereDatacenter(),
 			"vsphere_datastore_cluster":                       resourceVSphereDatastoreCluster(),
 			"vsphere_datastore_cluster_vm_anti_affinity_rule": resourceVSphereDatastoreClusterVMAntiAffinityRule(),
+			"vsphere_distributed_port":                        resourceVSphereDistributedPort(),
 			"vsphere_distributed_port_group":                  resourceVSphereDistributedPortGroup(),
 			"vsphere_distributed_virtual_switch":              resourceVSphereDistributedVirtualSwitch(),
 			"vsphere_drs_vm_override":                         resourceVSphereDRSVMOverride(),
 			"vsphere_dpm_host_override":                       resourceVSphereDPMHostOverride(),
+			"vsphere_dvs_network_resource_pool":               resourceVSphereDVSNetworkResourcePool(),
+			"vsphere_dvs_port_mirroring_session":              resourceVSphereDVSPortMirroringSession(),
+			"vsphere_entity_permissions":                      resourceVSphereEntityPermissions(),
 			"vsphere_file":                                    resourceVSphereFile(),
 			"vsphere_folder":                                  resourceVSphereFolder(),
 			"vsphere_ha_vm_override":                          resourceVSphereHAVMOverride(),
+			"vsphere_host_autostart":                          resourceVSphereHostAutostart(),
+			"vsphere_host_firewall_ruleset":                   resourceVSphereHostFirewallRuleset(),
+			"vsphere_host_iscsi_adapter":                      resourceVSphereHostIscsiAdapter(),
+			"vsphere_host_local_user":                         resourceVSphereHostLocalUser(),
+			"vsphere_host_maintenance":                        resourceVSphereHostMaintenance(),
+			"vsphere_host_multipath_policy":                   resourceVSphereHostMultipathPolicy(),
+			"vsphere_host_pci_passthrough":                    resourceVSphereHostPciPassthrough(),
 			"vsphere_host_port_group":                         resourceVSphereHostPortGroup(),
+			"vsphere_host_snmp":                               resourceVSphereHostSnmp(),
+			"vsphere_host_syslog":                             resourceVSphereHostSyslog(),
 			"vsphere_host_virtual_switch":                     resourceVSphereHostVirtualSwitch(),
 			"vsphere_license":                                 resourceVSphereLicense(),
 			"vsphere_resource_pool":                           resourceVSphereResourcePool(),
+			"vsphere_role":                                    resourceVSphereRole(),
 			"vsphere_tag":                                     resourceVSphereTag(),
 			"vsphere_tag_category":                            resourceVSphereTagCategory(),
 			"vsphere_virtual_disk":                            resourceVSphereVirtualDisk(),
 			"vsphere_virtual_machine":                         resourceVSphereVirtualMachine(),
 			"vsphere_nas_datastore":                           resourceVSphereNasDatastore(),
+			"vsphere_scheduled_task":                          resourceVSphereScheduledTask(),
 			"vsphere_storage_drs_vm_override":                 resourceVSphereStorageDrsVMOverride(),
 			"vsphere_vapp_container":                          resourceVSphereVAppContainer(),
 			"vsphere_vmfs_datastore":                          resourceVSphereVmfsDatastore(),
@@ -122,20 +138,31 @@ func Provider() terraform.ResourceProvider {
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
-			"vsphere_compute_cluster":            dataSourceVSphereComputeCluster(),
-			"vsphere_custom_attribute":           dataSourceVSphereCustomAttribute(),
-			"vsphere_datacenter":                 dataSourceVSphereDatacenter(),
-			"vsphere_datastore":                  dataSourceVSphereDatastore(),
-			"vsphere_datastore_cluster":          dataSourceVSphereDatastoreCluster(),
-			"vsphere_distributed_virtual_switch": dataSourceVSphereDistributedVirtualSwitch(),
-			"vsphere_host":                       dataSourceVSphereHost(),
-			"vsphere_network":                    dataSourceVSphereNetwork(),
-			"vsphere_resource_pool":              dataSourceVSphereResourcePool(),
-			"vsphere_tag":                        dataSourceVSphereTag(),
-			"vsphere_tag_category":               dataSourceVSphereTagCategory(),
-			"vsphere_vapp_container":             dataSourceVSphereVAppContainer(),
-			"vsphere_virtual_machine":            dataSourceVSphereVirtualMachine(),
-			"vsphere_vmfs_disks":                 dataSourceVSphereVmfsDisks(),
+			"vsphere_compute_cluster":                         dataSourceVSphereComputeCluster(),
+			"vsphere_custom_attribute":                        dataSourceVSphereCustomAttribute(),
+			"vsphere_datacenter":                              dataSourceVSphereDatacenter(),
+			"vsphere_datastore":                               dataSourceVSphereDatastore(),
+			"vsphere_datastore_cluster":                       dataSourceVSphereDatastoreCluster(),
+			"vsphere_distributed_virtual_switch":              dataSourceVSphereDistributedVirtualSwitch(),
+			"vsphere_distributed_virtual_switch_backup":       dataSourceVSphereDistributedVirtualSwitchBackup(),
+			"vsphere_distributed_virtual_switch_health_check": dataSourceVSphereDistributedVirtualSwitchHealthCheck(),
+			"vsphere_events":                                  dataSourceVSphereEvents(),
+			"vsphere_host":                                    dataSourceVSphereHost(),
+			"vsphere_host_firewall":                           dataSourceVSphereHostFirewall(),
+			"vsphere_host_multipath":                          dataSourceVSphereHostMultipath(),
+			"vsphere_host_pci_device":                         dataSourceVSphereHostPciDevice(),
+			"vsphere_inventory_search":                        dataSourceVSphereInventorySearch(),
+			"vsphere_managed_object":                          dataSourceVSphereManagedObject(),
+			"vsphere_network":                                 dataSourceVSphereNetwork(),
+			"vsphere_networks":                                dataSourceVSphereNetworks(),
+			"vsphere_performance_metrics":                     dataSourceVSpherePerformanceMetrics(),
+			"vsphere_resource_pool":                           dataSourceVSphereResourcePool(),
+			"vsphere_role":                                    dataSourceVSphereRole(),
+			"vsphere_tag":                                     dataSourceVSphereTag(),
+			"vsphere_tag_category":                            dataSourceVSphereTagCategory(),
+			"vsphere_vapp_container":                          dataSourceVSphereVAppContainer(),
+			"vsphere_virtual_machine":                         dataSourceVSphereVirtualMachine(),
+			"vsphere_vmfs_disks":                              dataSourceVSphereVmfsDisks(),
 		},
 
 		ConfigureFunc: providerConfigure,
@@ -0,0 +1,674 @@
+package vsphere
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/structure"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/viapi"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+const resourceVSphereAlarmName = "vsphere_alarm"
+
+const (
+	alarmExpressionOperatorOr  = "or"
+	alarmExpressionOperatorAnd = "and"
+)
+
+var alarmExpressionOperatorAllowedValues = []string{
+	alarmExpressionOperatorOr,
+	alarmExpressionOperatorAnd,
+}
+
+var alarmMetricOperatorAllowedValues = []string{
+	string(types.MetricAlarmOperatorIsAbove),
+	string(types.MetricAlarmOperatorIsBelow),
+}
+
+var alarmStateOperatorAllowedValues = []string{
+	string(types.StateAlarmOperatorIsEqual),
+	string(types.StateAlarmOperatorIsUnequal),
+}
+
+var alarmStatusAllowedValues = []string{
+	string(types.ManagedEntityStatusGray),
+	string(types.ManagedEntityStatusGreen),
+	string(types.ManagedEntityStatusYellow),
+	string(types.ManagedEntityStatusRed),
+}
+
+// alarmTransitionAllowedValues are the status transitions an alarm action can
+// be triggered on, in start:final form.
+var alarmTransitionAllowedValues = []string{
+	"green:yellow",
+	"yellow:red",
+	"red:yellow",
+	"yellow:green",
+}
+
+func resourceVSphereAlarm() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceVSphereAlarmCreate,
+		Read:   resourceVSphereAlarmRead,
+		Update: resourceVSphereAlarmUpdate,
+		Delete: resourceVSphereAlarmDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceVSphereAlarmImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"entity_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The managed object ID of the entity to define the alarm on.",
+			},
+			"entity_type": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The managed object type of the entity, such as Folder, Datacenter, HostSystem, or Datastore.",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the alarm.",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The description of the alarm.",
+			},
+			"enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether or not the alarm is enabled.",
+			},
+			"expression_operator": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      alarmExpressionOperatorOr,
+				Description:  "How the expressions of the alarm are combined. Can be one of or or and.",
+				ValidateFunc: validation.StringInSlice(alarmExpressionOperatorAllowedValues, false),
+			},
+			"metric_expression": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "An expression that triggers the alarm based on the value of a performance metric.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"object_type": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The type of object the metric is collected for, such as HostSystem or VirtualMachine.",
+						},
+						"counter_id": {
+							Type:        schema.TypeInt,
+							Required:    true,
+							Description: "The ID of the performance counter to monitor.",
+						},
+						"instance": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The instance of the performance counter to monitor. Leave empty for the aggregate value.",
+						},
+						"operator": {
+							Type:         schema.TypeString,
+							Required:     true,
+							Description:  "The comparison operator. Can be one of isAbove or isBelow.",
+							ValidateFunc: validation.StringInSlice(alarmMetricOperatorAllowedValues, false),
+						},
+						"yellow": {
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Description: "The threshold for the yellow status, in the units of the counter.",
+						},
+						"yellow_interval": {
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Description: "The time, in seconds, the yellow threshold must be crossed for before the status changes.",
+						},
+						"red": {
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Description: "The threshold for the red status, in the units of the counter.",
+						},
+						"red_interval": {
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Description: "The time, in seconds, the red threshold must be crossed for before the status changes.",
+						},
+					},
+				},
+			},
+			"state_expression": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "An expression that triggers the alarm based on the value of a state property.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"object_type": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The type of object the state property belongs to, such as HostSystem or VirtualMachine.",
+						},
+						"state_path": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The path of the state property, such as runtime.connectionState.",
+						},
+						"operator": {
+							Type:         schema.TypeString,
+							Required:     true,
+							Description:  "The comparison operator. Can be one of isEqual or isUnequal.",
+							ValidateFunc: validation.StringInSlice(alarmStateOperatorAllowedValues, false),
+						},
+						"yellow": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The value that sets the yellow status.",
+						},
+						"red": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The value that sets the red status.",
+						},
+					},
+				},
+			},
+			"event_expression": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "An expression that triggers the alarm when an event is logged.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"event_type": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The type of the event, such as VmPoweredOffEvent, or EventEx for extended events.",
+						},
+						"event_type_id": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The ID of an extended event, such as esx.problem.vmfs.heartbeat.timedout.",
+						},
+						"object_type": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The type of object the event is logged for.",
+						},
+						"status": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Description:  "The status to set when the event is logged. Can be one of gray, green, yellow, or red.",
+							ValidateFunc: validation.StringInSlice(alarmStatusAllowedValues, false),
+						},
+					},
+				},
+			},
+			"action_frequency": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "The time, in seconds, between repeated actions while the alarm remains triggered.",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"reporting_frequency": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "The minimum time, in seconds, between status changes of the alarm.",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"tolerance_range": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "The tolerance range for metric expressions, in hundredths of a percent.",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"email_action": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Send an email when the alarm changes status.",
+				Elem: &schema.Resource{
+					Schema: alarmActionSchema(map[string]*schema.Schema{
+						"to": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The comma-separated list of recipients.",
+						},
+						"cc": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The comma-separated list of CC recipients.",
+						},
+						"subject": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The subject of the email.",
+						},
+						"body": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The body of the email.",
+						},
+					}),
+				},
+			},
+			"snmp_action": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Send an SNMP trap when the alarm changes status.",
+				Elem: &schema.Resource{
+					Schema: alarmActionSchema(map[string]*schema.Schema{}),
+				},
+			},
+			"script_action": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Run a script on the vCenter Server when the alarm changes status.",
+				Elem: &schema.Resource{
+					Schema: alarmActionSchema(map[string]*schema.Schema{
+						"script": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The full path of the script to run, with arguments.",
+						},
+					}),
+				},
+			},
+		},
+	}
+}
+
+// alarmActionSchema adds the transition attributes shared by all alarm
+// actions to the supplied schema.
+func alarmActionSchema(s map[string]*schema.Schema) map[string]*schema.Schema {
+	s["start_state"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Default:      string(types.ManagedEntityStatusYellow),
+		Description:  "The status the alarm transitions from to trigger the action.",
+		ValidateFunc: validation.StringInSlice(alarmStatusAllowedValues, false),
+	}
+	s["final_state"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Default:      string(types.ManagedEntityStatusRed),
+		Description:  "The status the alarm transitions to to trigger the action.",
+		ValidateFunc: validation.StringInSlice(alarmStatusAllowedValues, false),
+	}
+	s["repeat"] = &schema.Schema{
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+		Description: "Repeat the action at action_frequency while the alarm remains in final_state.",
+	}
+	return s
+}
+
+func resourceVSphereAlarmCreate(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] %s: Beginning create", resourceVSphereAlarmIDString(d))
+	client := meta.(*VSphereClient).vimClient
+	if err := viapi.ValidateVirtualCenter(client); err != nil {
+		return err
+	}
+	spec, err := expandAlarmSpec(d)
+	if err != nil {
+		return err
+	}
+	entity := types.ManagedObjectReference{
+		Type:  d.Get("entity_type").(string),
+		Value: d.Get("entity_id").(string),
+	}
+	alarm, err := createAlarm(client, entity, spec)
+	if err != nil {
+		return fmt.Errorf("error creating alarm %q: %s", spec.Name, err)
+	}
+	d.SetId(alarm.Value)
+	log.Printf("[DEBUG] %s: Create finished successfully", resourceVSphereAlarmIDString(d))
+	return resourceVSphereAlarmRead(d, meta)
+}
+
+func resourceVSphereAlarmRead(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] %s: Beginning read", resourceVSphereAlarmIDString(d))
+	client := meta.(*VSphereClient).vimClient
+	props, err := alarmProperties(client, alarmFromID(d.Id()))
+	if err != nil {
+		if viapi.IsManagedObjectNotFoundError(err) {
+			log.Printf("[DEBUG] %s: Alarm not found, marking resource as gone", resourceVSphereAlarmIDString(d))
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("error fetching alarm properties: %s", err)
+	}
+	if err := flattenAlarmInfo(d, &props.Info); err != nil {
+		return err
+	}
+	log.Printf("[DEBUG] %s: Read completed successfully", resourceVSphereAlarmIDString(d))
+	return nil
+}
+
+func resourceVSphereAlarmUpdate(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] %s: Beginning update", resourceVSphereAlarmIDString(d))
+	client := meta.(*VSphereClient).vimClient
+	spec, err := expandAlarmSpec(d)
+	if err != nil {
+		return err
+	}
+	if err := reconfigureAlarm(client, alarmFromID(d.Id()), spec); err != nil {
+		return fmt.Errorf("error reconfiguring alarm: %s", err)
+	}
+	log.Printf("[DEBUG] %s: Update finished successfully", resourceVSphereAlarmIDString(d))
+	return resourceVSphereAlarmRead(d, meta)
+}
+
+func resourceVSphereAlarmDelete(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] %s: Beginning delete", resourceVSphereAlarmIDString(d))
+	client := meta.(*VSphereClient).vimClient
+	if err := removeAlarm(client, alarmFromID(d.Id())); err != nil {
+		return fmt.Errorf("error removing alarm: %s", err)
+	}
+	log.Printf("[DEBUG] %s: Delete finished successfully", resourceVSphereAlarmIDString(d))
+	return nil
+}
+
+func resourceVSphereAlarmImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client := meta.(*VSphereClient).vimClient
+	if err := viapi.ValidateVirtualCenter(client); err != nil {
+		return nil, err
+	}
+	// Alarms can be imported either by their managed object ID, or by the
+	// entity they are defined on and their name, in the form
+	// entityType:entityID:name.
+	parts := strings.SplitN(d.Id(), ":", 3)
+	if len(parts) == 3 {
+		entity := types.ManagedObjectReference{
+			Type:  parts[0],
+			Value: parts[1],
+		}
+		props, err := alarmFromName(client, entity, parts[2])
+		if err != nil {
+			return nil, err
+		}
+		d.SetId(props.Info.Alarm.Value)
+		return []*schema.ResourceData{d}, nil
+	}
+	if _, err := alarmProperties(client, alarmFromID(d.Id())); err != nil {
+		return nil, fmt.Errorf("error fetching alarm properties: %s", err)
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// expandAlarmSpec reads certain ResourceData keys and returns an AlarmSpec.
+func expandAlarmSpec(d *schema.ResourceData) (types.AlarmSpec, error) {
+	spec := types.AlarmSpec{
+		Name:            d.Get("name").(string),
+		Description:     d.Get("description").(string),
+		Enabled:         d.Get("enabled").(bool),
+		ActionFrequency: int32(d.Get("action_frequency").(int)),
+	}
+
+	var exprs []types.BaseAlarmExpression
+	for _, raw := range d.Get("metric_expression").([]interface{}) {
+		m := raw.(map[string]interface{})
+		exprs = append(exprs, &types.MetricAlarmExpression{
+			Operator: types.MetricAlarmOperator(m["operator"].(string)),
+			Type:     m["object_type"].(string),
+			Metric: types.PerfMetricId{
+				CounterId: int32(m["counter_id"].(int)),
+				Instance:  m["instance"].(string),
+			},
+			Yellow:         int32(m["yellow"].(int)),
+			YellowInterval: int32(m["yellow_interval"].(int)),
+			Red:            int32(m["red"].(int)),
+			RedInterval:    int32(m["red_interval"].(int)),
+		})
+	}
+	for _, raw := range d.Get("state_expression").([]interface{}) {
+		m := raw.(map[string]interface{})
+		exprs = append(exprs, &types.StateAlarmExpression{
+			Operator:  types.StateAlarmOperator(m["operator"].(string)),
+			Type:      m["object_type"].(string),
+			StatePath: m["state_path"].(string),
+			Yellow:    m["yellow"].(string),
+			Red:       m["red"].(string),
+		})
+	}
+	for _, raw := range d.Get("event_expression").([]interface{}) {
+		m := raw.(map[string]interface{})
+		exprs = append(exprs, &types.EventAlarmExpression{
+			EventType:   m["event_type"].(string),
+			EventTypeId: m["event_type_id"].(string),
+			ObjectType:  m["object_type"].(string),
+			Status:      types.ManagedEntityStatus(m["status"].(string)),
+		})
+	}
+	if len(exprs) < 1 {
+		return spec, errors.New("at least one of metric_expression, state_expression, or event_expression must be specified")
+	}
+	if d.Get("expression_operator").(string) == alarmExpressionOperatorAnd {
+		spec.Expression = &types.AndAlarmExpression{Expression: exprs}
+	} else {
+		spec.Expression = &types.OrAlarmExpression{Expression: exprs}
+	}
+
+	if v, ok := d.GetOk("reporting_frequency"); ok {
+		spec.Setting = &types.AlarmSetting{ReportingFrequency: int32(v.(int))}
+	}
+	if v, ok := d.GetOk("tolerance_range"); ok {
+		if spec.Setting == nil {
+			spec.Setting = &types.AlarmSetting{}
+		}
+		spec.Setting.ToleranceRange = int32(v.(int))
+	}
+
+	var actions []types.BaseAlarmAction
+	for _, raw := range d.Get("email_action").([]interface{}) {
+		m := raw.(map[string]interface{})
+		action, err := expandAlarmTriggeringAction(m, &types.SendEmailAction{
+			ToList:  m["to"].(string),
+			CcList:  m["cc"].(string),
+			Subject: m["subject"].(string),
+			Body:    m["body"].(string),
+		})
+		if err != nil {
+			return spec, err
+		}
+		actions = append(actions, action)
+	}
+	for _, raw := range d.Get("snmp_action").([]interface{}) {
+		action, err := expandAlarmTriggeringAction(raw.(map[string]interface{}), &types.SendSNMPAction{})
+		if err != nil {
+			return spec, err
+		}
+		actions = append(actions, action)
+	}
+	for _, raw := range d.Get("script_action").([]interface{}) {
+		m := raw.(map[string]interface{})
+		action, err := expandAlarmTriggeringAction(m, &types.RunScriptAction{
+			Script: m["script"].(string),
+		})
+		if err != nil {
+			return spec, err
+		}
+		actions = append(actions, action)
+	}
+	if len(actions) > 0 {
+		spec.Action = &types.GroupAlarmAction{Action: actions}
+	}
+	return spec, nil
+}
+
+// expandAlarmTriggeringAction wraps an action in an AlarmTriggeringAction
+// using the transition attributes of an action block.
+func expandAlarmTriggeringAction(m map[string]interface{}, action types.BaseAction) (*types.AlarmTriggeringAction, error) {
+	start := m["start_state"].(string)
+	final := m["final_state"].(string)
+	transition := start + ":" + final
+	var valid bool
+	for _, v := range alarmTransitionAllowedValues {
+		if v == transition {
+			valid = true
+			break
+		}
+	}
+	if !valid {
+		return nil, fmt.Errorf("unsupported alarm action transition from %s to %s", start, final)
+	}
+	return &types.AlarmTriggeringAction{
+		Action: action,
+		TransitionSpecs: []types.AlarmTriggeringActionTransitionSpec{
+			{
+				StartState: types.ManagedEntityStatus(start),
+				FinalState: types.ManagedEntityStatus(final),
+				Repeats:    m["repeat"].(bool),
+			},
+		},
+	}, nil
+}
+
+// flattenAlarmInfo reads an AlarmInfo into the passed in ResourceData.
+func flattenAlarmInfo(d *schema.ResourceData, info *types.AlarmInfo) error {
+	attrs := map[string]interface{}{
+		"entity_id":           info.Entity.Value,
+		"entity_type":         info.Entity.Type,
+		"name":                info.Name,
+		"description":         info.Description,
+		"enabled":             info.Enabled,
+		"action_frequency":    int(info.ActionFrequency),
+		"reporting_frequency": 0,
+		"tolerance_range":     0,
+	}
+	if info.Setting != nil {
+		attrs["reporting_frequency"] = int(info.Setting.ReportingFrequency)
+		attrs["tolerance_range"] = int(info.Setting.ToleranceRange)
+	}
+
+	exprs := []types.BaseAlarmExpression{info.Expression}
+	attrs["expression_operator"] = alarmExpressionOperatorOr
+	switch e := info.Expression.(type) {
+	case *types.OrAlarmExpression:
+		exprs = e.Expression
+	case *types.AndAlarmExpression:
+		exprs = e.Expression
+		attrs["expression_operator"] = alarmExpressionOperatorAnd
+	}
+	var metrics, states, events []interface{}
+	for _, expr := range exprs {
+		switch e := expr.(type) {
+		case *types.MetricAlarmExpression:
+			metrics = append(metrics, map[string]interface{}{
+				"object_type":     e.Type,
+				"counter_id":      int(e.Metric.CounterId),
+				"instance":        e.Metric.Instance,
+				"operator":        string(e.Operator),
+				"yellow":          int(e.Yellow),
+				"yellow_interval": int(e.YellowInterval),
+				"red":             int(e.Red),
+				"red_interval":    int(e.RedInterval),
+			})
+		case *types.StateAlarmExpression:
+			states = append(states, map[string]interface{}{
+				"object_type": e.Type,
+				"state_path":  e.StatePath,
+				"operator":    string(e.Operator),
+				"yellow":      e.Yellow,
+				"red":         e.Red,
+			})
+		case *types.EventAlarmExpression:
+			events = append(events, map[string]interface{}{
+				"event_type":    e.EventType,
+				"event_type_id": e.EventTypeId,
+				"object_type":   e.ObjectType,
+				"status":        string(e.Status),
+			})
+		default:
+			log.Printf("[DEBUG] %s: Ignoring unsupported alarm expression type %T", resourceVSphereAlarmIDString(d), expr)
+		}
+	}
+	attrs["metric_expression"] = metrics
+	attrs["state_expression"] = states
+	attrs["event_expression"] = events
+
+	var triggers []*types.AlarmTriggeringAction
+	switch a := info.Action.(type) {
+	case *types.GroupAlarmAction:
+		for _, action := range a.Action {
+			if t, ok := action.(*types.AlarmTriggeringAction); ok {
+				triggers = append(triggers, t)
+			}
+		}
+	case *types.AlarmTriggeringAction:
+		triggers = append(triggers, a)
+	}
+	var emails, snmps, scripts []interface{}
+	for _, t := range triggers {
+		for _, m := range flattenAlarmTriggeringActionTransitions(t) {
+			switch action := t.Action.(type) {
+			case *types.SendEmailAction:
+				m["to"] = action.ToList
+				m["cc"] = action.CcList
+				m["subject"] = action.Subject
+				m["body"] = action.Body
+				emails = append(emails, m)
+			case *types.SendSNMPAction:
+				snmps = append(snmps, m)
+			case *types.RunScriptAction:
+				m["script"] = action.Script
+				scripts = append(scripts, m)
+			default:
+				log.Printf("[DEBUG] %s: Ignoring unsupported alarm action type %T", resourceVSphereAlarmIDString(d), t.Action)
+			}
+		}
+	}
+	attrs["email_action"] = emails
+	attrs["snmp_action"] = snmps
+	attrs["script_action"] = scripts
+
+	return structure.SetBatch(d, attrs)
+}
+
+// flattenAlarmTriggeringActionTransitions returns the transition attributes
+// of an AlarmTriggeringAction, one map for each transition that triggers the
+// action. Actions created without transition specs use the older transition
+// flags instead.
+func flattenAlarmTriggeringActionTransitions(t *types.AlarmTriggeringAction) []map[string]interface{} {
+	var result []map[string]interface{}
+	add := func(start, final types.ManagedEntityStatus, repeats bool) {
+		result = append(result, map[string]interface{}{
+			"start_state": string(start),
+			"final_state": string(final),
+			"repeat":      repeats,
+		})
+	}
+	if len(t.TransitionSpecs) > 0 {
+		for _, spec := range t.TransitionSpecs {
+			add(spec.StartState, spec.FinalState, spec.Repeats)
+		}
+		return result
+	}
+	if t.Green2yellow {
+		add(types.ManagedEntityStatusGreen, types.ManagedEntityStatusYellow, false)
+	}
+	if t.Yellow2red {
+		add(types.ManagedEntityStatusYellow, types.ManagedEntityStatusRed, false)
+	}
+	if t.Red2yellow {
+		add(types.ManagedEntityStatusRed, types.ManagedEntityStatusYellow, false)
+	}
+	if t.Yellow2green {
+		add(types.ManagedEntityStatusYellow, types.ManagedEntityStatusGreen, false)
+	}
+	return result
+}
+
+// resourceVSphereAlarmIDString prints a friendly string for the vsphere_alarm
+// resource.
+func resourceVSphereAlarmIDString(d structure.ResourceIDStringer) string {
+	return structure.ResourceIDString(d, resourceVSphereAlarmName)
+}
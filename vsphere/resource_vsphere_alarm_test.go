@@ -0,0 +1,227 @@
+package vsphere
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/viapi"
+)
+
+func TestAccResourceVSphereAlarm_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccResourceVSphereAlarmPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccResourceVSphereAlarmExists(false),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceVSphereAlarmConfigState("yellow", "red"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereAlarmExists(true),
+					resource.TestCheckResourceAttr("vsphere_alarm.alarm", "state_expression.#", "1"),
+					resource.TestCheckResourceAttr("vsphere_alarm.alarm", "email_action.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceVSphereAlarm_update(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccResourceVSphereAlarmPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccResourceVSphereAlarmExists(false),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceVSphereAlarmConfigState("yellow", "red"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereAlarmExists(true),
+				),
+			},
+			{
+				Config: testAccResourceVSphereAlarmConfigState("green", "yellow"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereAlarmExists(true),
+					resource.TestCheckResourceAttr("vsphere_alarm.alarm", "email_action.0.start_state", "green"),
+					resource.TestCheckResourceAttr("vsphere_alarm.alarm", "email_action.0.final_state", "yellow"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceVSphereAlarm_metric(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccResourceVSphereAlarmPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccResourceVSphereAlarmExists(false),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceVSphereAlarmConfigMetric(),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereAlarmExists(true),
+					resource.TestCheckResourceAttr("vsphere_alarm.alarm", "metric_expression.#", "1"),
+					resource.TestCheckResourceAttr("vsphere_alarm.alarm", "event_expression.#", "1"),
+					resource.TestCheckResourceAttr("vsphere_alarm.alarm", "snmp_action.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceVSphereAlarm_import(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccResourceVSphereAlarmPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccResourceVSphereAlarmExists(false),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceVSphereAlarmConfigState("yellow", "red"),
+			},
+			{
+				ResourceName:      "vsphere_alarm.alarm",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName: "vsphere_alarm.alarm",
+				ImportState:  true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					vars, err := testClientVariablesForResource(s, "vsphere_alarm.alarm")
+					if err != nil {
+						return "", err
+					}
+					return fmt.Sprintf(
+						"%s:%s:%s",
+						vars.resourceAttributes["entity_type"],
+						vars.resourceAttributes["entity_id"],
+						vars.resourceAttributes["name"],
+					), nil
+				},
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccResourceVSphereAlarmPreCheck(t *testing.T) {
+	if os.Getenv("VSPHERE_DATACENTER") == "" {
+		t.Skip("set VSPHERE_DATACENTER to run vsphere_alarm acceptance tests")
+	}
+}
+
+func testAccResourceVSphereAlarmExists(expected bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		vars, err := testClientVariablesForResource(s, "vsphere_alarm.alarm")
+		if err != nil {
+			return err
+		}
+		_, err = alarmProperties(vars.client, alarmFromID(vars.resourceID))
+		switch {
+		case err != nil && viapi.IsManagedObjectNotFoundError(err) && !expected:
+			return nil
+		case err != nil && viapi.IsManagedObjectNotFoundError(err):
+			return errors.New("alarm not found")
+		case err != nil:
+			return err
+		case !expected:
+			return fmt.Errorf("alarm %q still exists", vars.resourceID)
+		}
+		return nil
+	}
+}
+
+func testAccResourceVSphereAlarmConfigState(startState, finalState string) string {
+	return fmt.Sprintf(`
+variable "datacenter" {
+  default = "%s"
+}
+
+data "vsphere_datacenter" "dc" {
+  name = "${var.datacenter}"
+}
+
+resource "vsphere_alarm" "alarm" {
+  entity_id   = "${data.vsphere_datacenter.dc.id}"
+  entity_type = "Datacenter"
+  name        = "terraform-test-alarm"
+  description = "Host disconnected"
+
+  state_expression {
+    object_type = "HostSystem"
+    state_path  = "runtime.connectionState"
+    operator    = "isEqual"
+    red         = "disconnected"
+  }
+
+  email_action {
+    to          = "ops@example.com"
+    subject     = "Host disconnected"
+    start_state = "%s"
+    final_state = "%s"
+  }
+}
+`,
+		os.Getenv("VSPHERE_DATACENTER"),
+		startState,
+		finalState,
+	)
+}
+
+func testAccResourceVSphereAlarmConfigMetric() string {
+	return fmt.Sprintf(`
+variable "datacenter" {
+  default = "%s"
+}
+
+data "vsphere_datacenter" "dc" {
+  name = "${var.datacenter}"
+}
+
+resource "vsphere_alarm" "alarm" {
+  entity_id   = "${data.vsphere_datacenter.dc.id}"
+  entity_type = "Datacenter"
+  name        = "terraform-test-alarm"
+
+  metric_expression {
+    object_type     = "HostSystem"
+    counter_id      = 2
+    operator        = "isAbove"
+    yellow          = 7500
+    yellow_interval = 300
+    red             = 9000
+    red_interval    = 300
+  }
+
+  event_expression {
+    event_type    = "EventEx"
+    event_type_id = "esx.problem.vmfs.heartbeat.timedout"
+    object_type   = "HostSystem"
+    status        = "red"
+  }
+
+  snmp_action {
+    repeat = true
+  }
+
+  action_frequency = 600
+}
+`,
+		os.Getenv("VSPHERE_DATACENTER"),
+	)
+}
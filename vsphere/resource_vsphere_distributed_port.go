@@ -0,0 +1,746 @@
+package vsphere
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/dvportgroup"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/structure"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/viapi"
+	"github.com/vmware/govmomi"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+const resourceVSphereDistributedPortName = "vsphere_distributed_port"
+
+// resourceVSphereDistributedPortUnsupportedKeys are the keys in
+// schemaVMwareDVSPortSetting that cannot be overridden on an individual port.
+var resourceVSphereDistributedPortUnsupportedKeys = []string{
+	"lacp_enabled",
+	"lacp_mode",
+	"tx_uplink",
+	"directpath_gen2_allowed",
+}
+
+func resourceVSphereDistributedPort() *schema.Resource {
+	s := map[string]*schema.Schema{
+		"distributed_virtual_switch_uuid": {
+			Type:         schema.TypeString,
+			Description:  "The UUID of the DVS the port is on.",
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.NoZeroValues,
+		},
+		"port_key": {
+			Type:         schema.TypeString,
+			Description:  "The key of the port.",
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.NoZeroValues,
+		},
+		"name": {
+			Type:        schema.TypeString,
+			Description: "The name of the port.",
+			Optional:    true,
+			Computed:    true,
+		},
+		"description": {
+			Type:        schema.TypeString,
+			Description: "The description of the port.",
+			Optional:    true,
+		},
+		"portgroup_key": {
+			Type:        schema.TypeString,
+			Description: "The key of the port group the port is in.",
+			Computed:    true,
+		},
+		"config_version": {
+			Type:        schema.TypeString,
+			Description: "Version string of the configuration that this port is set to.",
+			Computed:    true,
+		},
+	}
+	settings := schemaVMwareDVSPortSetting()
+	for _, k := range resourceVSphereDistributedPortUnsupportedKeys {
+		delete(settings, k)
+	}
+	// Overrides are only tracked when they are set in configuration, so that
+	// removing one from configuration returns the port to the policy of its
+	// port group. helper/schema can't tell a removed bool or integer apart
+	// from its zero value, so bools are stored as strings that are empty when
+	// unset, and integers default to -1 when unset.
+	for _, v := range settings {
+		v.Computed = false
+		switch v.Type {
+		case schema.TypeBool:
+			v.Type = schema.TypeString
+			v.ValidateFunc = structure.ValidateBoolStringPtr()
+			v.StateFunc = structure.BoolStringPtrState
+		case schema.TypeInt:
+			v.Default = -1
+			v.ValidateFunc = resourceVSphereDistributedPortValidateUnsetInt(v.ValidateFunc)
+		}
+	}
+	structure.MergeSchema(s, settings)
+
+	return &schema.Resource{
+		Create: resourceVSphereDistributedPortCreate,
+		Read:   resourceVSphereDistributedPortRead,
+		Update: resourceVSphereDistributedPortUpdate,
+		Delete: resourceVSphereDistributedPortDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceVSphereDistributedPortImport,
+		},
+		Schema: s,
+	}
+}
+
+func resourceVSphereDistributedPortCreate(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] %s: Beginning create", resourceVSphereDistributedPortIDString(d))
+	client := meta.(*VSphereClient).vimClient
+	if err := viapi.ValidateVirtualCenter(client); err != nil {
+		return err
+	}
+	dvsID := d.Get("distributed_virtual_switch_uuid").(string)
+	key := d.Get("port_key").(string)
+	dvs, err := dvsFromUUID(client, dvsID)
+	if err != nil {
+		return fmt.Errorf("could not find DVS %q: %s", dvsID, err)
+	}
+	port, err := dvsPortFromKey(dvs, key)
+	if err != nil {
+		return fmt.Errorf("error fetching port %q: %s", key, err)
+	}
+	if port == nil {
+		return fmt.Errorf("could not find port %q on DVS %q", key, dvsID)
+	}
+
+	// Ports are created with their port group, so creating this resource just
+	// applies the configured overrides to the existing port.
+	if err := resourceVSphereDistributedPortApply(client, dvs, d, port); err != nil {
+		return fmt.Errorf("error configuring port: %s", err)
+	}
+	d.SetId(resourceVSphereDistributedPortFlattenID(dvsID, key))
+
+	log.Printf("[DEBUG] %s: Create finished successfully", resourceVSphereDistributedPortIDString(d))
+	return resourceVSphereDistributedPortRead(d, meta)
+}
+
+func resourceVSphereDistributedPortRead(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] %s: Beginning read", resourceVSphereDistributedPortIDString(d))
+	client := meta.(*VSphereClient).vimClient
+	dvsID, key, err := resourceVSphereDistributedPortParseID(d.Id())
+	if err != nil {
+		return err
+	}
+	dvs, err := dvsFromUUID(client, dvsID)
+	if err != nil {
+		if viapi.IsAnyNotFoundError(err) {
+			log.Printf("[DEBUG] %s: DVS not found. Removing from state.", resourceVSphereDistributedPortIDString(d))
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("could not find DVS %q: %s", dvsID, err)
+	}
+	port, err := dvsPortFromKey(dvs, key)
+	if err != nil {
+		return fmt.Errorf("error fetching port %q: %s", key, err)
+	}
+	if port == nil {
+		log.Printf("[DEBUG] %s: Port not found. Removing from state.", resourceVSphereDistributedPortIDString(d))
+		d.SetId("")
+		return nil
+	}
+
+	err = structure.SetBatch(d, map[string]interface{}{
+		"distributed_virtual_switch_uuid": dvsID,
+		"port_key":                        port.Key,
+		"name":                            port.Config.Name,
+		"description":                     port.Config.Description,
+		"portgroup_key":                   port.PortgroupKey,
+		"config_version":                  port.Config.ConfigVersion,
+	})
+	if err != nil {
+		return err
+	}
+	overrides := &types.VMwareDVSPortSetting{}
+	if setting, ok := port.Config.Setting.(*types.VMwareDVSPortSetting); ok {
+		overrides = dvsPortSettingOverrides(setting)
+	}
+	if err := flattenDVSPortSettingOverrides(d, overrides); err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] %s: Read completed successfully", resourceVSphereDistributedPortIDString(d))
+	return nil
+}
+
+func resourceVSphereDistributedPortUpdate(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] %s: Beginning update", resourceVSphereDistributedPortIDString(d))
+	client := meta.(*VSphereClient).vimClient
+	dvsID, key, err := resourceVSphereDistributedPortParseID(d.Id())
+	if err != nil {
+		return err
+	}
+	dvs, err := dvsFromUUID(client, dvsID)
+	if err != nil {
+		return fmt.Errorf("could not find DVS %q: %s", dvsID, err)
+	}
+	port, err := dvsPortFromKey(dvs, key)
+	if err != nil {
+		return fmt.Errorf("error fetching port %q: %s", key, err)
+	}
+	if port == nil {
+		return fmt.Errorf("could not find port %q on DVS %q", key, dvsID)
+	}
+
+	if err := resourceVSphereDistributedPortApply(client, dvs, d, port); err != nil {
+		return fmt.Errorf("error updating port: %s", err)
+	}
+
+	log.Printf("[DEBUG] %s: Update finished successfully", resourceVSphereDistributedPortIDString(d))
+	return resourceVSphereDistributedPortRead(d, meta)
+}
+
+func resourceVSphereDistributedPortDelete(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] %s: Beginning delete", resourceVSphereDistributedPortIDString(d))
+	client := meta.(*VSphereClient).vimClient
+	dvsID, key, err := resourceVSphereDistributedPortParseID(d.Id())
+	if err != nil {
+		return err
+	}
+	dvs, err := dvsFromUUID(client, dvsID)
+	if err != nil {
+		return fmt.Errorf("could not find DVS %q: %s", dvsID, err)
+	}
+	port, err := dvsPortFromKey(dvs, key)
+	if err != nil {
+		return fmt.Errorf("error fetching port %q: %s", key, err)
+	}
+	if port == nil {
+		return nil
+	}
+
+	// The port itself is owned by its port group, so deleting this resource
+	// only returns every overridden policy to the policy of the port group.
+	var current *types.VMwareDVSPortSetting
+	if setting, ok := port.Config.Setting.(*types.VMwareDVSPortSetting); ok {
+		current = dvsPortSettingOverrides(setting)
+	}
+	spec := types.DVPortConfigSpec{
+		Operation:     string(types.ConfigSpecOperationEdit),
+		Key:           key,
+		Setting:       resourceVSphereDistributedPortSettingSpec(&types.VMwareDVSPortSetting{}, current),
+		ConfigVersion: port.Config.ConfigVersion,
+	}
+	if err := reconfigureDVSPorts(client, dvs, []types.DVPortConfigSpec{spec}); err != nil {
+		return fmt.Errorf("error resetting port: %s", err)
+	}
+
+	log.Printf("[DEBUG] %s: Deleted successfully", resourceVSphereDistributedPortIDString(d))
+	return nil
+}
+
+func resourceVSphereDistributedPortImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client := meta.(*VSphereClient).vimClient
+	if err := viapi.ValidateVirtualCenter(client); err != nil {
+		return nil, err
+	}
+	dvsID, key, err := resourceVSphereDistributedPortParseID(d.Id())
+	if err != nil {
+		return nil, err
+	}
+	dvs, err := dvsFromUUID(client, dvsID)
+	if err != nil {
+		return nil, fmt.Errorf("could not find DVS %q: %s", dvsID, err)
+	}
+	port, err := dvsPortFromKey(dvs, key)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching port %q: %s", key, err)
+	}
+	if port == nil {
+		return nil, fmt.Errorf("could not find port %q on DVS %q", key, dvsID)
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// resourceVSphereDistributedPortApply sends the name, description, and
+// overrides in the ResourceData to a port. Overrides that are set on the port
+// but no longer in configuration are returned to the policy of the port group.
+func resourceVSphereDistributedPortApply(client *govmomi.Client, dvs *object.VmwareDistributedVirtualSwitch, d *schema.ResourceData, port *types.DistributedVirtualPort) error {
+	setting, err := expandDVSPortSettingOverrides(d)
+	if err != nil {
+		return err
+	}
+	if port.PortgroupKey != "" {
+		if err := resourceVSphereDistributedPortValidateOverrides(client, port, setting); err != nil {
+			return err
+		}
+	}
+	var current *types.VMwareDVSPortSetting
+	if obj, ok := port.Config.Setting.(*types.VMwareDVSPortSetting); ok {
+		current = dvsPortSettingOverrides(obj)
+	}
+
+	spec := types.DVPortConfigSpec{
+		Operation:     string(types.ConfigSpecOperationEdit),
+		Key:           port.Key,
+		Name:          d.Get("name").(string),
+		Description:   d.Get("description").(string),
+		Setting:       resourceVSphereDistributedPortSettingSpec(setting, current),
+		ConfigVersion: port.Config.ConfigVersion,
+	}
+	return reconfigureDVSPorts(client, dvs, []types.DVPortConfigSpec{spec})
+}
+
+// resourceVSphereDistributedPortValidateOverrides checks that the port group
+// of a port allows each of the policies in setting to be overridden.
+func resourceVSphereDistributedPortValidateOverrides(client *govmomi.Client, port *types.DistributedVirtualPort, setting *types.VMwareDVSPortSetting) error {
+	pg, err := dvportgroup.FromKey(client, port.DvsUuid, port.PortgroupKey)
+	if err != nil {
+		return fmt.Errorf("could not find port group %q: %s", port.PortgroupKey, err)
+	}
+	props, err := dvportgroup.Properties(pg)
+	if err != nil {
+		return fmt.Errorf("error fetching port group properties: %s", err)
+	}
+	policy, ok := props.Config.Policy.(*types.VMwareDVSPortgroupPolicy)
+	if !ok {
+		return nil
+	}
+
+	var flags []string
+	if setting.Blocked != nil && !policy.BlockOverrideAllowed {
+		flags = append(flags, "block_override_allowed")
+	}
+	if (setting.InShapingPolicy != nil || setting.OutShapingPolicy != nil) && !policy.ShapingOverrideAllowed {
+		flags = append(flags, "shaping_override_allowed")
+	}
+	if setting.Vlan != nil && !policy.VlanOverrideAllowed {
+		flags = append(flags, "vlan_override_allowed")
+	}
+	if setting.UplinkTeamingPolicy != nil && !policy.UplinkTeamingOverrideAllowed {
+		flags = append(flags, "uplink_teaming_override_allowed")
+	}
+	if setting.SecurityPolicy != nil && !policy.SecurityPolicyOverrideAllowed {
+		flags = append(flags, "security_policy_override_allowed")
+	}
+	if setting.IpfixEnabled != nil && (policy.IpfixOverrideAllowed == nil || !*policy.IpfixOverrideAllowed) {
+		flags = append(flags, "netflow_override_allowed")
+	}
+	if len(flags) > 0 {
+		return fmt.Errorf("port group %q does not allow the configured settings to be overridden on its ports, enable %s on the port group first", props.Name, strings.Join(flags, ", "))
+	}
+	return nil
+}
+
+// expandDVSPortSettingOverrides reads the ResourceData keys of the policies
+// that can be overridden on a port and returns a VMwareDVSPortSetting. Only
+// the policies whose keys are set are included, and the rest are left nil.
+func expandDVSPortSettingOverrides(d *schema.ResourceData) (*types.VMwareDVSPortSetting, error) {
+	bools := make(map[string]*types.BoolPolicy)
+	for _, k := range []string{
+		"block_all_ports",
+		"netflow_enabled",
+		"check_beacon",
+		"notify_switches",
+		"failback",
+		"allow_promiscuous",
+		"allow_forged_transmits",
+		"allow_mac_changes",
+		"ingress_shaping_enabled",
+		"egress_shaping_enabled",
+	} {
+		v, err := structure.GetBoolStringPtr(d, k)
+		if err != nil {
+			return nil, fmt.Errorf("error reading %s: %s", k, err)
+		}
+		if v != nil {
+			bools[k] = &types.BoolPolicy{Value: v}
+		}
+	}
+
+	obj := &types.VMwareDVSPortSetting{
+		DVPortSetting: types.DVPortSetting{
+			Blocked:          bools["block_all_ports"],
+			InShapingPolicy:  expandDVSPortTrafficShapingPolicyOverrides(d, "ingress", bools["ingress_shaping_enabled"]),
+			OutShapingPolicy: expandDVSPortTrafficShapingPolicyOverrides(d, "egress", bools["egress_shaping_enabled"]),
+		},
+		IpfixEnabled: bools["netflow_enabled"],
+	}
+
+	switch {
+	case d.Get("vlan_range").(*schema.Set).Len() > 0:
+		obj.Vlan = expandVmwareDistributedVirtualSwitchTrunkVlanSpec(d)
+	case d.Get("port_private_secondary_vlan_id").(int) >= 0:
+		obj.Vlan = expandVmwareDistributedVirtualSwitchPvlanSpec(d)
+	case d.Get("vlan_id").(int) >= 0:
+		obj.Vlan = expandVmwareDistributedVirtualSwitchVlanIDSpec(d)
+	}
+
+	teaming := &types.VmwareUplinkPortTeamingPolicy{
+		NotifySwitches:  bools["notify_switches"],
+		UplinkPortOrder: expandVMwareUplinkPortOrderPolicy(d),
+	}
+	if v := d.Get("teaming_policy").(string); v != "" {
+		teaming.Policy = &types.StringPolicy{Value: v}
+	}
+	if p := bools["failback"]; p != nil {
+		teaming.RollingOrder = &types.BoolPolicy{Value: structure.BoolPtr(!*p.Value)}
+	}
+	if p := bools["check_beacon"]; p != nil {
+		teaming.FailureCriteria = &types.DVSFailureCriteria{CheckBeacon: p}
+	}
+	if teaming.Policy != nil || teaming.NotifySwitches != nil || teaming.RollingOrder != nil || teaming.FailureCriteria != nil || teaming.UplinkPortOrder != nil {
+		obj.UplinkTeamingPolicy = teaming
+	}
+
+	security := &types.DVSSecurityPolicy{
+		AllowPromiscuous: bools["allow_promiscuous"],
+		MacChanges:       bools["allow_mac_changes"],
+		ForgedTransmits:  bools["allow_forged_transmits"],
+	}
+	if security.AllowPromiscuous != nil || security.MacChanges != nil || security.ForgedTransmits != nil {
+		obj.SecurityPolicy = security
+	}
+	return obj, nil
+}
+
+// expandDVSPortTrafficShapingPolicyOverrides reads the traffic shaping keys
+// with the supplied prefix, either ingress or egress, and returns a
+// DVSTrafficShapingPolicy, or nil if none of the keys are set.
+func expandDVSPortTrafficShapingPolicyOverrides(d *schema.ResourceData, prefix string, enabled *types.BoolPolicy) *types.DVSTrafficShapingPolicy {
+	obj := &types.DVSTrafficShapingPolicy{
+		Enabled:          enabled,
+		AverageBandwidth: expandDVSPortLongPolicyOverride(d, prefix+"_shaping_average_bandwidth"),
+		PeakBandwidth:    expandDVSPortLongPolicyOverride(d, prefix+"_shaping_peak_bandwidth"),
+		BurstSize:        expandDVSPortLongPolicyOverride(d, prefix+"_shaping_burst_size"),
+	}
+	if obj.Enabled == nil && obj.AverageBandwidth == nil && obj.PeakBandwidth == nil && obj.BurstSize == nil {
+		return nil
+	}
+	return obj
+}
+
+// expandDVSPortLongPolicyOverride returns a LongPolicy for an integer
+// override key, or nil if the key is unset.
+func expandDVSPortLongPolicyOverride(d *schema.ResourceData, key string) *types.LongPolicy {
+	v := d.Get(key).(int)
+	if v < 0 {
+		return nil
+	}
+	return &types.LongPolicy{Value: int64(v)}
+}
+
+// dvsPortSettingOverrides returns the policies in the setting of a port that
+// are overridden on the port, leaving out those inherited from its port group.
+func dvsPortSettingOverrides(obj *types.VMwareDVSPortSetting) *types.VMwareDVSPortSetting {
+	overrides := &types.VMwareDVSPortSetting{
+		DVPortSetting: types.DVPortSetting{
+			Blocked:          overriddenBoolPolicy(obj.Blocked),
+			InShapingPolicy:  overriddenDVSTrafficShapingPolicy(obj.InShapingPolicy),
+			OutShapingPolicy: overriddenDVSTrafficShapingPolicy(obj.OutShapingPolicy),
+		},
+		IpfixEnabled: overriddenBoolPolicy(obj.IpfixEnabled),
+	}
+	if obj.Vlan != nil && !obj.Vlan.GetVmwareDistributedVirtualSwitchVlanSpec().Inherited {
+		overrides.Vlan = obj.Vlan
+	}
+	if p := obj.UplinkTeamingPolicy; p != nil && !p.Inherited {
+		overrides.UplinkTeamingPolicy = &types.VmwareUplinkPortTeamingPolicy{
+			Policy:         overriddenStringPolicy(p.Policy),
+			NotifySwitches: overriddenBoolPolicy(p.NotifySwitches),
+			RollingOrder:   overriddenBoolPolicy(p.RollingOrder),
+		}
+		if p.FailureCriteria != nil && !p.FailureCriteria.Inherited {
+			overrides.UplinkTeamingPolicy.FailureCriteria = &types.DVSFailureCriteria{
+				CheckBeacon: overriddenBoolPolicy(p.FailureCriteria.CheckBeacon),
+			}
+		}
+		if p.UplinkPortOrder != nil && !p.UplinkPortOrder.Inherited {
+			overrides.UplinkTeamingPolicy.UplinkPortOrder = p.UplinkPortOrder
+		}
+	}
+	if p := obj.SecurityPolicy; p != nil && !p.Inherited {
+		overrides.SecurityPolicy = &types.DVSSecurityPolicy{
+			AllowPromiscuous: overriddenBoolPolicy(p.AllowPromiscuous),
+			MacChanges:       overriddenBoolPolicy(p.MacChanges),
+			ForgedTransmits:  overriddenBoolPolicy(p.ForgedTransmits),
+		}
+	}
+	return overrides
+}
+
+// flattenDVSPortSettingOverrides sets the override keys of a port from the
+// policies that are overridden on it, as returned by dvsPortSettingOverrides.
+// Every key is set, and the keys of policies that are not overridden are set
+// to their unset value, so that overrides reset outside of Terraform show up
+// as drift.
+func flattenDVSPortSettingOverrides(d *schema.ResourceData, obj *types.VMwareDVSPortSetting) error {
+	attrs := map[string]interface{}{
+		"vlan_id":                           -1,
+		"vlan_range":                        nil,
+		"port_private_secondary_vlan_id":    -1,
+		"teaming_policy":                    "",
+		"active_uplinks":                    nil,
+		"standby_uplinks":                   nil,
+		"check_beacon":                      "",
+		"notify_switches":                   "",
+		"failback":                          "",
+		"allow_promiscuous":                 "",
+		"allow_forged_transmits":            "",
+		"allow_mac_changes":                 "",
+		"ingress_shaping_enabled":           "",
+		"ingress_shaping_average_bandwidth": -1,
+		"ingress_shaping_peak_bandwidth":    -1,
+		"ingress_shaping_burst_size":        -1,
+		"egress_shaping_enabled":            "",
+		"egress_shaping_average_bandwidth":  -1,
+		"egress_shaping_peak_bandwidth":     -1,
+		"egress_shaping_burst_size":         -1,
+		"block_all_ports":                   flattenDVSPortBoolPolicyOverride(obj.Blocked),
+		"netflow_enabled":                   flattenDVSPortBoolPolicyOverride(obj.IpfixEnabled),
+	}
+
+	switch vlan := obj.Vlan.(type) {
+	case *types.VmwareDistributedVirtualSwitchVlanIdSpec:
+		attrs["vlan_id"] = int(vlan.VlanId)
+	case *types.VmwareDistributedVirtualSwitchTrunkVlanSpec:
+		attrs["vlan_range"] = flattenSliceOfNumericRangeVlans(vlan.VlanId)
+	case *types.VmwareDistributedVirtualSwitchPvlanSpec:
+		attrs["port_private_secondary_vlan_id"] = int(vlan.PvlanId)
+	}
+	if p := obj.UplinkTeamingPolicy; p != nil {
+		if p.Policy != nil {
+			attrs["teaming_policy"] = p.Policy.Value
+		}
+		attrs["notify_switches"] = flattenDVSPortBoolPolicyOverride(p.NotifySwitches)
+		if p.RollingOrder != nil && p.RollingOrder.Value != nil {
+			attrs["failback"] = strconv.FormatBool(!*p.RollingOrder.Value)
+		}
+		if p.FailureCriteria != nil {
+			attrs["check_beacon"] = flattenDVSPortBoolPolicyOverride(p.FailureCriteria.CheckBeacon)
+		}
+		if p.UplinkPortOrder != nil {
+			attrs["active_uplinks"] = p.UplinkPortOrder.ActiveUplinkPort
+			attrs["standby_uplinks"] = p.UplinkPortOrder.StandbyUplinkPort
+		}
+	}
+	if p := obj.SecurityPolicy; p != nil {
+		attrs["allow_promiscuous"] = flattenDVSPortBoolPolicyOverride(p.AllowPromiscuous)
+		attrs["allow_forged_transmits"] = flattenDVSPortBoolPolicyOverride(p.ForgedTransmits)
+		attrs["allow_mac_changes"] = flattenDVSPortBoolPolicyOverride(p.MacChanges)
+	}
+	for prefix, p := range map[string]*types.DVSTrafficShapingPolicy{
+		"ingress": obj.InShapingPolicy,
+		"egress":  obj.OutShapingPolicy,
+	} {
+		if p == nil {
+			continue
+		}
+		attrs[prefix+"_shaping_enabled"] = flattenDVSPortBoolPolicyOverride(p.Enabled)
+		attrs[prefix+"_shaping_average_bandwidth"] = flattenDVSPortLongPolicyOverride(p.AverageBandwidth)
+		attrs[prefix+"_shaping_peak_bandwidth"] = flattenDVSPortLongPolicyOverride(p.PeakBandwidth)
+		attrs[prefix+"_shaping_burst_size"] = flattenDVSPortLongPolicyOverride(p.BurstSize)
+	}
+	return structure.SetBatch(d, attrs)
+}
+
+// flattenDVSPortBoolPolicyOverride returns the value of a BoolPolicy as a
+// string, or an empty string if it is not set.
+func flattenDVSPortBoolPolicyOverride(p *types.BoolPolicy) string {
+	if p == nil || p.Value == nil {
+		return ""
+	}
+	return strconv.FormatBool(*p.Value)
+}
+
+// flattenDVSPortLongPolicyOverride returns the value of a LongPolicy, or -1
+// if it is not set.
+func flattenDVSPortLongPolicyOverride(p *types.LongPolicy) int {
+	if p == nil {
+		return -1
+	}
+	return int(p.Value)
+}
+
+// resourceVSphereDistributedPortValidateUnsetInt wraps the validation of an
+// integer override key so that it also accepts -1, which means unset.
+func resourceVSphereDistributedPortValidateUnsetInt(f schema.SchemaValidateFunc) schema.SchemaValidateFunc {
+	return func(v interface{}, k string) ([]string, []error) {
+		if v.(int) == -1 || f == nil {
+			return nil, nil
+		}
+		return f(v, k)
+	}
+}
+
+// resourceVSphereDistributedPortSettingSpec returns the setting to send to a
+// port given the overrides in configuration and the ones currently on the
+// port. Policies that are currently overridden but not in configuration are
+// set back to inherited, as are the unset parts of a configured policy, as
+// leaving them out of the spec would leave the port unchanged.
+func resourceVSphereDistributedPortSettingSpec(setting, current *types.VMwareDVSPortSetting) *types.VMwareDVSPortSetting {
+	if current == nil {
+		current = &types.VMwareDVSPortSetting{}
+	}
+	inherited := types.InheritablePolicy{Inherited: true}
+	spec := &types.VMwareDVSPortSetting{
+		DVPortSetting: types.DVPortSetting{
+			Blocked:          setting.Blocked,
+			InShapingPolicy:  setting.InShapingPolicy,
+			OutShapingPolicy: setting.OutShapingPolicy,
+		},
+		Vlan:                setting.Vlan,
+		UplinkTeamingPolicy: setting.UplinkTeamingPolicy,
+		SecurityPolicy:      setting.SecurityPolicy,
+		IpfixEnabled:        setting.IpfixEnabled,
+	}
+
+	if spec.Blocked == nil && current.Blocked != nil {
+		spec.Blocked = inheritedBoolPolicy(nil)
+	}
+	if spec.IpfixEnabled == nil && current.IpfixEnabled != nil {
+		spec.IpfixEnabled = inheritedBoolPolicy(nil)
+	}
+	if spec.Vlan == nil && current.Vlan != nil {
+		spec.Vlan = &types.VmwareDistributedVirtualSwitchVlanIdSpec{
+			VmwareDistributedVirtualSwitchVlanSpec: types.VmwareDistributedVirtualSwitchVlanSpec{
+				InheritablePolicy: inherited,
+			},
+		}
+	}
+	for _, p := range []struct {
+		spec    **types.DVSTrafficShapingPolicy
+		current *types.DVSTrafficShapingPolicy
+	}{
+		{&spec.InShapingPolicy, current.InShapingPolicy},
+		{&spec.OutShapingPolicy, current.OutShapingPolicy},
+	} {
+		switch {
+		case *p.spec != nil:
+			(*p.spec).Enabled = inheritedBoolPolicy((*p.spec).Enabled)
+			(*p.spec).AverageBandwidth = inheritedLongPolicy((*p.spec).AverageBandwidth)
+			(*p.spec).PeakBandwidth = inheritedLongPolicy((*p.spec).PeakBandwidth)
+			(*p.spec).BurstSize = inheritedLongPolicy((*p.spec).BurstSize)
+		case p.current != nil:
+			*p.spec = &types.DVSTrafficShapingPolicy{InheritablePolicy: inherited}
+		}
+	}
+	switch p := spec.UplinkTeamingPolicy; {
+	case p != nil:
+		p.Policy = inheritedStringPolicy(p.Policy)
+		p.NotifySwitches = inheritedBoolPolicy(p.NotifySwitches)
+		p.RollingOrder = inheritedBoolPolicy(p.RollingOrder)
+		if p.FailureCriteria == nil {
+			p.FailureCriteria = &types.DVSFailureCriteria{InheritablePolicy: inherited}
+		}
+		if p.UplinkPortOrder == nil {
+			p.UplinkPortOrder = &types.VMwareUplinkPortOrderPolicy{InheritablePolicy: inherited}
+		}
+	case current.UplinkTeamingPolicy != nil:
+		spec.UplinkTeamingPolicy = &types.VmwareUplinkPortTeamingPolicy{InheritablePolicy: inherited}
+	}
+	switch p := spec.SecurityPolicy; {
+	case p != nil:
+		p.AllowPromiscuous = inheritedBoolPolicy(p.AllowPromiscuous)
+		p.MacChanges = inheritedBoolPolicy(p.MacChanges)
+		p.ForgedTransmits = inheritedBoolPolicy(p.ForgedTransmits)
+	case current.SecurityPolicy != nil:
+		spec.SecurityPolicy = &types.DVSSecurityPolicy{InheritablePolicy: inherited}
+	}
+	return spec
+}
+
+// overriddenBoolPolicy returns p if it is set and not inherited, or nil
+// otherwise.
+func overriddenBoolPolicy(p *types.BoolPolicy) *types.BoolPolicy {
+	if p == nil || p.Inherited {
+		return nil
+	}
+	return p
+}
+
+// overriddenLongPolicy returns p if it is set and not inherited, or nil
+// otherwise.
+func overriddenLongPolicy(p *types.LongPolicy) *types.LongPolicy {
+	if p == nil || p.Inherited {
+		return nil
+	}
+	return p
+}
+
+// overriddenStringPolicy returns p if it is set and not inherited, or nil
+// otherwise.
+func overriddenStringPolicy(p *types.StringPolicy) *types.StringPolicy {
+	if p == nil || p.Inherited {
+		return nil
+	}
+	return p
+}
+
+// overriddenDVSTrafficShapingPolicy returns the parts of a
+// DVSTrafficShapingPolicy that are not inherited, or nil if the whole policy
+// is inherited.
+func overriddenDVSTrafficShapingPolicy(p *types.DVSTrafficShapingPolicy) *types.DVSTrafficShapingPolicy {
+	if p == nil || p.Inherited {
+		return nil
+	}
+	return &types.DVSTrafficShapingPolicy{
+		Enabled:          overriddenBoolPolicy(p.Enabled),
+		AverageBandwidth: overriddenLongPolicy(p.AverageBandwidth),
+		PeakBandwidth:    overriddenLongPolicy(p.PeakBandwidth),
+		BurstSize:        overriddenLongPolicy(p.BurstSize),
+	}
+}
+
+// inheritedBoolPolicy returns p, or an inherited BoolPolicy if p is nil.
+func inheritedBoolPolicy(p *types.BoolPolicy) *types.BoolPolicy {
+	if p != nil {
+		return p
+	}
+	return &types.BoolPolicy{InheritablePolicy: types.InheritablePolicy{Inherited: true}}
+}
+
+// inheritedLongPolicy returns p, or an inherited LongPolicy if p is nil.
+func inheritedLongPolicy(p *types.LongPolicy) *types.LongPolicy {
+	if p != nil {
+		return p
+	}
+	return &types.LongPolicy{InheritablePolicy: types.InheritablePolicy{Inherited: true}}
+}
+
+// inheritedStringPolicy returns p, or an inherited StringPolicy if p is nil.
+func inheritedStringPolicy(p *types.StringPolicy) *types.StringPolicy {
+	if p != nil {
+		return p
+	}
+	return &types.StringPolicy{InheritablePolicy: types.InheritablePolicy{Inherited: true}}
+}
+
+// resourceVSphereDistributedPortFlattenID makes an ID for the
+// vsphere_distributed_port resource.
+func resourceVSphereDistributedPortFlattenID(dvsID, key string) string {
+	return strings.Join([]string{dvsID, key}, ":")
+}
+
+// resourceVSphereDistributedPortParseID parses an ID for the
+// vsphere_distributed_port resource and outputs its parts.
+func resourceVSphereDistributedPortParseID(id string) (string, string, error) {
+	parts := strings.SplitN(id, ":", 2)
+	if len(parts) < 2 {
+		return "", "", fmt.Errorf("bad ID %q", id)
+	}
+	return parts[0], parts[1], nil
+}
+
+// resourceVSphereDistributedPortIDString prints a friendly string for the
+// vsphere_distributed_port resource.
+func resourceVSphereDistributedPortIDString(d structure.ResourceIDStringer) string {
+	return structure.ResourceIDString(d, resourceVSphereDistributedPortName)
+}
@@ -26,6 +26,12 @@ func resourceVSphereDistributedPortGroup() *schema.Resource {
 			Description: "The generated UUID of the portgroup.",
 			Computed:    true,
 		},
+		"port_keys": {
+			Type:        schema.TypeList,
+			Description: "The keys of the ports in the portgroup.",
+			Computed:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+		},
 		// Tagging
 		vSphereTagAttributeKey: tagsSchema(),
 		// Custom Attributes
@@ -123,6 +129,9 @@ func resourceVSphereDistributedPortGroupRead(d *schema.ResourceData, meta interf
 	}
 
 	d.Set("key", props.Key)
+	if err := d.Set("port_keys", props.PortKeys); err != nil {
+		return err
+	}
 
 	if err := flattenDVPortgroupConfigInfo(d, props.Config); err != nil {
 		return err
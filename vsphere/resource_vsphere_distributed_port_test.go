@@ -0,0 +1,302 @@
+package vsphere
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/structure"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+func TestAccResourceVSphereDistributedPort_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccResourceVSphereDistributedPortPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccResourceVSphereDistributedPortVlanOverridden(false),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceVSphereDistributedPortConfig(true, "vlan_id = 200"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereDistributedPortVlanOverridden(true),
+					testAccResourceVSphereDistributedPortVlanID(200),
+					resource.TestCheckResourceAttrPair(
+						"vsphere_distributed_port.port", "portgroup_key",
+						"vsphere_distributed_port_group.pg", "key",
+					),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceVSphereDistributedPort_removeOverride(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccResourceVSphereDistributedPortPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccResourceVSphereDistributedPortVlanOverridden(false),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceVSphereDistributedPortConfig(true, "vlan_id = 200"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereDistributedPortVlanOverridden(true),
+					testAccResourceVSphereDistributedPortVlanID(200),
+				),
+			},
+			{
+				Config: testAccResourceVSphereDistributedPortConfig(true, ""),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereDistributedPortVlanOverridden(false),
+					testAccResourceVSphereDistributedPortVlanID(100),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceVSphereDistributedPort_overrideNotAllowed(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccResourceVSphereDistributedPortPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccResourceVSphereDistributedPortConfig(false, "vlan_id = 200"),
+				ExpectError: regexp.MustCompile("enable vlan_override_allowed on the port group"),
+			},
+		},
+	})
+}
+
+func TestAccResourceVSphereDistributedPort_import(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccResourceVSphereDistributedPortPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccResourceVSphereDistributedPortVlanOverridden(false),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceVSphereDistributedPortConfig(true, "vlan_id = 200"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereDistributedPortVlanOverridden(true),
+				),
+			},
+			{
+				ResourceName:      "vsphere_distributed_port.port",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestExpandDVSPortSettingOverrides(t *testing.T) {
+	cases := map[string]struct {
+		Config   map[string]interface{}
+		Expected *types.VMwareDVSPortSetting
+	}{
+		"nothing overridden": {
+			Config:   map[string]interface{}{},
+			Expected: &types.VMwareDVSPortSetting{},
+		},
+		"zero values overridden": {
+			Config: map[string]interface{}{
+				"vlan_id":                           0,
+				"allow_promiscuous":                 "false",
+				"failback":                          "false",
+				"ingress_shaping_average_bandwidth": 0,
+			},
+			Expected: &types.VMwareDVSPortSetting{
+				DVPortSetting: types.DVPortSetting{
+					InShapingPolicy: &types.DVSTrafficShapingPolicy{
+						AverageBandwidth: &types.LongPolicy{Value: 0},
+					},
+				},
+				Vlan: &types.VmwareDistributedVirtualSwitchVlanIdSpec{VlanId: 0},
+				UplinkTeamingPolicy: &types.VmwareUplinkPortTeamingPolicy{
+					RollingOrder: &types.BoolPolicy{Value: structure.BoolPtr(true)},
+				},
+				SecurityPolicy: &types.DVSSecurityPolicy{
+					AllowPromiscuous: &types.BoolPolicy{Value: structure.BoolPtr(false)},
+				},
+			},
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			tc.Config["distributed_virtual_switch_uuid"] = "dvs-uuid"
+			tc.Config["port_key"] = "10"
+			d := schema.TestResourceDataRaw(t, resourceVSphereDistributedPort().Schema, tc.Config)
+			actual, err := expandDVSPortSettingOverrides(d)
+			if err != nil {
+				t.Fatalf("bad: %s", err)
+			}
+			if !reflect.DeepEqual(tc.Expected, actual) {
+				t.Fatalf("expected %#v, got %#v", tc.Expected, actual)
+			}
+		})
+	}
+}
+
+func TestFlattenDVSPortSettingOverrides(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceVSphereDistributedPort().Schema, map[string]interface{}{
+		"distributed_virtual_switch_uuid": "dvs-uuid",
+		"port_key":                        "10",
+		"vlan_id":                         200,
+		"allow_promiscuous":               "true",
+		"active_uplinks":                  []interface{}{"uplink1"},
+	})
+	obj := &types.VMwareDVSPortSetting{
+		UplinkTeamingPolicy: &types.VmwareUplinkPortTeamingPolicy{
+			Policy:       &types.StringPolicy{Value: "failover_explicit"},
+			RollingOrder: &types.BoolPolicy{Value: structure.BoolPtr(true)},
+		},
+		InShapingPolicy: &types.DVSTrafficShapingPolicy{
+			AverageBandwidth: &types.LongPolicy{Value: 0},
+		},
+	}
+	if err := flattenDVSPortSettingOverrides(d, obj); err != nil {
+		t.Fatalf("bad: %s", err)
+	}
+
+	expected := map[string]interface{}{
+		"teaming_policy":                    "failover_explicit",
+		"failback":                          "false",
+		"ingress_shaping_average_bandwidth": 0,
+		"vlan_id":                           -1,
+		"allow_promiscuous":                 "",
+		"ingress_shaping_enabled":           "",
+		"ingress_shaping_peak_bandwidth":    -1,
+		"active_uplinks.#":                  0,
+	}
+	for k, v := range expected {
+		if actual := d.Get(k); actual != v {
+			t.Fatalf("expected %s to be %#v, got %#v", k, v, actual)
+		}
+	}
+}
+
+func testAccResourceVSphereDistributedPortPreCheck(t *testing.T) {
+	if os.Getenv("VSPHERE_DATACENTER") == "" {
+		t.Skip("set VSPHERE_DATACENTER to run vsphere_distributed_port acceptance tests")
+	}
+}
+
+func testAccResourceVSphereDistributedPortVlanOverridden(expected bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		port, err := testGetDistributedPort(s, "port")
+		if err != nil {
+			if !expected && err.Error() == "no distributed port in state" {
+				return nil
+			}
+			return err
+		}
+		if port == nil {
+			if expected {
+				return errors.New("port not found")
+			}
+			return nil
+		}
+		setting := port.Config.Setting.(*types.VMwareDVSPortSetting)
+		actual := setting.Vlan != nil && !setting.Vlan.GetVmwareDistributedVirtualSwitchVlanSpec().Inherited
+		if actual != expected {
+			return fmt.Errorf("expected VLAN override to be %t, got %t", expected, actual)
+		}
+		return nil
+	}
+}
+
+func testAccResourceVSphereDistributedPortVlanID(expected int32) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		port, err := testGetDistributedPort(s, "port")
+		if err != nil {
+			return err
+		}
+		if port == nil {
+			return errors.New("port not found")
+		}
+		setting := port.Config.Setting.(*types.VMwareDVSPortSetting)
+		vlan, ok := setting.Vlan.(*types.VmwareDistributedVirtualSwitchVlanIdSpec)
+		if !ok {
+			return fmt.Errorf("expected VLAN ID spec, got %T", setting.Vlan)
+		}
+		if vlan.VlanId != expected {
+			return fmt.Errorf("expected VLAN ID to be %d, got %d", expected, vlan.VlanId)
+		}
+		return nil
+	}
+}
+
+// testGetDistributedPort is a convenience method to fetch a DVS port by
+// resource name. nil is returned if the port no longer exists.
+func testGetDistributedPort(s *terraform.State, resourceName string) (*types.DistributedVirtualPort, error) {
+	rs, ok := s.RootModule().Resources[fmt.Sprintf("vsphere_distributed_port.%s", resourceName)]
+	if !ok {
+		return nil, errors.New("no distributed port in state")
+	}
+	dvsID, key, err := resourceVSphereDistributedPortParseID(rs.Primary.ID)
+	if err != nil {
+		return nil, err
+	}
+	client := testAccProvider.Meta().(*VSphereClient).vimClient
+	dvs, err := dvsFromUUID(client, dvsID)
+	if err != nil {
+		return nil, err
+	}
+	return dvsPortFromKey(dvs, key)
+}
+
+func testAccResourceVSphereDistributedPortConfig(vlanOverrideAllowed bool, override string) string {
+	return fmt.Sprintf(`
+variable "datacenter" {
+  default = "%s"
+}
+
+data "vsphere_datacenter" "dc" {
+  name = "${var.datacenter}"
+}
+
+resource "vsphere_distributed_virtual_switch" "dvs" {
+  name          = "terraform-test-dvs"
+  datacenter_id = "${data.vsphere_datacenter.dc.id}"
+}
+
+resource "vsphere_distributed_port_group" "pg" {
+  name                            = "terraform-test-pg"
+  distributed_virtual_switch_uuid = "${vsphere_distributed_virtual_switch.dvs.id}"
+  type                            = "earlyBinding"
+  number_of_ports                 = 8
+  vlan_id                         = 100
+  vlan_override_allowed           = %t
+}
+
+resource "vsphere_distributed_port" "port" {
+  distributed_virtual_switch_uuid = "${vsphere_distributed_virtual_switch.dvs.id}"
+  port_key                        = "${vsphere_distributed_port_group.pg.port_keys[0]}"
+  name                            = "terraform-test-port"
+
+  %s
+}
+`,
+		os.Getenv("VSPHERE_DATACENTER"),
+		vlanOverrideAllowed,
+		override,
+	)
+}
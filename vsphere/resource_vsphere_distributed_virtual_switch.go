@@ -9,6 +9,7 @@ import (
 	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/folder"
 	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/structure"
 	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/viapi"
+	"github.com/vmware/govmomi"
 	"github.com/vmware/govmomi/object"
 	"github.com/vmware/govmomi/vim25/types"
 )
@@ -27,6 +28,12 @@ func resourceVSphereDistributedVirtualSwitch() *schema.Resource {
 			Optional:    true,
 			ForceNew:    true,
 		},
+		"backup_file": {
+			Type:        schema.TypeString,
+			Description: "The path to a local backup file written by the vsphere_distributed_virtual_switch_backup data source to create this virtual switch from. The original UUID and port group keys are preserved.",
+			Optional:    true,
+			ForceNew:    true,
+		},
 		"network_resource_control_enabled": {
 			Type:        schema.TypeBool,
 			Description: "Whether or not to enable network resource control, enabling advanced traffic shaping and resource control features.",
@@ -46,7 +53,8 @@ func resourceVSphereDistributedVirtualSwitch() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: resourceVSphereDistributedVirtualSwitchImport,
 		},
-		Schema: s,
+		CustomizeDiff: resourceVSphereDistributedVirtualSwitchCustomizeDiff,
+		Schema:        s,
 	}
 }
 
@@ -74,23 +82,39 @@ func resourceVSphereDistributedVirtualSwitchCreate(d *schema.ResourceData, meta
 		return fmt.Errorf("cannot locate folder: %s", err)
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
-	defer cancel()
-	spec := expandDVSCreateSpec(d)
-	task, err := fo.CreateDVS(ctx, spec)
-	if err != nil {
-		return fmt.Errorf("error creating DVS: %s", err)
-	}
-	tctx, tcancel := context.WithTimeout(context.Background(), defaultAPITimeout)
-	defer tcancel()
-	info, err := task.WaitForResult(tctx, nil)
-	if err != nil {
-		return fmt.Errorf("error waiting for DVS creation to complete: %s", err)
-	}
+	// Link aggregation groups can only be added once the DVS exists, so any
+	// uplink order that may reference them is deferred to a reconfigure after
+	// the groups have been created.
+	_, lacpGroups := d.GetOk("lacp_group")
+	backupFile, restore := d.GetOk("backup_file")
+	var dvs *object.VmwareDistributedVirtualSwitch
+	if restore {
+		dvs, err = resourceVSphereDistributedVirtualSwitchRestore(client, fo, d.Get("name").(string), backupFile.(string))
+		if err != nil {
+			return err
+		}
+	} else {
+		ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+		defer cancel()
+		spec := expandDVSCreateSpec(d)
+		if lacpGroups {
+			resourceVSphereDistributedVirtualSwitchClearUplinkOrder(spec.ConfigSpec.(*types.VMwareDVSConfigSpec))
+		}
+		task, err := fo.CreateDVS(ctx, spec)
+		if err != nil {
+			return fmt.Errorf("error creating DVS: %s", err)
+		}
+		tctx, tcancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+		defer tcancel()
+		info, err := task.WaitForResult(tctx, nil)
+		if err != nil {
+			return fmt.Errorf("error waiting for DVS creation to complete: %s", err)
+		}
 
-	dvs, err := dvsFromMOID(client, info.Result.(types.ManagedObjectReference).Value)
-	if err != nil {
-		return fmt.Errorf("error fetching DVS after creation: %s", err)
+		dvs, err = dvsFromMOID(client, info.Result.(types.ManagedObjectReference).Value)
+		if err != nil {
+			return fmt.Errorf("error fetching DVS after creation: %s", err)
+		}
 	}
 	props, err := dvsProperties(dvs)
 	if err != nil {
@@ -98,12 +122,54 @@ func resourceVSphereDistributedVirtualSwitchCreate(d *schema.ResourceData, meta
 	}
 
 	d.SetId(props.Uuid)
+	d.Set("config_version", props.Config.(*types.VMwareDVSConfigInfo).ConfigVersion)
 
 	// Enable network resource I/O control if it needs to be enabled
 	if d.Get("network_resource_control_enabled").(bool) {
 		enableDVSNetworkResourceManagement(client, dvs, true)
 	}
 
+	// Configure health checks if any settings have been defined
+	if resourceVSphereDistributedVirtualSwitchHasHealthCheckConfig(d) {
+		if err := resourceVSphereDistributedVirtualSwitchApplyHealthCheck(d, client, dvs); err != nil {
+			return err
+		}
+	}
+
+	// Add any link aggregation groups and apply the full configuration now
+	// that they exist. A DVS restored from a backup has the configuration
+	// applied on top of the backup here as well, which can include removing
+	// groups from the backup that are no longer configured.
+	if lacpGroups || restore {
+		if err := resourceVSphereDistributedVirtualSwitchAddLacpGroups(d, client, dvs); err != nil {
+			return err
+		}
+		// A new DVS was created with the private VLAN map already applied. The
+		// map of a restored DVS comes from the backup, and is reconciled with
+		// the configured map here.
+		spec := expandVMwareDVSConfigSpec(d)
+		spec.PvlanConfigSpec = nil
+		if restore {
+			spec.PvlanConfigSpec = expandSliceOfVMwareDVSPvlanConfigSpecFromEntries(
+				props.Config.(*types.VMwareDVSConfigInfo).PvlanConfig,
+				expandSliceOfVMwareDVSPvlanMapEntry(d.Get("pvlan_mapping").(*schema.Set)),
+			)
+		}
+		spec.LacpApiVersion = ""
+		if err := updateDVSConfiguration(client, dvs, spec); err != nil {
+			return fmt.Errorf("could not update DVS after creation: %s", err)
+		}
+		if err := resourceVSphereDistributedVirtualSwitchRemoveLacpGroups(d, client, dvs); err != nil {
+			return err
+		}
+	}
+
+	// Assign any devices mapped to uplinks and migrate VMkernel network
+	// adapters now that the hosts are members of the DVS.
+	if err := resourceVSphereDistributedVirtualSwitchUpdateHostNetworking(d, client, dvs); err != nil {
+		return err
+	}
+
 	// Apply any pending tags now
 	if tagsClient != nil {
 		if err := processTagDiff(tagsClient, d, object.NewReference(client.Client, dvs.Reference())); err != nil {
@@ -155,7 +221,11 @@ func resourceVSphereDistributedVirtualSwitchRead(d *schema.ResourceData, meta in
 	d.Set("folder", folder.NormalizePath(f))
 
 	// Read in config info
-	if err := flattenVMwareDVSConfigInfo(d, props.Config.(*types.VMwareDVSConfigInfo)); err != nil {
+	uplinkNames, err := dvsUplinkPortNames(dvs)
+	if err != nil {
+		return fmt.Errorf("error fetching DVS uplink ports: %s", err)
+	}
+	if err := flattenVMwareDVSConfigInfo(d, props.Config.(*types.VMwareDVSConfigInfo), uplinkNames); err != nil {
 		return err
 	}
 
@@ -223,11 +293,48 @@ func resourceVSphereDistributedVirtualSwitchUpdate(d *schema.ResourceData, meta
 		d.Set("config_version", props.Config.(*types.VMwareDVSConfigInfo).ConfigVersion)
 	}
 
+	// Link aggregation groups need to be in place before the uplink order can
+	// reference them, so new and changed groups are sent before the rest of
+	// the configuration, and removed groups after it.
+	lacpChanged := d.HasChange("lacp_group") || d.HasChange("lacp_api_version")
+	if lacpChanged {
+		if err := resourceVSphereDistributedVirtualSwitchAddLacpGroups(d, client, dvs); err != nil {
+			return err
+		}
+	}
+
+	// Health checks are not part of the DVS configuration spec and are updated
+	// separately.
+	if d.HasChange("vlan_mtu_health_check_enabled") ||
+		d.HasChange("vlan_mtu_health_check_interval") ||
+		d.HasChange("teaming_health_check_enabled") ||
+		d.HasChange("teaming_health_check_interval") {
+		if err := resourceVSphereDistributedVirtualSwitchApplyHealthCheck(d, client, dvs); err != nil {
+			return err
+		}
+	}
+
+	// The LACP API version is updated along with the link aggregation groups.
 	spec := expandVMwareDVSConfigSpec(d)
+	spec.LacpApiVersion = ""
 	if err := updateDVSConfiguration(client, dvs, spec); err != nil {
 		return fmt.Errorf("could not update DVS: %s", err)
 	}
 
+	if lacpChanged {
+		if err := resourceVSphereDistributedVirtualSwitchRemoveLacpGroups(d, client, dvs); err != nil {
+			return err
+		}
+	}
+
+	// Assign any devices mapped to uplinks and migrate VMkernel network
+	// adapters for new or changed hosts.
+	if d.HasChange("host") {
+		if err := resourceVSphereDistributedVirtualSwitchUpdateHostNetworking(d, client, dvs); err != nil {
+			return err
+		}
+	}
+
 	// Modify network I/O control if necessary
 	if d.HasChange("network_resource_control_enabled") {
 		enableDVSNetworkResourceManagement(client, dvs, d.Get("network_resource_control_enabled").(bool))
@@ -296,3 +403,231 @@ func resourceVSphereDistributedVirtualSwitchImport(d *schema.ResourceData, meta
 	d.SetId(props.Uuid)
 	return []*schema.ResourceData{d}, nil
 }
+
+// resourceVSphereDistributedVirtualSwitchCustomizeDiff checks that link
+// aggregation groups are only configured with the multipleLag LACP API
+// version.
+func resourceVSphereDistributedVirtualSwitchCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if len(d.Get("lacp_group").([]interface{})) < 1 {
+		return nil
+	}
+	if d.Get("lacp_api_version").(string) != string(types.VMwareDvsLacpApiVersionMultipleLag) {
+		return fmt.Errorf("lacp_group can only be used when lacp_api_version is %s", types.VMwareDvsLacpApiVersionMultipleLag)
+	}
+	return nil
+}
+
+// resourceVSphereDistributedVirtualSwitchAddLacpGroups moves the DVS to the
+// multipleLag LACP API version if required, and then adds and edits the link
+// aggregation groups on the DVS to match the configuration. This runs before
+// the main reconfigure, so that the uplink order can reference the groups.
+func resourceVSphereDistributedVirtualSwitchAddLacpGroups(d *schema.ResourceData, client *govmomi.Client, dvs *object.VmwareDistributedVirtualSwitch) error {
+	props, err := dvsProperties(dvs)
+	if err != nil {
+		return fmt.Errorf("error fetching DVS properties: %s", err)
+	}
+	config := props.Config.(*types.VMwareDVSConfigInfo)
+	version := d.Get("lacp_api_version").(string)
+	if version == string(types.VMwareDvsLacpApiVersionMultipleLag) && config.LacpApiVersion != version {
+		if err := resourceVSphereDistributedVirtualSwitchUpdateLacpAPIVersion(d, client, dvs, config.ConfigVersion, version); err != nil {
+			return err
+		}
+		if props, err = dvsProperties(dvs); err != nil {
+			return fmt.Errorf("error fetching DVS properties: %s", err)
+		}
+		config = props.Config.(*types.VMwareDVSConfigInfo)
+	}
+	specs, _ := expandSliceOfVMwareDvsLacpGroupSpec(d, config.LacpGroupConfig)
+	return resourceVSphereDistributedVirtualSwitchUpdateLacpGroups(d, client, dvs, specs)
+}
+
+// resourceVSphereDistributedVirtualSwitchRemoveLacpGroups removes the link
+// aggregation groups that are no longer configured from the DVS, and then
+// moves the DVS off of the multipleLag LACP API version if required. This
+// runs after the main reconfigure, once the uplink order no longer references
+// the removed groups.
+func resourceVSphereDistributedVirtualSwitchRemoveLacpGroups(d *schema.ResourceData, client *govmomi.Client, dvs *object.VmwareDistributedVirtualSwitch) error {
+	props, err := dvsProperties(dvs)
+	if err != nil {
+		return fmt.Errorf("error fetching DVS properties: %s", err)
+	}
+	_, specs := expandSliceOfVMwareDvsLacpGroupSpec(d, props.Config.(*types.VMwareDVSConfigInfo).LacpGroupConfig)
+	if err := resourceVSphereDistributedVirtualSwitchUpdateLacpGroups(d, client, dvs, specs); err != nil {
+		return err
+	}
+	if props, err = dvsProperties(dvs); err != nil {
+		return fmt.Errorf("error fetching DVS properties: %s", err)
+	}
+	config := props.Config.(*types.VMwareDVSConfigInfo)
+	version := d.Get("lacp_api_version").(string)
+	if version == "" || config.LacpApiVersion == version {
+		return nil
+	}
+	return resourceVSphereDistributedVirtualSwitchUpdateLacpAPIVersion(d, client, dvs, config.ConfigVersion, version)
+}
+
+// resourceVSphereDistributedVirtualSwitchUpdateLacpGroups sends the supplied
+// link aggregation group specs to the DVS. config_version is updated
+// afterwards so that a subsequent reconfigure does not fail with a
+// ConcurrentAccess error.
+func resourceVSphereDistributedVirtualSwitchUpdateLacpGroups(d *schema.ResourceData, client *govmomi.Client, dvs *object.VmwareDistributedVirtualSwitch, specs []types.VMwareDvsLacpGroupSpec) error {
+	if len(specs) < 1 {
+		return nil
+	}
+	if err := updateDVSLacpGroupConfig(client, dvs, specs); err != nil {
+		return fmt.Errorf("could not update link aggregation groups: %s", err)
+	}
+	props, err := dvsProperties(dvs)
+	if err != nil {
+		return fmt.Errorf("could not get DVS properties after updating link aggregation groups: %s", err)
+	}
+	d.Set("config_version", props.Config.(*types.VMwareDVSConfigInfo).ConfigVersion)
+	return nil
+}
+
+// resourceVSphereDistributedVirtualSwitchUpdateLacpAPIVersion changes the
+// LACP API version of the DVS on its own, and updates config_version
+// afterwards.
+func resourceVSphereDistributedVirtualSwitchUpdateLacpAPIVersion(d *schema.ResourceData, client *govmomi.Client, dvs *object.VmwareDistributedVirtualSwitch, configVersion, version string) error {
+	spec := &types.VMwareDVSConfigSpec{
+		DVSConfigSpec: types.DVSConfigSpec{
+			ConfigVersion: configVersion,
+		},
+		LacpApiVersion: version,
+	}
+	if err := updateDVSConfiguration(client, dvs, spec); err != nil {
+		return fmt.Errorf("could not update LACP API version: %s", err)
+	}
+	props, err := dvsProperties(dvs)
+	if err != nil {
+		return fmt.Errorf("could not get DVS properties after updating LACP API version: %s", err)
+	}
+	d.Set("config_version", props.Config.(*types.VMwareDVSConfigInfo).ConfigVersion)
+	return nil
+}
+
+// resourceVSphereDistributedVirtualSwitchHasHealthCheckConfig returns true if
+// any health check settings have been defined in the configuration.
+func resourceVSphereDistributedVirtualSwitchHasHealthCheckConfig(d *schema.ResourceData) bool {
+	for _, k := range []string{
+		"vlan_mtu_health_check_enabled",
+		"vlan_mtu_health_check_interval",
+		"teaming_health_check_enabled",
+		"teaming_health_check_interval",
+	} {
+		if _, ok := d.GetOk(k); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// resourceVSphereDistributedVirtualSwitchApplyHealthCheck updates the health
+// check configuration on the DVS. config_version is updated afterwards so
+// that a subsequent reconfigure does not fail with a ConcurrentAccess error.
+func resourceVSphereDistributedVirtualSwitchApplyHealthCheck(d *schema.ResourceData, client *govmomi.Client, dvs *object.VmwareDistributedVirtualSwitch) error {
+	if err := updateDVSHealthCheckConfig(client, dvs, expandSliceOfVMwareDVSHealthCheckConfig(d)); err != nil {
+		return fmt.Errorf("could not update health check configuration: %s", err)
+	}
+	props, err := dvsProperties(dvs)
+	if err != nil {
+		return fmt.Errorf("could not get DVS properties after updating health checks: %s", err)
+	}
+	d.Set("config_version", props.Config.(*types.VMwareDVSConfigInfo).ConfigVersion)
+	return nil
+}
+
+// resourceVSphereDistributedVirtualSwitchClearUplinkOrder removes the uplink
+// order from the default port configuration of a DVS config spec.
+func resourceVSphereDistributedVirtualSwitchClearUplinkOrder(spec *types.VMwareDVSConfigSpec) {
+	ps, ok := spec.DefaultPortConfig.(*types.VMwareDVSPortSetting)
+	if !ok || ps.UplinkTeamingPolicy == nil {
+		return
+	}
+	ps.UplinkTeamingPolicy.UplinkPortOrder = nil
+}
+
+// resourceVSphereDistributedVirtualSwitchUpdateHostNetworking assigns the
+// devices of any new or changed host entries that map devices to uplinks or
+// migrate VMkernel network adapters. This is done through a single network
+// update on each host, so that the devices are removed from any standard
+// virtual switches and the adapters are moved to the DVS atomically, without
+// the host losing connectivity in between.
+func resourceVSphereDistributedVirtualSwitchUpdateHostNetworking(d *schema.ResourceData, client *govmomi.Client, dvs *object.VmwareDistributedVirtualSwitch) error {
+	o, n := d.GetChange("host")
+	for _, v := range n.(*schema.Set).Difference(o.(*schema.Set)).List() {
+		m := v.(map[string]interface{})
+		if !distributedVirtualSwitchHostMemberUsesNetworkUpdate(m) {
+			continue
+		}
+		hsID := m["host_system_id"].(string)
+		ns, err := hostNetworkSystemFromHostSystemID(client, hsID)
+		if err != nil {
+			return fmt.Errorf("error loading host network system for host %q: %s", hsID, err)
+		}
+		vswitches, err := hostVSwitchList(client, ns)
+		if err != nil {
+			return err
+		}
+		ports, err := dvsUplinkPorts(dvs, types.ManagedObjectReference{Type: "HostSystem", Value: hsID})
+		if err != nil {
+			return fmt.Errorf("error fetching uplink ports for host %q: %s", hsID, err)
+		}
+		uplinkKeys := make(map[string]string)
+		for _, port := range ports {
+			if port.ProxyHost != nil && port.ProxyHost.Value == hsID {
+				uplinkKeys[port.Config.Name] = port.Key
+			}
+		}
+		config, err := expandHostNetworkConfigForDVSHostMember(m, d.Id(), uplinkKeys, vswitches)
+		if err != nil {
+			return err
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+		_, err = ns.UpdateNetworkConfig(ctx, *config, string(types.HostConfigChangeModeModify))
+		cancel()
+		if err != nil {
+			return fmt.Errorf("error updating network configuration for host %q: %s", hsID, err)
+		}
+	}
+	return nil
+}
+
+// resourceVSphereDistributedVirtualSwitchRestore creates a DVS and its port
+// groups from a backup file, in the supplied folder. The DVS keeps its
+// original UUID and the port groups keep their original keys, so that
+// references to them from elsewhere remain valid.
+func resourceVSphereDistributedVirtualSwitchRestore(client *govmomi.Client, fo *object.Folder, name, path string) (*object.VmwareDistributedVirtualSwitch, error) {
+	backup, err := readDVSBackupFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading backup file: %s", err)
+	}
+	var found bool
+	for i := range backup {
+		if backup[i].EntityType != string(types.EntityTypeDistributedVirtualSwitch) {
+			continue
+		}
+		if found {
+			return nil, fmt.Errorf("backup file %q contains more than one DVS", path)
+		}
+		found = true
+		ref := fo.Reference()
+		backup[i].Container = &ref
+		backup[i].Name = name
+	}
+	if !found {
+		return nil, fmt.Errorf("backup file %q does not contain a DVS", path)
+	}
+	result, err := importDVSEntities(client, backup, types.EntityImportTypeCreateEntityWithOriginalIdentifier)
+	if err != nil {
+		return nil, fmt.Errorf("error restoring DVS from backup: %s", err)
+	}
+	if len(result.DistributedVirtualSwitch) < 1 {
+		return nil, fmt.Errorf("no DVS was created when restoring from backup file %q", path)
+	}
+	dvs, err := dvsFromMOID(client, result.DistributedVirtualSwitch[0].Value)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching DVS after restore: %s", err)
+	}
+	return dvs, nil
+}
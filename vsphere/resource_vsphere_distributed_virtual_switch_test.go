@@ -1,17 +1,23 @@
 package vsphere
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os"
 	"path"
+	"path/filepath"
 	"reflect"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/dvportgroup"
 	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/folder"
 	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/viapi"
+	"github.com/vmware/govmomi"
+	"github.com/vmware/govmomi/vim25/mo"
 	"github.com/vmware/govmomi/vim25/types"
 )
 
@@ -345,6 +351,232 @@ func TestAccResourceVSphereDistributedVirtualSwitch_vlanRanges(t *testing.T) {
 	})
 }
 
+func TestAccResourceVSphereDistributedVirtualSwitch_lacpGroups(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccResourceVSphereDistributedVirtualSwitchPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccResourceVSphereDistributedVirtualSwitchExists(false),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceVSphereDistributedVirtualSwitchConfigLacpGroups("lag1", 2, "active"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereDistributedVirtualSwitchExists(true),
+					testAccResourceVSphereDistributedVirtualSwitchHasLacpGroup("lag1", 2, "active"),
+					testAccResourceVSphereDistributedVirtualSwitchHasActiveUplinks([]string{"lag1"}),
+					resource.TestCheckResourceAttr("vsphere_distributed_virtual_switch.dvs", "lacp_group.0.uplinks.#", "2"),
+				),
+			},
+			{
+				Config: testAccResourceVSphereDistributedVirtualSwitchConfigLacpGroups("lag1", 4, "passive"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereDistributedVirtualSwitchExists(true),
+					testAccResourceVSphereDistributedVirtualSwitchHasLacpGroup("lag1", 4, "passive"),
+					testAccResourceVSphereDistributedVirtualSwitchHasActiveUplinks([]string{"lag1"}),
+				),
+			},
+			{
+				Config: testAccResourceVSphereDistributedVirtualSwitchConfigLacpGroups("lag2", 2, "active"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereDistributedVirtualSwitchExists(true),
+					testAccResourceVSphereDistributedVirtualSwitchHasLacpGroup("lag2", 2, "active"),
+					testAccResourceVSphereDistributedVirtualSwitchHasActiveUplinks([]string{"lag2"}),
+					resource.TestCheckResourceAttr("vsphere_distributed_virtual_switch.dvs", "lacp_group.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceVSphereDistributedVirtualSwitch_lacpGroupsSingleLag(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccResourceVSphereDistributedVirtualSwitchPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccResourceVSphereDistributedVirtualSwitchConfigLacpGroupsSingleLag(),
+				ExpectError: regexp.MustCompile("lacp_group can only be used when lacp_api_version is multipleLag"),
+				PlanOnly:    true,
+			},
+			{
+				Config: testAccResourceVSphereEmpty,
+				Check:  resource.ComposeTestCheckFunc(),
+			},
+		},
+	})
+}
+
+func TestAccResourceVSphereDistributedVirtualSwitch_pvlanMapping(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccResourceVSphereDistributedVirtualSwitchPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccResourceVSphereDistributedVirtualSwitchExists(false),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceVSphereDistributedVirtualSwitchConfigPvlanMapping("isolated"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereDistributedVirtualSwitchExists(true),
+					testAccResourceVSphereDistributedVirtualSwitchHasPvlanMapping(1000, 1000, "promiscuous"),
+					testAccResourceVSphereDistributedVirtualSwitchHasPvlanMapping(1000, 1001, "isolated"),
+					resource.TestCheckResourceAttr("vsphere_distributed_virtual_switch.dvs", "pvlan_mapping.#", "2"),
+				),
+			},
+			{
+				Config: testAccResourceVSphereDistributedVirtualSwitchConfigPvlanMapping("community"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereDistributedVirtualSwitchExists(true),
+					testAccResourceVSphereDistributedVirtualSwitchHasPvlanMapping(1000, 1001, "community"),
+					resource.TestCheckResourceAttr("vsphere_distributed_virtual_switch.dvs", "pvlan_mapping.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceVSphereDistributedVirtualSwitch_uplinkMapping(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccResourceVSphereDistributedVirtualSwitchPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccResourceVSphereDistributedVirtualSwitchExists(false),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceVSphereDistributedVirtualSwitchConfigUplinkMapping("uplink2"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereDistributedVirtualSwitchExists(true),
+					testAccResourceVSphereDistributedVirtualSwitchHasHostUplink(os.Getenv("VSPHERE_HOST_NIC0"), "uplink2"),
+				),
+			},
+			{
+				Config: testAccResourceVSphereDistributedVirtualSwitchConfigUplinkMapping("uplink3"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereDistributedVirtualSwitchExists(true),
+					testAccResourceVSphereDistributedVirtualSwitchHasHostUplink(os.Getenv("VSPHERE_HOST_NIC0"), "uplink3"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceVSphereDistributedVirtualSwitch_vmknicMigration(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccResourceVSphereDistributedVirtualSwitchPreCheck(t)
+			if os.Getenv("VSPHERE_HOST_VMKNIC") == "" {
+				t.Skip("set VSPHERE_HOST_VMKNIC to run vsphere_distributed_virtual_switch VMkernel adapter migration acceptance tests")
+			}
+			if os.Getenv("VSPHERE_HOST_VMKNIC_PORTGROUP") == "" {
+				t.Skip("set VSPHERE_HOST_VMKNIC_PORTGROUP to run vsphere_distributed_virtual_switch VMkernel adapter migration acceptance tests")
+			}
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccResourceVSphereDistributedVirtualSwitchExists(false),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceVSphereDistributedVirtualSwitchConfigVmknicMigration(false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereDistributedVirtualSwitchExists(true),
+					testAccResourceVSphereDistributedVirtualSwitchHasHostUplink(os.Getenv("VSPHERE_HOST_NIC0"), "uplink1"),
+				),
+			},
+			{
+				Config: testAccResourceVSphereDistributedVirtualSwitchConfigVmknicMigration(true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereDistributedVirtualSwitchExists(true),
+					testAccResourceVSphereDistributedVirtualSwitchVmknicOnPortgroup(os.Getenv("VSPHERE_HOST_VMKNIC")),
+					// Move the adapter back so that the DVS can be destroyed.
+					testAccResourceVSphereDistributedVirtualSwitchRestoreVmknic(os.Getenv("VSPHERE_HOST_VMKNIC"), os.Getenv("VSPHERE_HOST_VMKNIC_PORTGROUP")),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceVSphereDistributedVirtualSwitch_restoreFromBackup(t *testing.T) {
+	backupPath := filepath.Join(os.TempDir(), "terraform-test-dvs-restore.json")
+	defer os.Remove(backupPath)
+	var uuid, pgKey string
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccResourceVSphereDistributedVirtualSwitchExists(false),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceVSphereDistributedVirtualSwitchBackupConfig(backupPath),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereDistributedVirtualSwitchExists(true),
+					func(s *terraform.State) error {
+						uuid = s.RootModule().Resources["vsphere_distributed_virtual_switch.dvs"].Primary.ID
+						pgKey = s.RootModule().Resources["vsphere_distributed_port_group.pg"].Primary.ID
+						return nil
+					},
+				),
+			},
+			{
+				Config: testAccResourceVSphereDistributedVirtualSwitchConfigRestore(""),
+				Check:  testAccResourceVSphereDistributedVirtualSwitchExists(false),
+			},
+			{
+				Config: testAccResourceVSphereDistributedVirtualSwitchConfigRestore(backupPath),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereDistributedVirtualSwitchExists(true),
+					func(s *terraform.State) error {
+						if actual := s.RootModule().Resources["vsphere_distributed_virtual_switch.dvs"].Primary.ID; actual != uuid {
+							return fmt.Errorf("expected restored DVS to have UUID %q, got %q", uuid, actual)
+						}
+						return nil
+					},
+					func(s *terraform.State) error {
+						_, err := dvportgroup.FromKey(testAccProvider.Meta().(*VSphereClient).vimClient, uuid, pgKey)
+						return err
+					},
+					testAccResourceVSphereDistributedVirtualSwitchHasPvlanMapping(1000, 1000, "promiscuous"),
+					testAccResourceVSphereDistributedVirtualSwitchHasPvlanMapping(1000, 1001, "isolated"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceVSphereDistributedVirtualSwitch_healthCheck(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccResourceVSphereDistributedVirtualSwitchExists(false),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceVSphereDistributedVirtualSwitchConfigHealthCheck(true, 1),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereDistributedVirtualSwitchExists(true),
+					testAccResourceVSphereDistributedVirtualSwitchHasHealthCheck(true, 1),
+				),
+			},
+			{
+				Config: testAccResourceVSphereDistributedVirtualSwitchConfigHealthCheck(false, 5),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereDistributedVirtualSwitchExists(true),
+					testAccResourceVSphereDistributedVirtualSwitchHasHealthCheck(false, 5),
+				),
+			},
+		},
+	})
+}
+
 func TestAccResourceVSphereDistributedVirtualSwitch_import(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck: func() {
@@ -535,6 +767,176 @@ func testAccResourceVSphereDistributedVirtualSwitchHasActiveUplinks(expected []s
 	}
 }
 
+func testAccResourceVSphereDistributedVirtualSwitchHasLacpGroup(name string, uplinks int32, mode string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		props, err := testGetDVSProperties(s, "dvs")
+		if err != nil {
+			return err
+		}
+		for _, group := range props.Config.(*types.VMwareDVSConfigInfo).LacpGroupConfig {
+			if group.Name != name {
+				continue
+			}
+			if group.UplinkNum != uplinks {
+				return fmt.Errorf("expected LAG %q to have %d uplinks, got %d", name, uplinks, group.UplinkNum)
+			}
+			if group.Mode != mode {
+				return fmt.Errorf("expected LAG %q to have mode %q, got %q", name, mode, group.Mode)
+			}
+			return nil
+		}
+		return fmt.Errorf("could not find LAG %q", name)
+	}
+}
+
+func testAccResourceVSphereDistributedVirtualSwitchHasPvlanMapping(primary, secondary int32, pvlanType string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		props, err := testGetDVSProperties(s, "dvs")
+		if err != nil {
+			return err
+		}
+		for _, entry := range props.Config.(*types.VMwareDVSConfigInfo).PvlanConfig {
+			if entry.SecondaryVlanId != secondary {
+				continue
+			}
+			if entry.PrimaryVlanId != primary || entry.PvlanType != pvlanType {
+				return fmt.Errorf("expected PVLAN %d to be %s in primary VLAN %d, got %s in primary VLAN %d", secondary, pvlanType, primary, entry.PvlanType, entry.PrimaryVlanId)
+			}
+			return nil
+		}
+		return fmt.Errorf("could not find PVLAN %d", secondary)
+	}
+}
+
+func testAccResourceVSphereDistributedVirtualSwitchHasHostUplink(device, uplink string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		dvs, err := testGetDVS(s, "dvs")
+		if err != nil {
+			return err
+		}
+		props, err := dvsProperties(dvs)
+		if err != nil {
+			return err
+		}
+		names, err := dvsUplinkPortNames(dvs)
+		if err != nil {
+			return err
+		}
+		for _, host := range props.Config.(*types.VMwareDVSConfigInfo).Host {
+			backing := host.Config.Backing.(*types.DistributedVirtualSwitchHostMemberPnicBacking)
+			for _, spec := range backing.PnicSpec {
+				if spec.PnicDevice != device {
+					continue
+				}
+				if names[spec.UplinkPortKey] != uplink {
+					return fmt.Errorf("expected %s on host %s to be assigned to %q, got %q", device, host.Config.Host.Value, uplink, names[spec.UplinkPortKey])
+				}
+			}
+		}
+		return nil
+	}
+}
+
+// testAccResourceVSphereDistributedVirtualSwitchVmknicOnPortgroup checks that
+// a VMkernel adapter on the test host is connected to the test DVS port
+// group.
+func testAccResourceVSphereDistributedVirtualSwitchVmknicOnPortgroup(device string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		vars, err := testClientVariablesForResource(s, "data.vsphere_host.host")
+		if err != nil {
+			return err
+		}
+		pg, err := testClientVariablesForResource(s, "vsphere_distributed_port_group.pg")
+		if err != nil {
+			return err
+		}
+		vnic, err := testGetHostVirtualNic(vars.client, vars.resourceID, device)
+		if err != nil {
+			return err
+		}
+		port := vnic.Spec.DistributedVirtualPort
+		if port == nil {
+			return fmt.Errorf("expected %s to be connected to a DVS, got port group %q", device, vnic.Spec.Portgroup)
+		}
+		if port.PortgroupKey != pg.resourceAttributes["key"] {
+			return fmt.Errorf("expected %s to be on port group %q, got %q", device, pg.resourceAttributes["key"], port.PortgroupKey)
+		}
+		return nil
+	}
+}
+
+// testAccResourceVSphereDistributedVirtualSwitchRestoreVmknic moves a
+// VMkernel adapter on the test host back to a standard port group.
+func testAccResourceVSphereDistributedVirtualSwitchRestoreVmknic(device, portgroup string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		vars, err := testClientVariablesForResource(s, "data.vsphere_host.host")
+		if err != nil {
+			return err
+		}
+		ns, err := hostNetworkSystemFromHostSystemID(vars.client, vars.resourceID)
+		if err != nil {
+			return err
+		}
+		config := types.HostNetworkConfig{
+			Vnic: []types.HostVirtualNicConfig{
+				{
+					ChangeOperation: string(types.HostConfigChangeOperationEdit),
+					Device:          device,
+					Portgroup:       portgroup,
+					Spec:            &types.HostVirtualNicSpec{},
+				},
+			},
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+		defer cancel()
+		_, err = ns.UpdateNetworkConfig(ctx, config, string(types.HostConfigChangeModeModify))
+		return err
+	}
+}
+
+// testGetHostVirtualNic returns a VMkernel adapter on a host by device name.
+func testGetHostVirtualNic(client *govmomi.Client, hsID, device string) (*types.HostVirtualNic, error) {
+	ns, err := hostNetworkSystemFromHostSystemID(client, hsID)
+	if err != nil {
+		return nil, err
+	}
+	var mns mo.HostNetworkSystem
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	if err := client.PropertyCollector().RetrieveOne(ctx, ns.Reference(), []string{"networkInfo.vnic"}, &mns); err != nil {
+		return nil, err
+	}
+	for _, vnic := range mns.NetworkInfo.Vnic {
+		if vnic.Device == device {
+			return &vnic, nil
+		}
+	}
+	return nil, fmt.Errorf("VMkernel adapter %q not found on host %q", device, hsID)
+}
+
+func testAccResourceVSphereDistributedVirtualSwitchHasHealthCheck(enabled bool, interval int32) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		props, err := testGetDVSProperties(s, "dvs")
+		if err != nil {
+			return err
+		}
+		checks := props.Config.(*types.VMwareDVSConfigInfo).HealthCheckConfig
+		if len(checks) < 2 {
+			return fmt.Errorf("expected 2 health checks, got %d", len(checks))
+		}
+		for _, check := range checks {
+			config := check.GetDVSHealthCheckConfig()
+			if config.Enable == nil || *config.Enable != enabled {
+				return fmt.Errorf("expected health check %T enabled to be %t", check, enabled)
+			}
+			if config.Interval != interval {
+				return fmt.Errorf("expected health check %T interval to be %d, got %d", check, interval, config.Interval)
+			}
+		}
+		return nil
+	}
+}
+
 func testAccResourceVSphereDistributedVirtualSwitchHasStandbyUplinks(expected []string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		props, err := testGetDVSProperties(s, "dvs")
@@ -1033,6 +1435,309 @@ resource "vsphere_distributed_virtual_switch" "dvs" {
 	)
 }
 
+func testAccResourceVSphereDistributedVirtualSwitchConfigLacpGroups(name string, uplinks int, mode string) string {
+	return fmt.Sprintf(`
+variable "datacenter" {
+  default = "%s"
+}
+
+data "vsphere_datacenter" "dc" {
+  name = "${var.datacenter}"
+}
+
+resource "vsphere_distributed_virtual_switch" "dvs" {
+  name             = "terraform-test-dvs"
+  datacenter_id    = "${data.vsphere_datacenter.dc.id}"
+  lacp_api_version = "multipleLag"
+
+  lacp_group {
+    name         = "%s"
+    uplink_count = %d
+    mode         = "%s"
+  }
+
+  active_uplinks  = ["%s"]
+  standby_uplinks = []
+}
+`,
+		os.Getenv("VSPHERE_DATACENTER"),
+		name,
+		uplinks,
+		mode,
+		name,
+	)
+}
+
+func testAccResourceVSphereDistributedVirtualSwitchConfigLacpGroupsSingleLag() string {
+	return fmt.Sprintf(`
+variable "datacenter" {
+  default = "%s"
+}
+
+data "vsphere_datacenter" "dc" {
+  name = "${var.datacenter}"
+}
+
+resource "vsphere_distributed_virtual_switch" "dvs" {
+  name             = "terraform-test-dvs"
+  datacenter_id    = "${data.vsphere_datacenter.dc.id}"
+  lacp_api_version = "singleLag"
+
+  lacp_group {
+    name         = "lag1"
+    uplink_count = 2
+  }
+}
+`,
+		os.Getenv("VSPHERE_DATACENTER"),
+	)
+}
+
+func testAccResourceVSphereDistributedVirtualSwitchConfigPvlanMapping(pvlanType string) string {
+	return fmt.Sprintf(`
+variable "datacenter" {
+  default = "%s"
+}
+
+data "vsphere_datacenter" "dc" {
+  name = "${var.datacenter}"
+}
+
+resource "vsphere_distributed_virtual_switch" "dvs" {
+  name          = "terraform-test-dvs"
+  datacenter_id = "${data.vsphere_datacenter.dc.id}"
+
+  pvlan_mapping {
+    primary_vlan_id   = 1000
+    secondary_vlan_id = 1000
+    pvlan_type        = "promiscuous"
+  }
+
+  pvlan_mapping {
+    primary_vlan_id   = 1000
+    secondary_vlan_id = 1001
+    pvlan_type        = "%s"
+  }
+}
+
+resource "vsphere_distributed_port_group" "pg" {
+  name                            = "terraform-test-pg"
+  distributed_virtual_switch_uuid = "${vsphere_distributed_virtual_switch.dvs.id}"
+  port_private_secondary_vlan_id  = 1001
+}
+`,
+		os.Getenv("VSPHERE_DATACENTER"),
+		pvlanType,
+	)
+}
+
+func testAccResourceVSphereDistributedVirtualSwitchConfigVmknicMigration(migrate bool) string {
+	// The port group is looked up through a data source in the second step,
+	// as referencing the port group resource from the DVS would be a cycle.
+	var migration, network string
+	if migrate {
+		migration = `
+    vmknic_migration {
+      device        = "${var.vmknic}"
+      portgroup_key = "${data.vsphere_network.pg.id}"
+    }
+`
+		network = `
+data "vsphere_network" "pg" {
+  name          = "terraform-test-vmknic-pg"
+  datacenter_id = "${data.vsphere_datacenter.dc.id}"
+}
+`
+	}
+	return fmt.Sprintf(`
+variable "datacenter" {
+  default = "%s"
+}
+
+variable "esxi_host" {
+  default = "%s"
+}
+
+variable "network_interface" {
+  default = "%s"
+}
+
+variable "vmknic" {
+  default = "%s"
+}
+
+data "vsphere_datacenter" "dc" {
+  name = "${var.datacenter}"
+}
+
+data "vsphere_host" "host" {
+  name          = "${var.esxi_host}"
+  datacenter_id = "${data.vsphere_datacenter.dc.id}"
+}
+
+resource "vsphere_distributed_virtual_switch" "dvs" {
+  name          = "terraform-test-dvs"
+  datacenter_id = "${data.vsphere_datacenter.dc.id}"
+
+  uplinks = ["uplink1", "uplink2"]
+
+  host {
+    host_system_id = "${data.vsphere_host.host.id}"
+    devices        = ["${var.network_interface}"]
+    uplinks        = ["uplink1"]
+%s  }
+}
+
+resource "vsphere_distributed_port_group" "pg" {
+  name                            = "terraform-test-vmknic-pg"
+  distributed_virtual_switch_uuid = "${vsphere_distributed_virtual_switch.dvs.id}"
+}
+%s`,
+		os.Getenv("VSPHERE_DATACENTER"),
+		os.Getenv("VSPHERE_ESXI_HOST"),
+		os.Getenv("VSPHERE_HOST_NIC0"),
+		os.Getenv("VSPHERE_HOST_VMKNIC"),
+		migration,
+		network,
+	)
+}
+
+func testAccResourceVSphereDistributedVirtualSwitchConfigUplinkMapping(uplink string) string {
+	return fmt.Sprintf(`
+variable "datacenter" {
+  default = "%s"
+}
+
+variable "esxi_hosts" {
+  default = [
+    "%s",
+    "%s",
+    "%s",
+  ]
+}
+
+variable "network_interfaces" {
+  default = [
+    "%s",
+  ]
+}
+
+data "vsphere_datacenter" "dc" {
+  name = "${var.datacenter}"
+}
+
+data "vsphere_host" "host" {
+  count         = "${length(var.esxi_hosts)}"
+  name          = "${var.esxi_hosts[count.index]}"
+  datacenter_id = "${data.vsphere_datacenter.dc.id}"
+}
+
+resource "vsphere_distributed_virtual_switch" "dvs" {
+  name          = "terraform-test-dvs"
+  datacenter_id = "${data.vsphere_datacenter.dc.id}"
+
+  uplinks = ["uplink1", "uplink2", "uplink3", "uplink4"]
+
+  host {
+    host_system_id = "${data.vsphere_host.host.0.id}"
+    devices        = ["${var.network_interfaces}"]
+    uplinks        = ["%s"]
+  }
+
+  host {
+    host_system_id = "${data.vsphere_host.host.1.id}"
+    devices        = ["${var.network_interfaces}"]
+    uplinks        = ["%s"]
+  }
+
+  host {
+    host_system_id = "${data.vsphere_host.host.2.id}"
+    devices        = ["${var.network_interfaces}"]
+    uplinks        = ["%s"]
+  }
+}
+`,
+		os.Getenv("VSPHERE_DATACENTER"),
+		os.Getenv("VSPHERE_ESXI_HOST"),
+		os.Getenv("VSPHERE_ESXI_HOST2"),
+		os.Getenv("VSPHERE_ESXI_HOST3"),
+		os.Getenv("VSPHERE_HOST_NIC0"),
+		uplink,
+		uplink,
+		uplink,
+	)
+}
+
+func testAccResourceVSphereDistributedVirtualSwitchConfigHealthCheck(enabled bool, interval int) string {
+	return fmt.Sprintf(`
+variable "datacenter" {
+  default = "%s"
+}
+
+data "vsphere_datacenter" "dc" {
+  name = "${var.datacenter}"
+}
+
+resource "vsphere_distributed_virtual_switch" "dvs" {
+  name          = "terraform-test-dvs"
+  datacenter_id = "${data.vsphere_datacenter.dc.id}"
+
+  vlan_mtu_health_check_enabled  = %t
+  vlan_mtu_health_check_interval = %d
+  teaming_health_check_enabled   = %t
+  teaming_health_check_interval  = %d
+}
+`,
+		os.Getenv("VSPHERE_DATACENTER"),
+		enabled,
+		interval,
+		enabled,
+		interval,
+	)
+}
+
+// testAccResourceVSphereDistributedVirtualSwitchConfigRestore returns a
+// configuration that restores a DVS from the supplied backup file, with a
+// private VLAN map that is not part of the backup. An empty path returns a
+// configuration with no DVS, used to remove the original DVS while keeping the
+// backup.
+func testAccResourceVSphereDistributedVirtualSwitchConfigRestore(backupPath string) string {
+	var dvs string
+	if backupPath != "" {
+		dvs = fmt.Sprintf(`
+resource "vsphere_distributed_virtual_switch" "dvs" {
+  name          = "terraform-test-dvs"
+  datacenter_id = "${data.vsphere_datacenter.dc.id}"
+  backup_file   = "%s"
+
+  pvlan_mapping {
+    primary_vlan_id   = 1000
+    secondary_vlan_id = 1000
+    pvlan_type        = "promiscuous"
+  }
+
+  pvlan_mapping {
+    primary_vlan_id   = 1000
+    secondary_vlan_id = 1001
+    pvlan_type        = "isolated"
+  }
+}
+`, backupPath)
+	}
+	return fmt.Sprintf(`
+variable "datacenter" {
+  default = "%s"
+}
+
+data "vsphere_datacenter" "dc" {
+  name = "${var.datacenter}"
+}
+%s`,
+		os.Getenv("VSPHERE_DATACENTER"),
+		dvs,
+	)
+}
+
 func testAccResourceVSphereDistributedVirtualSwitchConfigInFolder() string {
 	return fmt.Sprintf(`
 variable "datacenter" {
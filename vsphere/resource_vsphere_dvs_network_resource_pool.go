@@ -0,0 +1,304 @@
+package vsphere
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/structure"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/viapi"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+const resourceVSphereDVSNetworkResourcePoolName = "vsphere_dvs_network_resource_pool"
+
+func resourceVSphereDVSNetworkResourcePool() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceVSphereDVSNetworkResourcePoolCreate,
+		Read:   resourceVSphereDVSNetworkResourcePoolRead,
+		Update: resourceVSphereDVSNetworkResourcePoolUpdate,
+		Delete: resourceVSphereDVSNetworkResourcePoolDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceVSphereDVSNetworkResourcePoolImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"distributed_virtual_switch_uuid": {
+				Type:        schema.TypeString,
+				Description: "The UUID of the DVS to create the network resource pool on.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Description: "The name of the network resource pool.",
+				Required:    true,
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Description: "The description of the network resource pool.",
+				Optional:    true,
+			},
+			"reservation_mbit": {
+				Type:         schema.TypeInt,
+				Description:  "The bandwidth, in Mbits/sec, reserved for the virtual machine network adapters in this pool. This is taken from the reservation of the virtualMachine traffic class on the DVS.",
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"key": {
+				Type:        schema.TypeString,
+				Description: "The key of the network resource pool, used in network_resource_pool_key on port groups.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourceVSphereDVSNetworkResourcePoolCreate(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] %s: Beginning create", resourceVSphereDVSNetworkResourcePoolIDString(d))
+	client := meta.(*VSphereClient).vimClient
+	if err := viapi.ValidateVirtualCenter(client); err != nil {
+		return err
+	}
+	dvsID := d.Get("distributed_virtual_switch_uuid").(string)
+	dvs, err := dvsFromUUID(client, dvsID)
+	if err != nil {
+		return fmt.Errorf("could not find DVS %q: %s", dvsID, err)
+	}
+	pools, err := resourceVSphereDVSNetworkResourcePoolList(dvs)
+	if err != nil {
+		return err
+	}
+	existing := make(map[string]bool)
+	for _, pool := range pools {
+		existing[pool.Key] = true
+	}
+
+	spec := expandDvsVmVnicResourcePoolConfigSpec(d)
+	spec.Operation = string(types.ConfigSpecOperationAdd)
+	if err := reconfigureDVSVmVnicNetworkResourcePool(client, dvs, []types.DvsVmVnicResourcePoolConfigSpec{spec}); err != nil {
+		return fmt.Errorf("error creating network resource pool: %s", err)
+	}
+
+	// The key of the new pool is only known after it has been added, so look
+	// for the pool with our name that was not there before.
+	pools, err = resourceVSphereDVSNetworkResourcePoolList(dvs)
+	if err != nil {
+		return err
+	}
+	for _, pool := range pools {
+		if !existing[pool.Key] && pool.Name == spec.Name {
+			d.SetId(resourceVSphereDVSNetworkResourcePoolFlattenID(dvsID, pool.Key))
+			break
+		}
+	}
+	if d.Id() == "" {
+		return fmt.Errorf("could not find network resource pool %q after creation", spec.Name)
+	}
+
+	log.Printf("[DEBUG] %s: Create finished successfully", resourceVSphereDVSNetworkResourcePoolIDString(d))
+	return resourceVSphereDVSNetworkResourcePoolRead(d, meta)
+}
+
+func resourceVSphereDVSNetworkResourcePoolRead(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] %s: Beginning read", resourceVSphereDVSNetworkResourcePoolIDString(d))
+	client := meta.(*VSphereClient).vimClient
+	dvsID, key, err := resourceVSphereDVSNetworkResourcePoolParseID(d.Id())
+	if err != nil {
+		return err
+	}
+	dvs, err := dvsFromUUID(client, dvsID)
+	if err != nil {
+		if viapi.IsAnyNotFoundError(err) {
+			log.Printf("[DEBUG] %s: DVS not found. Removing from state.", resourceVSphereDVSNetworkResourcePoolIDString(d))
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("could not find DVS %q: %s", dvsID, err)
+	}
+	pool, err := resourceVSphereDVSNetworkResourcePoolFind(dvs, key)
+	if err != nil {
+		return err
+	}
+	if pool == nil {
+		log.Printf("[DEBUG] %s: Pool not found. Removing from state.", resourceVSphereDVSNetworkResourcePoolIDString(d))
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("distributed_virtual_switch_uuid", dvsID)
+	if err := flattenDVSVmVnicNetworkResourcePool(d, pool); err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] %s: Read completed successfully", resourceVSphereDVSNetworkResourcePoolIDString(d))
+	return nil
+}
+
+func resourceVSphereDVSNetworkResourcePoolUpdate(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] %s: Beginning update", resourceVSphereDVSNetworkResourcePoolIDString(d))
+	client := meta.(*VSphereClient).vimClient
+	dvsID, key, err := resourceVSphereDVSNetworkResourcePoolParseID(d.Id())
+	if err != nil {
+		return err
+	}
+	dvs, err := dvsFromUUID(client, dvsID)
+	if err != nil {
+		return fmt.Errorf("could not find DVS %q: %s", dvsID, err)
+	}
+	pool, err := resourceVSphereDVSNetworkResourcePoolFind(dvs, key)
+	if err != nil {
+		return err
+	}
+	if pool == nil {
+		return fmt.Errorf("could not find network resource pool %q on DVS %q", key, dvsID)
+	}
+
+	spec := expandDvsVmVnicResourcePoolConfigSpec(d)
+	spec.Operation = string(types.ConfigSpecOperationEdit)
+	spec.Key = key
+	spec.ConfigVersion = pool.ConfigVersion
+	if err := reconfigureDVSVmVnicNetworkResourcePool(client, dvs, []types.DvsVmVnicResourcePoolConfigSpec{spec}); err != nil {
+		return fmt.Errorf("error updating network resource pool: %s", err)
+	}
+
+	log.Printf("[DEBUG] %s: Update finished successfully", resourceVSphereDVSNetworkResourcePoolIDString(d))
+	return resourceVSphereDVSNetworkResourcePoolRead(d, meta)
+}
+
+func resourceVSphereDVSNetworkResourcePoolDelete(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] %s: Beginning delete", resourceVSphereDVSNetworkResourcePoolIDString(d))
+	client := meta.(*VSphereClient).vimClient
+	dvsID, key, err := resourceVSphereDVSNetworkResourcePoolParseID(d.Id())
+	if err != nil {
+		return err
+	}
+	dvs, err := dvsFromUUID(client, dvsID)
+	if err != nil {
+		return fmt.Errorf("could not find DVS %q: %s", dvsID, err)
+	}
+	pool, err := resourceVSphereDVSNetworkResourcePoolFind(dvs, key)
+	if err != nil {
+		return err
+	}
+	if pool == nil {
+		return nil
+	}
+
+	spec := types.DvsVmVnicResourcePoolConfigSpec{
+		Operation:     string(types.ConfigSpecOperationRemove),
+		Key:           key,
+		ConfigVersion: pool.ConfigVersion,
+	}
+	if err := reconfigureDVSVmVnicNetworkResourcePool(client, dvs, []types.DvsVmVnicResourcePoolConfigSpec{spec}); err != nil {
+		return fmt.Errorf("error deleting network resource pool: %s", err)
+	}
+
+	log.Printf("[DEBUG] %s: Deleted successfully", resourceVSphereDVSNetworkResourcePoolIDString(d))
+	return nil
+}
+
+func resourceVSphereDVSNetworkResourcePoolImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client := meta.(*VSphereClient).vimClient
+	if err := viapi.ValidateVirtualCenter(client); err != nil {
+		return nil, err
+	}
+	dvsID, name, err := resourceVSphereDVSNetworkResourcePoolParseID(d.Id())
+	if err != nil {
+		return nil, err
+	}
+	dvs, err := dvsFromUUID(client, dvsID)
+	if err != nil {
+		return nil, fmt.Errorf("could not find DVS %q: %s", dvsID, err)
+	}
+	pools, err := resourceVSphereDVSNetworkResourcePoolList(dvs)
+	if err != nil {
+		return nil, err
+	}
+	// Pools can be imported by either their key or their name.
+	for _, pool := range pools {
+		if pool.Key == name || pool.Name == name {
+			d.SetId(resourceVSphereDVSNetworkResourcePoolFlattenID(dvsID, pool.Key))
+			return []*schema.ResourceData{d}, nil
+		}
+	}
+	return nil, fmt.Errorf("could not find network resource pool %q on DVS %q", name, dvsID)
+}
+
+// expandDvsVmVnicResourcePoolConfigSpec reads certain ResourceData keys and
+// returns a DvsVmVnicResourcePoolConfigSpec. The operation, key, and config
+// version are left for the caller to set.
+func expandDvsVmVnicResourcePoolConfigSpec(d *schema.ResourceData) types.DvsVmVnicResourcePoolConfigSpec {
+	return types.DvsVmVnicResourcePoolConfigSpec{
+		Name:        d.Get("name").(string),
+		Description: d.Get("description").(string),
+		AllocationInfo: &types.DvsVmVnicResourceAllocation{
+			ReservationQuota: int64(d.Get("reservation_mbit").(int)),
+		},
+	}
+}
+
+// flattenDVSVmVnicNetworkResourcePool reads various fields from a
+// DVSVmVnicNetworkResourcePool into the passed in ResourceData.
+func flattenDVSVmVnicNetworkResourcePool(d *schema.ResourceData, obj *types.DVSVmVnicNetworkResourcePool) error {
+	var reservation int64
+	if obj.AllocationInfo != nil {
+		reservation = obj.AllocationInfo.ReservationQuota
+	}
+	return structure.SetBatch(d, map[string]interface{}{
+		"key":              obj.Key,
+		"name":             obj.Name,
+		"description":      obj.Description,
+		"reservation_mbit": reservation,
+	})
+}
+
+// resourceVSphereDVSNetworkResourcePoolList returns the virtual machine
+// network resource pools on a DVS.
+func resourceVSphereDVSNetworkResourcePoolList(dvs *object.VmwareDistributedVirtualSwitch) ([]types.DVSVmVnicNetworkResourcePool, error) {
+	props, err := dvsProperties(dvs)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching DVS properties: %s", err)
+	}
+	return props.Config.(*types.VMwareDVSConfigInfo).VmVnicNetworkResourcePool, nil
+}
+
+// resourceVSphereDVSNetworkResourcePoolFind locates a network resource pool
+// on a DVS by its key. nil is returned if the pool cannot be found.
+func resourceVSphereDVSNetworkResourcePoolFind(dvs *object.VmwareDistributedVirtualSwitch, key string) (*types.DVSVmVnicNetworkResourcePool, error) {
+	pools, err := resourceVSphereDVSNetworkResourcePoolList(dvs)
+	if err != nil {
+		return nil, err
+	}
+	for _, pool := range pools {
+		if pool.Key == key {
+			return &pool, nil
+		}
+	}
+	return nil, nil
+}
+
+// resourceVSphereDVSNetworkResourcePoolFlattenID makes an ID for the
+// vsphere_dvs_network_resource_pool resource.
+func resourceVSphereDVSNetworkResourcePoolFlattenID(dvsID, key string) string {
+	return strings.Join([]string{dvsID, key}, ":")
+}
+
+// resourceVSphereDVSNetworkResourcePoolParseID parses an ID for the
+// vsphere_dvs_network_resource_pool resource and outputs its parts.
+func resourceVSphereDVSNetworkResourcePoolParseID(id string) (string, string, error) {
+	parts := strings.SplitN(id, ":", 2)
+	if len(parts) < 2 {
+		return "", "", fmt.Errorf("bad ID %q", id)
+	}
+	return parts[0], parts[1], nil
+}
+
+// resourceVSphereDVSNetworkResourcePoolIDString prints a friendly string for
+// the vsphere_dvs_network_resource_pool resource.
+func resourceVSphereDVSNetworkResourcePoolIDString(d structure.ResourceIDStringer) string {
+	return structure.ResourceIDString(d, resourceVSphereDVSNetworkResourcePoolName)
+}
@@ -0,0 +1,201 @@
+package vsphere
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+func TestAccResourceVSphereDVSNetworkResourcePool_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccResourceVSphereDVSNetworkResourcePoolPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccResourceVSphereDVSNetworkResourcePoolExists(false),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceVSphereDVSNetworkResourcePoolConfig("terraform-test-pool", 100),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereDVSNetworkResourcePoolExists(true),
+					testAccResourceVSphereDVSNetworkResourcePoolMatch("terraform-test-pool", 100),
+					resource.TestCheckResourceAttrPair(
+						"vsphere_distributed_port_group.pg", "network_resource_pool_key",
+						"vsphere_dvs_network_resource_pool.pool", "key",
+					),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceVSphereDVSNetworkResourcePool_update(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccResourceVSphereDVSNetworkResourcePoolPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccResourceVSphereDVSNetworkResourcePoolExists(false),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceVSphereDVSNetworkResourcePoolConfig("terraform-test-pool", 100),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereDVSNetworkResourcePoolExists(true),
+					testAccResourceVSphereDVSNetworkResourcePoolMatch("terraform-test-pool", 100),
+				),
+			},
+			{
+				Config: testAccResourceVSphereDVSNetworkResourcePoolConfig("terraform-test-pool-renamed", 200),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereDVSNetworkResourcePoolExists(true),
+					testAccResourceVSphereDVSNetworkResourcePoolMatch("terraform-test-pool-renamed", 200),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceVSphereDVSNetworkResourcePool_import(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccResourceVSphereDVSNetworkResourcePoolPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccResourceVSphereDVSNetworkResourcePoolExists(false),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceVSphereDVSNetworkResourcePoolConfig("terraform-test-pool", 100),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereDVSNetworkResourcePoolExists(true),
+				),
+			},
+			{
+				ResourceName:      "vsphere_dvs_network_resource_pool.pool",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName: "vsphere_dvs_network_resource_pool.pool",
+				ImportState:  true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					rs, ok := s.RootModule().Resources["vsphere_distributed_virtual_switch.dvs"]
+					if !ok {
+						return "", errors.New("no DVS in state")
+					}
+					return fmt.Sprintf("%s:terraform-test-pool", rs.Primary.ID), nil
+				},
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccResourceVSphereDVSNetworkResourcePoolPreCheck(t *testing.T) {
+	if os.Getenv("VSPHERE_DATACENTER") == "" {
+		t.Skip("set VSPHERE_DATACENTER to run vsphere_dvs_network_resource_pool acceptance tests")
+	}
+}
+
+func testAccResourceVSphereDVSNetworkResourcePoolExists(expected bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		pool, err := testGetDVSNetworkResourcePool(s, "pool")
+		if err != nil {
+			if !expected && err.Error() == "no network resource pool in state" {
+				return nil
+			}
+			return err
+		}
+		switch {
+		case pool == nil && expected:
+			return errors.New("network resource pool not found")
+		case pool != nil && !expected:
+			return errors.New("network resource pool still present")
+		}
+		return nil
+	}
+}
+
+func testAccResourceVSphereDVSNetworkResourcePoolMatch(name string, reservation int64) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		pool, err := testGetDVSNetworkResourcePool(s, "pool")
+		if err != nil {
+			return err
+		}
+		if pool == nil {
+			return errors.New("network resource pool not found")
+		}
+		if pool.Name != name {
+			return fmt.Errorf("expected name to be %q, got %q", name, pool.Name)
+		}
+		if pool.AllocationInfo == nil || pool.AllocationInfo.ReservationQuota != reservation {
+			return fmt.Errorf("expected reservation to be %d, got %#v", reservation, pool.AllocationInfo)
+		}
+		return nil
+	}
+}
+
+// testGetDVSNetworkResourcePool is a convenience method to fetch a network
+// resource pool by resource name. nil is returned if the pool no longer
+// exists.
+func testGetDVSNetworkResourcePool(s *terraform.State, resourceName string) (*types.DVSVmVnicNetworkResourcePool, error) {
+	rs, ok := s.RootModule().Resources[fmt.Sprintf("vsphere_dvs_network_resource_pool.%s", resourceName)]
+	if !ok {
+		return nil, errors.New("no network resource pool in state")
+	}
+	dvsID, key, err := resourceVSphereDVSNetworkResourcePoolParseID(rs.Primary.ID)
+	if err != nil {
+		return nil, err
+	}
+	client := testAccProvider.Meta().(*VSphereClient).vimClient
+	dvs, err := dvsFromUUID(client, dvsID)
+	if err != nil {
+		return nil, err
+	}
+	return resourceVSphereDVSNetworkResourcePoolFind(dvs, key)
+}
+
+func testAccResourceVSphereDVSNetworkResourcePoolConfig(name string, reservation int) string {
+	return fmt.Sprintf(`
+variable "datacenter" {
+  default = "%s"
+}
+
+data "vsphere_datacenter" "dc" {
+  name = "${var.datacenter}"
+}
+
+resource "vsphere_distributed_virtual_switch" "dvs" {
+  name          = "terraform-test-dvs"
+  datacenter_id = "${data.vsphere_datacenter.dc.id}"
+
+  network_resource_control_enabled = true
+  network_resource_control_version = "version3"
+
+  virtualmachine_reservation_mbit = 1000
+}
+
+resource "vsphere_dvs_network_resource_pool" "pool" {
+  distributed_virtual_switch_uuid = "${vsphere_distributed_virtual_switch.dvs.id}"
+  name                            = "%s"
+  reservation_mbit                = %d
+}
+
+resource "vsphere_distributed_port_group" "pg" {
+  name                            = "terraform-test-pg"
+  distributed_virtual_switch_uuid = "${vsphere_distributed_virtual_switch.dvs.id}"
+  network_resource_pool_key       = "${vsphere_dvs_network_resource_pool.pool.key}"
+}
+`,
+		os.Getenv("VSPHERE_DATACENTER"),
+		name,
+		reservation,
+	)
+}
@@ -0,0 +1,477 @@
+package vsphere
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/structure"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/viapi"
+	"github.com/vmware/govmomi"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+const resourceVSphereDVSPortMirroringSessionName = "vsphere_dvs_port_mirroring_session"
+
+var vspanSessionTypeAllowedValues = []string{
+	string(types.VMwareDVSVspanSessionTypeDvPortMirror),
+	string(types.VMwareDVSVspanSessionTypeRemoteMirrorSource),
+	string(types.VMwareDVSVspanSessionTypeRemoteMirrorDest),
+	string(types.VMwareDVSVspanSessionTypeEncapsulatedRemoteMirrorSource),
+	string(types.VMwareDVSVspanSessionTypeMixedDestMirror),
+}
+
+var vspanSessionEncapTypeAllowedValues = []string{
+	string(types.VMwareDVSVspanSessionEncapTypeGre),
+	string(types.VMwareDVSVspanSessionEncapTypeErspan2),
+	string(types.VMwareDVSVspanSessionEncapTypeErspan3),
+}
+
+func resourceVSphereDVSPortMirroringSession() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceVSphereDVSPortMirroringSessionCreate,
+		Read:   resourceVSphereDVSPortMirroringSessionRead,
+		Update: resourceVSphereDVSPortMirroringSessionUpdate,
+		Delete: resourceVSphereDVSPortMirroringSessionDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceVSphereDVSPortMirroringSessionImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"distributed_virtual_switch_uuid": {
+				Type:        schema.TypeString,
+				Description: "The UUID of the DVS to create the port mirroring session on.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Description: "The name of the port mirroring session.",
+				Required:    true,
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Description: "The description of the port mirroring session.",
+				Optional:    true,
+			},
+			"enabled": {
+				Type:        schema.TypeBool,
+				Description: "Whether or not the port mirroring session is enabled.",
+				Optional:    true,
+				Default:     true,
+			},
+			"session_type": {
+				Type:         schema.TypeString,
+				Description:  "The type of the port mirroring session. Can be one of dvPortMirror, remoteMirrorSource, remoteMirrorDest, encapsulatedRemoteMirrorSource, or mixedDestMirror.",
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice(vspanSessionTypeAllowedValues, false),
+			},
+			"source_transmitted": {
+				Type:        schema.TypeList,
+				Description: "The source of traffic transmitted by the mirrored ports.",
+				Optional:    true,
+				MaxItems:    1,
+				Elem:        schemaVMwareVspanPort(),
+			},
+			"source_received": {
+				Type:        schema.TypeList,
+				Description: "The source of traffic received by the mirrored ports.",
+				Optional:    true,
+				MaxItems:    1,
+				Elem:        schemaVMwareVspanPort(),
+			},
+			"destination": {
+				Type:        schema.TypeList,
+				Description: "The destination of the mirrored traffic.",
+				Optional:    true,
+				MaxItems:    1,
+				Elem:        schemaVMwareVspanPort(),
+			},
+			"mirrored_packet_length": {
+				Type:         schema.TypeInt,
+				Description:  "The maximum length, in bytes, of mirrored packets. Packets are truncated to this length. Set to 0 to mirror full packets.",
+				Optional:     true,
+				ValidateFunc: validation.IntBetween(0, 9000),
+			},
+			"sampling_rate": {
+				Type:         schema.TypeInt,
+				Description:  "The rate at which packets are sampled. A value of n mirrors one of every n packets.",
+				Optional:     true,
+				Default:      1,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"normal_traffic_allowed": {
+				Type:        schema.TypeBool,
+				Description: "Whether or not the destination ports can send and receive normal traffic.",
+				Optional:    true,
+			},
+			"encapsulation_vlan_id": {
+				Type:         schema.TypeInt,
+				Description:  "The VLAN ID used to encapsulate mirrored traffic. Used with remoteMirrorSource sessions.",
+				Optional:     true,
+				ValidateFunc: validation.IntBetween(0, 4094),
+			},
+			"strip_original_vlan": {
+				Type:        schema.TypeBool,
+				Description: "Whether or not to strip the original VLAN tag from mirrored traffic.",
+				Optional:    true,
+			},
+			"encapsulation_type": {
+				Type:         schema.TypeString,
+				Description:  "The encapsulation type of encapsulatedRemoteMirrorSource sessions. Can be one of gre, erspan2, or erspan3.",
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice(vspanSessionEncapTypeAllowedValues, false),
+			},
+			"erspan_id": {
+				Type:         schema.TypeInt,
+				Description:  "The ERSPAN session ID, for erspan2 and erspan3 encapsulation.",
+				Optional:     true,
+				ValidateFunc: validation.IntBetween(0, 1023),
+			},
+			"erspan_cos": {
+				Type:         schema.TypeInt,
+				Description:  "The class of service of the ERSPAN encapsulated traffic, for erspan2 and erspan3 encapsulation.",
+				Optional:     true,
+				ValidateFunc: validation.IntBetween(0, 7),
+			},
+			"key": {
+				Type:        schema.TypeString,
+				Description: "The key of the port mirroring session.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// schemaVMwareVspanPort returns the schema for a source or destination of a
+// port mirroring session.
+func schemaVMwareVspanPort() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"port_keys": {
+				Type:        schema.TypeSet,
+				Description: "The keys of the distributed ports.",
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"uplinks": {
+				Type:        schema.TypeSet,
+				Description: "The names of the uplinks.",
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"vlans": {
+				Type:        schema.TypeSet,
+				Description: "The VLAN IDs. Used as the source of remoteMirrorDest sessions.",
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeInt,
+					ValidateFunc: validation.IntBetween(1, 4094),
+				},
+			},
+			"ip_addresses": {
+				Type:        schema.TypeSet,
+				Description: "The IP addresses of the GRE or ERSPAN tunnel endpoints. Used as the destination of encapsulatedRemoteMirrorSource sessions.",
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func resourceVSphereDVSPortMirroringSessionCreate(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] %s: Beginning create", resourceVSphereDVSPortMirroringSessionIDString(d))
+	client := meta.(*VSphereClient).vimClient
+	if err := viapi.ValidateVirtualCenter(client); err != nil {
+		return err
+	}
+	dvsID := d.Get("distributed_virtual_switch_uuid").(string)
+	dvs, err := dvsFromUUID(client, dvsID)
+	if err != nil {
+		return fmt.Errorf("could not find DVS %q: %s", dvsID, err)
+	}
+	props, err := dvsProperties(dvs)
+	if err != nil {
+		return fmt.Errorf("error fetching DVS properties: %s", err)
+	}
+	existing := make(map[string]bool)
+	for _, session := range props.Config.(*types.VMwareDVSConfigInfo).VspanSession {
+		existing[session.Key] = true
+	}
+
+	session := expandVMwareVspanSession(d)
+	if err := updateDVSVspanConfig(client, dvs, session, types.ConfigSpecOperationAdd); err != nil {
+		return fmt.Errorf("error creating port mirroring session: %s", err)
+	}
+
+	// The key of the new session is only known after it has been added, so
+	// look for the session with our name that was not there before.
+	props, err = dvsProperties(dvs)
+	if err != nil {
+		return fmt.Errorf("error fetching DVS properties after creation: %s", err)
+	}
+	for _, s := range props.Config.(*types.VMwareDVSConfigInfo).VspanSession {
+		if !existing[s.Key] && s.Name == session.Name {
+			d.SetId(resourceVSphereDVSPortMirroringSessionFlattenID(dvsID, s.Key))
+			break
+		}
+	}
+	if d.Id() == "" {
+		return fmt.Errorf("could not find port mirroring session %q after creation", session.Name)
+	}
+
+	log.Printf("[DEBUG] %s: Create finished successfully", resourceVSphereDVSPortMirroringSessionIDString(d))
+	return resourceVSphereDVSPortMirroringSessionRead(d, meta)
+}
+
+func resourceVSphereDVSPortMirroringSessionRead(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] %s: Beginning read", resourceVSphereDVSPortMirroringSessionIDString(d))
+	client := meta.(*VSphereClient).vimClient
+	dvsID, key, err := resourceVSphereDVSPortMirroringSessionParseID(d.Id())
+	if err != nil {
+		return err
+	}
+	dvs, err := dvsFromUUID(client, dvsID)
+	if err != nil {
+		if viapi.IsAnyNotFoundError(err) {
+			log.Printf("[DEBUG] %s: DVS not found. Removing from state.", resourceVSphereDVSPortMirroringSessionIDString(d))
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("could not find DVS %q: %s", dvsID, err)
+	}
+	session, err := resourceVSphereDVSPortMirroringSessionFind(dvs, key)
+	if err != nil {
+		return err
+	}
+	if session == nil {
+		log.Printf("[DEBUG] %s: Session not found. Removing from state.", resourceVSphereDVSPortMirroringSessionIDString(d))
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("distributed_virtual_switch_uuid", dvsID)
+	if err := flattenVMwareVspanSession(d, session); err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] %s: Read completed successfully", resourceVSphereDVSPortMirroringSessionIDString(d))
+	return nil
+}
+
+func resourceVSphereDVSPortMirroringSessionUpdate(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] %s: Beginning update", resourceVSphereDVSPortMirroringSessionIDString(d))
+	client := meta.(*VSphereClient).vimClient
+	dvsID, key, err := resourceVSphereDVSPortMirroringSessionParseID(d.Id())
+	if err != nil {
+		return err
+	}
+	dvs, err := dvsFromUUID(client, dvsID)
+	if err != nil {
+		return fmt.Errorf("could not find DVS %q: %s", dvsID, err)
+	}
+
+	session := expandVMwareVspanSession(d)
+	session.Key = key
+	if err := updateDVSVspanConfig(client, dvs, session, types.ConfigSpecOperationEdit); err != nil {
+		return fmt.Errorf("error updating port mirroring session: %s", err)
+	}
+
+	log.Printf("[DEBUG] %s: Update finished successfully", resourceVSphereDVSPortMirroringSessionIDString(d))
+	return resourceVSphereDVSPortMirroringSessionRead(d, meta)
+}
+
+func resourceVSphereDVSPortMirroringSessionDelete(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] %s: Beginning delete", resourceVSphereDVSPortMirroringSessionIDString(d))
+	client := meta.(*VSphereClient).vimClient
+	dvsID, key, err := resourceVSphereDVSPortMirroringSessionParseID(d.Id())
+	if err != nil {
+		return err
+	}
+	dvs, err := dvsFromUUID(client, dvsID)
+	if err != nil {
+		return fmt.Errorf("could not find DVS %q: %s", dvsID, err)
+	}
+
+	session := &types.VMwareVspanSession{Key: key}
+	if err := updateDVSVspanConfig(client, dvs, session, types.ConfigSpecOperationRemove); err != nil {
+		return fmt.Errorf("error deleting port mirroring session: %s", err)
+	}
+
+	log.Printf("[DEBUG] %s: Deleted successfully", resourceVSphereDVSPortMirroringSessionIDString(d))
+	return nil
+}
+
+func resourceVSphereDVSPortMirroringSessionImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client := meta.(*VSphereClient).vimClient
+	if err := viapi.ValidateVirtualCenter(client); err != nil {
+		return nil, err
+	}
+	dvsID, name, err := resourceVSphereDVSPortMirroringSessionParseID(d.Id())
+	if err != nil {
+		return nil, err
+	}
+	dvs, err := dvsFromUUID(client, dvsID)
+	if err != nil {
+		return nil, fmt.Errorf("could not find DVS %q: %s", dvsID, err)
+	}
+	props, err := dvsProperties(dvs)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching DVS properties: %s", err)
+	}
+	// Sessions can be imported by either their key or their name.
+	for _, session := range props.Config.(*types.VMwareDVSConfigInfo).VspanSession {
+		if session.Key == name || session.Name == name {
+			d.SetId(resourceVSphereDVSPortMirroringSessionFlattenID(dvsID, session.Key))
+			return []*schema.ResourceData{d}, nil
+		}
+	}
+	return nil, fmt.Errorf("could not find port mirroring session %q on DVS %q", name, dvsID)
+}
+
+// expandVMwareVspanSession reads certain ResourceData keys and returns a
+// VMwareVspanSession.
+func expandVMwareVspanSession(d *schema.ResourceData) *types.VMwareVspanSession {
+	obj := &types.VMwareVspanSession{
+		Name:                  d.Get("name").(string),
+		Description:           d.Get("description").(string),
+		Enabled:               d.Get("enabled").(bool),
+		SessionType:           d.Get("session_type").(string),
+		SourcePortTransmitted: expandVMwareVspanPort(d.Get("source_transmitted").([]interface{})),
+		SourcePortReceived:    expandVMwareVspanPort(d.Get("source_received").([]interface{})),
+		DestinationPort:       expandVMwareVspanPort(d.Get("destination").([]interface{})),
+		MirroredPacketLength:  int32(d.Get("mirrored_packet_length").(int)),
+		SamplingRate:          int32(d.Get("sampling_rate").(int)),
+		NormalTrafficAllowed:  d.Get("normal_traffic_allowed").(bool),
+		EncapsulationVlanId:   int32(d.Get("encapsulation_vlan_id").(int)),
+		StripOriginalVlan:     d.Get("strip_original_vlan").(bool),
+		EncapType:             d.Get("encapsulation_type").(string),
+		ErspanId:              int32(d.Get("erspan_id").(int)),
+		ErspanCOS:             int32(d.Get("erspan_cos").(int)),
+	}
+	return obj
+}
+
+// flattenVMwareVspanSession reads various fields from a VMwareVspanSession
+// into the passed in ResourceData.
+func flattenVMwareVspanSession(d *schema.ResourceData, obj *types.VMwareVspanSession) error {
+	return structure.SetBatch(d, map[string]interface{}{
+		"key":                    obj.Key,
+		"name":                   obj.Name,
+		"description":            obj.Description,
+		"enabled":                obj.Enabled,
+		"session_type":           obj.SessionType,
+		"source_transmitted":     flattenVMwareVspanPort(obj.SourcePortTransmitted),
+		"source_received":        flattenVMwareVspanPort(obj.SourcePortReceived),
+		"destination":            flattenVMwareVspanPort(obj.DestinationPort),
+		"mirrored_packet_length": obj.MirroredPacketLength,
+		"sampling_rate":          obj.SamplingRate,
+		"normal_traffic_allowed": obj.NormalTrafficAllowed,
+		"encapsulation_vlan_id":  obj.EncapsulationVlanId,
+		"strip_original_vlan":    obj.StripOriginalVlan,
+		"encapsulation_type":     obj.EncapType,
+		"erspan_id":              obj.ErspanId,
+		"erspan_cos":             obj.ErspanCOS,
+	})
+}
+
+// expandVMwareVspanPort reads a source or destination block and returns a
+// VMwareVspanPort. nil is returned if the block is not defined.
+func expandVMwareVspanPort(l []interface{}) *types.VMwareVspanPort {
+	if len(l) < 1 || l[0] == nil {
+		return nil
+	}
+	m := l[0].(map[string]interface{})
+	obj := &types.VMwareVspanPort{
+		PortKey:        structure.SliceInterfacesToStrings(m["port_keys"].(*schema.Set).List()),
+		UplinkPortName: structure.SliceInterfacesToStrings(m["uplinks"].(*schema.Set).List()),
+		IpAddress:      structure.SliceInterfacesToStrings(m["ip_addresses"].(*schema.Set).List()),
+	}
+	for _, v := range m["vlans"].(*schema.Set).List() {
+		obj.Vlans = append(obj.Vlans, int32(v.(int)))
+	}
+	return obj
+}
+
+// flattenVMwareVspanPort reads a VMwareVspanPort into a list suitable for
+// a source or destination block.
+func flattenVMwareVspanPort(obj *types.VMwareVspanPort) []interface{} {
+	if obj == nil || structure.AllFieldsEmpty(obj) {
+		return nil
+	}
+	var vlans []interface{}
+	for _, v := range obj.Vlans {
+		vlans = append(vlans, int(v))
+	}
+	return []interface{}{
+		map[string]interface{}{
+			"port_keys":    obj.PortKey,
+			"uplinks":      obj.UplinkPortName,
+			"vlans":        vlans,
+			"ip_addresses": obj.IpAddress,
+		},
+	}
+}
+
+// updateDVSVspanConfig adds, edits, or removes a port mirroring session on a
+// DVS.
+func updateDVSVspanConfig(client *govmomi.Client, dvs *object.VmwareDistributedVirtualSwitch, session *types.VMwareVspanSession, op types.ConfigSpecOperation) error {
+	props, err := dvsProperties(dvs)
+	if err != nil {
+		return fmt.Errorf("error fetching DVS properties: %s", err)
+	}
+	spec := &types.VMwareDVSConfigSpec{
+		DVSConfigSpec: types.DVSConfigSpec{
+			ConfigVersion: props.Config.(*types.VMwareDVSConfigInfo).ConfigVersion,
+		},
+		VspanConfigSpec: []types.VMwareDVSVspanConfigSpec{
+			{
+				VspanSession: *session,
+				Operation:    string(op),
+			},
+		},
+	}
+	return updateDVSConfiguration(client, dvs, spec)
+}
+
+// resourceVSphereDVSPortMirroringSessionFind locates a port mirroring session
+// on a DVS by its key. nil is returned if the session cannot be found.
+func resourceVSphereDVSPortMirroringSessionFind(dvs *object.VmwareDistributedVirtualSwitch, key string) (*types.VMwareVspanSession, error) {
+	props, err := dvsProperties(dvs)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching DVS properties: %s", err)
+	}
+	for _, session := range props.Config.(*types.VMwareDVSConfigInfo).VspanSession {
+		if session.Key == key {
+			return &session, nil
+		}
+	}
+	return nil, nil
+}
+
+// resourceVSphereDVSPortMirroringSessionFlattenID makes an ID for the
+// vsphere_dvs_port_mirroring_session resource.
+func resourceVSphereDVSPortMirroringSessionFlattenID(dvsID, key string) string {
+	return strings.Join([]string{dvsID, key}, ":")
+}
+
+// resourceVSphereDVSPortMirroringSessionParseID parses an ID for the
+// vsphere_dvs_port_mirroring_session resource and outputs its parts.
+func resourceVSphereDVSPortMirroringSessionParseID(id string) (string, string, error) {
+	parts := strings.SplitN(id, ":", 2)
+	if len(parts) < 2 {
+		return "", "", fmt.Errorf("bad ID %q", id)
+	}
+	return parts[0], parts[1], nil
+}
+
+// resourceVSphereDVSPortMirroringSessionIDString prints a friendly string for
+// the vsphere_dvs_port_mirroring_session resource.
+func resourceVSphereDVSPortMirroringSessionIDString(d structure.ResourceIDStringer) string {
+	return structure.ResourceIDString(d, resourceVSphereDVSPortMirroringSessionName)
+}
@@ -0,0 +1,196 @@
+package vsphere
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+func TestAccResourceVSphereDVSPortMirroringSession_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccResourceVSphereDVSPortMirroringSessionPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccResourceVSphereDVSPortMirroringSessionExists(false),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceVSphereDVSPortMirroringSessionConfig("terraform-test-session", 1),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereDVSPortMirroringSessionExists(true),
+					testAccResourceVSphereDVSPortMirroringSessionMatch("terraform-test-session", 1),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceVSphereDVSPortMirroringSession_update(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccResourceVSphereDVSPortMirroringSessionPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccResourceVSphereDVSPortMirroringSessionExists(false),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceVSphereDVSPortMirroringSessionConfig("terraform-test-session", 1),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereDVSPortMirroringSessionExists(true),
+					testAccResourceVSphereDVSPortMirroringSessionMatch("terraform-test-session", 1),
+				),
+			},
+			{
+				Config: testAccResourceVSphereDVSPortMirroringSessionConfig("terraform-test-session-renamed", 10),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereDVSPortMirroringSessionExists(true),
+					testAccResourceVSphereDVSPortMirroringSessionMatch("terraform-test-session-renamed", 10),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceVSphereDVSPortMirroringSession_import(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccResourceVSphereDVSPortMirroringSessionPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccResourceVSphereDVSPortMirroringSessionExists(false),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceVSphereDVSPortMirroringSessionConfig("terraform-test-session", 1),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereDVSPortMirroringSessionExists(true),
+				),
+			},
+			{
+				ResourceName:      "vsphere_dvs_port_mirroring_session.session",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName: "vsphere_dvs_port_mirroring_session.session",
+				ImportState:  true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					rs, ok := s.RootModule().Resources["vsphere_distributed_virtual_switch.dvs"]
+					if !ok {
+						return "", errors.New("no DVS in state")
+					}
+					return fmt.Sprintf("%s:terraform-test-session", rs.Primary.ID), nil
+				},
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccResourceVSphereDVSPortMirroringSessionPreCheck(t *testing.T) {
+	if os.Getenv("VSPHERE_DATACENTER") == "" {
+		t.Skip("set VSPHERE_DATACENTER to run vsphere_dvs_port_mirroring_session acceptance tests")
+	}
+}
+
+func testAccResourceVSphereDVSPortMirroringSessionExists(expected bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		session, err := testGetDVSPortMirroringSession(s, "session")
+		if err != nil {
+			if !expected && err.Error() == "no port mirroring session in state" {
+				return nil
+			}
+			return err
+		}
+		switch {
+		case session == nil && expected:
+			return errors.New("port mirroring session not found")
+		case session != nil && !expected:
+			return errors.New("port mirroring session still present")
+		}
+		return nil
+	}
+}
+
+func testAccResourceVSphereDVSPortMirroringSessionMatch(name string, rate int32) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		session, err := testGetDVSPortMirroringSession(s, "session")
+		if err != nil {
+			return err
+		}
+		if session == nil {
+			return errors.New("port mirroring session not found")
+		}
+		if session.Name != name {
+			return fmt.Errorf("expected name to be %q, got %q", name, session.Name)
+		}
+		if session.SamplingRate != rate {
+			return fmt.Errorf("expected sampling rate to be %d, got %d", rate, session.SamplingRate)
+		}
+		if session.SessionType != string(types.VMwareDVSVspanSessionTypeEncapsulatedRemoteMirrorSource) {
+			return fmt.Errorf("unexpected session type %q", session.SessionType)
+		}
+		return nil
+	}
+}
+
+// testGetDVSPortMirroringSession is a convenience method to fetch a port
+// mirroring session by resource name. nil is returned if the session no
+// longer exists.
+func testGetDVSPortMirroringSession(s *terraform.State, resourceName string) (*types.VMwareVspanSession, error) {
+	rs, ok := s.RootModule().Resources[fmt.Sprintf("vsphere_dvs_port_mirroring_session.%s", resourceName)]
+	if !ok {
+		return nil, errors.New("no port mirroring session in state")
+	}
+	dvsID, key, err := resourceVSphereDVSPortMirroringSessionParseID(rs.Primary.ID)
+	if err != nil {
+		return nil, err
+	}
+	client := testAccProvider.Meta().(*VSphereClient).vimClient
+	dvs, err := dvsFromUUID(client, dvsID)
+	if err != nil {
+		return nil, err
+	}
+	return resourceVSphereDVSPortMirroringSessionFind(dvs, key)
+}
+
+func testAccResourceVSphereDVSPortMirroringSessionConfig(name string, rate int) string {
+	return fmt.Sprintf(`
+variable "datacenter" {
+  default = "%s"
+}
+
+data "vsphere_datacenter" "dc" {
+  name = "${var.datacenter}"
+}
+
+resource "vsphere_distributed_virtual_switch" "dvs" {
+  name          = "terraform-test-dvs"
+  datacenter_id = "${data.vsphere_datacenter.dc.id}"
+}
+
+resource "vsphere_dvs_port_mirroring_session" "session" {
+  distributed_virtual_switch_uuid = "${vsphere_distributed_virtual_switch.dvs.id}"
+  name                            = "%s"
+  session_type                    = "encapsulatedRemoteMirrorSource"
+  encapsulation_type              = "gre"
+  sampling_rate                   = %d
+  mirrored_packet_length          = 128
+
+  destination {
+    ip_addresses = ["192.0.2.10"]
+  }
+}
+`,
+		os.Getenv("VSPHERE_DATACENTER"),
+		name,
+		rate,
+	)
+}
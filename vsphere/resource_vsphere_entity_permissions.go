@@ -0,0 +1,294 @@
+package vsphere
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/structure"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/viapi"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+const resourceVSphereEntityPermissionsName = "vsphere_entity_permissions"
+
+func resourceVSphereEntityPermissions() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceVSphereEntityPermissionsCreate,
+		Read:   resourceVSphereEntityPermissionsRead,
+		Update: resourceVSphereEntityPermissionsUpdate,
+		Delete: resourceVSphereEntityPermissionsDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceVSphereEntityPermissionsImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"entity_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The managed object ID of the entity to set permissions on.",
+			},
+			"entity_type": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The managed object type of the entity, such as Folder, Datacenter, ClusterComputeResource, or VirtualMachine.",
+			},
+			"permissions": {
+				Type:        schema.TypeSet,
+				Required:    true,
+				MinItems:    1,
+				Description: "The permissions to set on the entity.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"user_or_group": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The user or group to grant the role to, such as VSPHERE.LOCAL\\devops.",
+						},
+						"is_group": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+							Description: "Whether or not user_or_group is a group.",
+						},
+						"role_id": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The ID of the role to grant.",
+						},
+						"propagate": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     true,
+							Description: "Whether or not the permission propagates to child objects of the entity.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceVSphereEntityPermissionsCreate(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] %s: Beginning create", resourceVSphereEntityPermissionsIDString(d))
+	client := meta.(*VSphereClient).vimClient
+	entity := resourceVSphereEntityPermissionsEntity(d.Get("entity_type").(string), d.Get("entity_id").(string))
+	perms, err := expandEntityPermissions(d.Get("permissions").(*schema.Set))
+	if err != nil {
+		return err
+	}
+	if err := setEntityPermissions(client, entity, perms); err != nil {
+		return fmt.Errorf("error setting permissions on %s %q: %s", entity.Type, entity.Value, err)
+	}
+	d.SetId(resourceVSphereEntityPermissionsFlattenID(entity))
+	log.Printf("[DEBUG] %s: Create finished successfully", resourceVSphereEntityPermissionsIDString(d))
+	return resourceVSphereEntityPermissionsRead(d, meta)
+}
+
+func resourceVSphereEntityPermissionsRead(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] %s: Beginning read", resourceVSphereEntityPermissionsIDString(d))
+	client := meta.(*VSphereClient).vimClient
+	entity, err := resourceVSphereEntityPermissionsParseID(d.Id())
+	if err != nil {
+		return err
+	}
+	perms, err := entityPermissions(client, entity)
+	if err != nil {
+		if viapi.IsManagedObjectNotFoundError(err) {
+			log.Printf("[DEBUG] %s: Entity not found, marking resource as gone", resourceVSphereEntityPermissionsIDString(d))
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("error reading permissions on %s %q: %s", entity.Type, entity.Value, err)
+	}
+	// Only the principals in state are managed by this resource. The state is
+	// empty on import, in which case all permissions on the entity are read.
+	if managed := d.Get("permissions").(*schema.Set); managed.Len() > 0 {
+		managedPerms, err := expandEntityPermissions(managed)
+		if err != nil {
+			return err
+		}
+		perms = filterEntityPermissions(perms, managedPerms)
+	}
+	if len(perms) < 1 {
+		log.Printf("[DEBUG] %s: No managed permissions found, marking resource as gone", resourceVSphereEntityPermissionsIDString(d))
+		d.SetId("")
+		return nil
+	}
+
+	if err := structure.SetBatch(d, map[string]interface{}{
+		"entity_id":   entity.Value,
+		"entity_type": entity.Type,
+		"permissions": flattenEntityPermissions(perms),
+	}); err != nil {
+		return err
+	}
+	log.Printf("[DEBUG] %s: Read completed successfully", resourceVSphereEntityPermissionsIDString(d))
+	return nil
+}
+
+func resourceVSphereEntityPermissionsUpdate(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] %s: Beginning update", resourceVSphereEntityPermissionsIDString(d))
+	client := meta.(*VSphereClient).vimClient
+	entity, err := resourceVSphereEntityPermissionsParseID(d.Id())
+	if err != nil {
+		return err
+	}
+	o, n := d.GetChange("permissions")
+	newPerms, err := expandEntityPermissions(n.(*schema.Set))
+	if err != nil {
+		return err
+	}
+	oldPerms, err := expandEntityPermissions(o.(*schema.Set))
+	if err != nil {
+		return err
+	}
+
+	// Remove principals that are no longer configured before setting the new
+	// permissions. Changed permissions for the same principal are replaced in
+	// place by SetEntityPermissions.
+	keep := make(map[string]bool)
+	for _, p := range newPerms {
+		keep[entityPermissionPrincipalKey(p)] = true
+	}
+	for _, p := range oldPerms {
+		if keep[entityPermissionPrincipalKey(p)] {
+			continue
+		}
+		log.Printf("[DEBUG] %s: Removing permission for %q", resourceVSphereEntityPermissionsIDString(d), p.Principal)
+		if err := removeEntityPermission(client, entity, p.Principal, p.Group); err != nil {
+			return fmt.Errorf("error removing permission for %q: %s", p.Principal, err)
+		}
+	}
+	if err := setEntityPermissions(client, entity, newPerms); err != nil {
+		return fmt.Errorf("error setting permissions on %s %q: %s", entity.Type, entity.Value, err)
+	}
+	log.Printf("[DEBUG] %s: Update finished successfully", resourceVSphereEntityPermissionsIDString(d))
+	return resourceVSphereEntityPermissionsRead(d, meta)
+}
+
+func resourceVSphereEntityPermissionsDelete(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] %s: Beginning delete", resourceVSphereEntityPermissionsIDString(d))
+	client := meta.(*VSphereClient).vimClient
+	entity, err := resourceVSphereEntityPermissionsParseID(d.Id())
+	if err != nil {
+		return err
+	}
+	perms, err := expandEntityPermissions(d.Get("permissions").(*schema.Set))
+	if err != nil {
+		return err
+	}
+	for _, p := range perms {
+		if err := removeEntityPermission(client, entity, p.Principal, p.Group); err != nil {
+			return fmt.Errorf("error removing permission for %q: %s", p.Principal, err)
+		}
+	}
+	log.Printf("[DEBUG] %s: Delete finished successfully", resourceVSphereEntityPermissionsIDString(d))
+	return nil
+}
+
+func resourceVSphereEntityPermissionsImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	if _, err := resourceVSphereEntityPermissionsParseID(d.Id()); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// expandEntityPermissions reads the permissions set and returns a list of
+// Permission.
+func expandEntityPermissions(s *schema.Set) ([]types.Permission, error) {
+	var perms []types.Permission
+	seen := make(map[string]bool)
+	for _, raw := range s.List() {
+		m := raw.(map[string]interface{})
+		roleID, err := resourceVSphereRoleParseID(m["role_id"].(string))
+		if err != nil {
+			return nil, err
+		}
+		p := types.Permission{
+			Principal: m["user_or_group"].(string),
+			Group:     m["is_group"].(bool),
+			RoleId:    roleID,
+			Propagate: m["propagate"].(bool),
+		}
+		key := entityPermissionPrincipalKey(p)
+		if seen[key] {
+			return nil, fmt.Errorf("%q is listed more than once in permissions", p.Principal)
+		}
+		seen[key] = true
+		perms = append(perms, p)
+	}
+	return perms, nil
+}
+
+// flattenEntityPermissions converts a list of Permission into the structure
+// used by the permissions attribute.
+func flattenEntityPermissions(perms []types.Permission) []interface{} {
+	var result []interface{}
+	for _, p := range perms {
+		result = append(result, map[string]interface{}{
+			"user_or_group": p.Principal,
+			"is_group":      p.Group,
+			"role_id":       strconv.Itoa(int(p.RoleId)),
+			"propagate":     p.Propagate,
+		})
+	}
+	return result
+}
+
+// filterEntityPermissions returns the permissions in perms whose principal
+// is also in managed. Permissions for other principals are left out.
+func filterEntityPermissions(perms, managed []types.Permission) []types.Permission {
+	keys := make(map[string]bool)
+	for _, p := range managed {
+		keys[entityPermissionPrincipalKey(p)] = true
+	}
+	var result []types.Permission
+	for _, p := range perms {
+		if keys[entityPermissionPrincipalKey(p)] {
+			result = append(result, p)
+		}
+	}
+	return result
+}
+
+// entityPermissionPrincipalKey returns a key that uniquely identifies the
+// principal of a permission on an entity.
+func entityPermissionPrincipalKey(p types.Permission) string {
+	return fmt.Sprintf("%s:%t", p.Principal, p.Group)
+}
+
+// resourceVSphereEntityPermissionsEntity returns a managed object reference
+// for the supplied entity type and ID.
+func resourceVSphereEntityPermissionsEntity(entityType, entityID string) types.ManagedObjectReference {
+	return types.ManagedObjectReference{
+		Type:  entityType,
+		Value: entityID,
+	}
+}
+
+// resourceVSphereEntityPermissionsIDString prints a friendly string for the
+// vsphere_entity_permissions resource.
+func resourceVSphereEntityPermissionsIDString(d structure.ResourceIDStringer) string {
+	return structure.ResourceIDString(d, resourceVSphereEntityPermissionsName)
+}
+
+// resourceVSphereEntityPermissionsFlattenID makes an ID for the
+// vsphere_entity_permissions resource.
+func resourceVSphereEntityPermissionsFlattenID(entity types.ManagedObjectReference) string {
+	return strings.Join([]string{entity.Type, entity.Value}, ":")
+}
+
+// resourceVSphereEntityPermissionsParseID parses an ID for the
+// vsphere_entity_permissions and outputs the entity it refers to.
+func resourceVSphereEntityPermissionsParseID(id string) (types.ManagedObjectReference, error) {
+	parts := strings.SplitN(id, ":", 2)
+	if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+		return types.ManagedObjectReference{}, fmt.Errorf("bad ID %q", id)
+	}
+	return resourceVSphereEntityPermissionsEntity(parts[0], parts[1]), nil
+}
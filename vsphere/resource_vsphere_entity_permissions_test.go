@@ -0,0 +1,300 @@
+package vsphere
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/viapi"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+func TestAccResourceVSphereEntityPermissions_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccResourceVSphereEntityPermissionsPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccResourceVSphereEntityPermissionsExists(false),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceVSphereEntityPermissionsConfig(true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereEntityPermissionsExists(true),
+					resource.TestCheckResourceAttr("vsphere_entity_permissions.permissions", "permissions.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceVSphereEntityPermissions_update(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccResourceVSphereEntityPermissionsPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccResourceVSphereEntityPermissionsExists(false),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceVSphereEntityPermissionsConfig(true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereEntityPermissionsExists(true),
+				),
+			},
+			{
+				Config: testAccResourceVSphereEntityPermissionsConfig(false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereEntityPermissionsExists(true),
+					testAccResourceVSphereEntityPermissionsPropagate(false),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceVSphereEntityPermissions_import(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccResourceVSphereEntityPermissionsPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccResourceVSphereEntityPermissionsExists(false),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceVSphereEntityPermissionsConfig(true),
+			},
+			{
+				ResourceName:      "vsphere_entity_permissions.permissions",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccResourceVSphereEntityPermissions_existingPermission(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccResourceVSphereEntityPermissionsPreCheck(t)
+			if os.Getenv("VSPHERE_PERMISSION_PRINCIPAL2") == "" {
+				t.Skip("set VSPHERE_PERMISSION_PRINCIPAL2 to run vsphere_entity_permissions existing permission acceptance tests")
+			}
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccResourceVSphereEntityPermissionsExists(false),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceVSphereEntityPermissionsConfigExisting(false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereEntityPermissionsAddUnmanaged(),
+				),
+			},
+			{
+				Config: testAccResourceVSphereEntityPermissionsConfigExisting(true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereEntityPermissionsExists(true),
+					testAccResourceVSphereEntityPermissionsUnmanagedExists(),
+					resource.TestCheckResourceAttr("vsphere_entity_permissions.permissions", "permissions.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func testAccResourceVSphereEntityPermissionsPreCheck(t *testing.T) {
+	if os.Getenv("VSPHERE_DATACENTER") == "" {
+		t.Skip("set VSPHERE_DATACENTER to run vsphere_entity_permissions acceptance tests")
+	}
+	if os.Getenv("VSPHERE_PERMISSION_PRINCIPAL") == "" {
+		t.Skip("set VSPHERE_PERMISSION_PRINCIPAL to run vsphere_entity_permissions acceptance tests")
+	}
+}
+
+func testAccResourceVSphereEntityPermissionsExists(expected bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		perm, err := testGetEntityPermissionForTestPrincipal(s)
+		if err != nil {
+			if viapi.IsManagedObjectNotFoundError(err) && !expected {
+				return nil
+			}
+			return err
+		}
+		switch {
+		case perm == nil && expected:
+			return errors.New("permission not found")
+		case perm != nil && !expected:
+			return fmt.Errorf("permission for %q still exists", perm.Principal)
+		}
+		return nil
+	}
+}
+
+func testAccResourceVSphereEntityPermissionsPropagate(expected bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		perm, err := testGetEntityPermissionForTestPrincipal(s)
+		if err != nil {
+			return err
+		}
+		if perm == nil {
+			return errors.New("permission not found")
+		}
+		if perm.Propagate != expected {
+			return fmt.Errorf("expected propagate to be %t, got %t", expected, perm.Propagate)
+		}
+		return nil
+	}
+}
+
+// testGetEntityPermissionForTestPrincipal returns the permission for the test
+// principal on the entity managed by the vsphere_entity_permissions resource.
+func testGetEntityPermissionForTestPrincipal(s *terraform.State) (*types.Permission, error) {
+	vars, err := testClientVariablesForResource(s, "vsphere_entity_permissions.permissions")
+	if err != nil {
+		return nil, err
+	}
+	entity, err := resourceVSphereEntityPermissionsParseID(vars.resourceID)
+	if err != nil {
+		return nil, err
+	}
+	return entityPermissionForPrincipal(vars.client, entity, os.Getenv("VSPHERE_PERMISSION_PRINCIPAL"), false)
+}
+
+// testAccResourceVSphereEntityPermissionsAddUnmanaged grants the ReadOnly
+// role to the second test principal on the test folder, outside of the
+// vsphere_entity_permissions resource. A system role is used so that the
+// permission does not block the removal of the test role on destroy.
+func testAccResourceVSphereEntityPermissionsAddUnmanaged() resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		vars, err := testClientVariablesForResource(s, "vsphere_folder.folder")
+		if err != nil {
+			return err
+		}
+		perm := types.Permission{
+			Principal: os.Getenv("VSPHERE_PERMISSION_PRINCIPAL2"),
+			RoleId:    -2,
+			Propagate: true,
+		}
+		entity := resourceVSphereEntityPermissionsEntity("Folder", vars.resourceID)
+		return setEntityPermissions(vars.client, entity, []types.Permission{perm})
+	}
+}
+
+// testAccResourceVSphereEntityPermissionsUnmanagedExists checks that the
+// permission added by testAccResourceVSphereEntityPermissionsAddUnmanaged is
+// still on the entity.
+func testAccResourceVSphereEntityPermissionsUnmanagedExists() resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		vars, err := testClientVariablesForResource(s, "vsphere_entity_permissions.permissions")
+		if err != nil {
+			return err
+		}
+		entity, err := resourceVSphereEntityPermissionsParseID(vars.resourceID)
+		if err != nil {
+			return err
+		}
+		principal := os.Getenv("VSPHERE_PERMISSION_PRINCIPAL2")
+		perm, err := entityPermissionForPrincipal(vars.client, entity, principal, false)
+		if err != nil {
+			return err
+		}
+		if perm == nil {
+			return fmt.Errorf("unmanaged permission for %q was removed", principal)
+		}
+		return nil
+	}
+}
+
+func testAccResourceVSphereEntityPermissionsConfig(propagate bool) string {
+	return fmt.Sprintf(`
+variable "datacenter" {
+  default = "%s"
+}
+
+variable "principal" {
+  default = "%s"
+}
+
+data "vsphere_datacenter" "dc" {
+  name = "${var.datacenter}"
+}
+
+resource "vsphere_folder" "folder" {
+  path          = "terraform-test-permissions"
+  type          = "vm"
+  datacenter_id = "${data.vsphere_datacenter.dc.id}"
+}
+
+resource "vsphere_role" "role" {
+  name            = "terraform-test-permissions-role"
+  role_privileges = ["VirtualMachine.Interact.PowerOn"]
+}
+
+resource "vsphere_entity_permissions" "permissions" {
+  entity_id   = "${vsphere_folder.folder.id}"
+  entity_type = "Folder"
+
+  permissions {
+    user_or_group = "${var.principal}"
+    role_id       = "${vsphere_role.role.id}"
+    propagate     = %t
+  }
+}
+`,
+		os.Getenv("VSPHERE_DATACENTER"),
+		os.Getenv("VSPHERE_PERMISSION_PRINCIPAL"),
+		propagate,
+	)
+}
+
+func testAccResourceVSphereEntityPermissionsConfigExisting(managed bool) string {
+	var permissions string
+	if managed {
+		permissions = `
+resource "vsphere_entity_permissions" "permissions" {
+  entity_id   = "${vsphere_folder.folder.id}"
+  entity_type = "Folder"
+
+  permissions {
+    user_or_group = "${var.principal}"
+    role_id       = "${vsphere_role.role.id}"
+  }
+}
+`
+	}
+	return fmt.Sprintf(`
+variable "datacenter" {
+  default = "%s"
+}
+
+variable "principal" {
+  default = "%s"
+}
+
+data "vsphere_datacenter" "dc" {
+  name = "${var.datacenter}"
+}
+
+resource "vsphere_folder" "folder" {
+  path          = "terraform-test-permissions"
+  type          = "vm"
+  datacenter_id = "${data.vsphere_datacenter.dc.id}"
+}
+
+resource "vsphere_role" "role" {
+  name            = "terraform-test-permissions-role"
+  role_privileges = ["VirtualMachine.Interact.PowerOn"]
+}
+%s`,
+		os.Getenv("VSPHERE_DATACENTER"),
+		os.Getenv("VSPHERE_PERMISSION_PRINCIPAL"),
+		permissions,
+	)
+}
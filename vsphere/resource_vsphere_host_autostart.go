@@ -0,0 +1,346 @@
+package vsphere
+
+import (
+	"fmt"
+	"log"
+	"sort"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/structure"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/virtualmachine"
+	"github.com/vmware/govmomi"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+const resourceVSphereHostAutostartName = "vsphere_host_autostart"
+
+var hostAutostartDefaultStopActionAllowedValues = []string{
+	string(types.AutoStartActionNone),
+	string(types.AutoStartActionPowerOff),
+	string(types.AutoStartActionGuestShutdown),
+	string(types.AutoStartActionSuspend),
+}
+
+var hostAutostartVMStartActionAllowedValues = []string{
+	string(types.AutoStartActionNone),
+	string(types.AutoStartActionPowerOn),
+}
+
+var hostAutostartVMStopActionAllowedValues = []string{
+	string(types.AutoStartActionNone),
+	string(types.AutoStartActionSystemDefault),
+	string(types.AutoStartActionPowerOff),
+	string(types.AutoStartActionGuestShutdown),
+	string(types.AutoStartActionSuspend),
+}
+
+var hostAutostartVMWaitForHeartbeatAllowedValues = []string{
+	string(types.AutoStartWaitHeartbeatSettingYes),
+	string(types.AutoStartWaitHeartbeatSettingNo),
+	string(types.AutoStartWaitHeartbeatSettingSystemDefault),
+}
+
+func resourceVSphereHostAutostart() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceVSphereHostAutostartCreate,
+		Read:   resourceVSphereHostAutostartRead,
+		Update: resourceVSphereHostAutostartUpdate,
+		Delete: resourceVSphereHostAutostartDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceVSphereHostAutostartImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"host_system_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The managed object ID of the host to configure autostart for.",
+			},
+			"enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Enable automatic start and stop of virtual machines with the host.",
+			},
+			"start_delay": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      120,
+				Description:  "The default delay, in seconds, to wait after starting a virtual machine before starting the next one.",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"stop_delay": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      120,
+				Description:  "The default delay, in seconds, to wait after stopping a virtual machine before stopping the next one.",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"wait_for_heartbeat": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Start the next virtual machine as soon as VMware Tools reports a heartbeat from the current one, instead of waiting for the full start delay.",
+			},
+			"stop_action": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      string(types.AutoStartActionPowerOff),
+				Description:  "The default action to take on virtual machines when the host shuts down. Can be one of none, powerOff, guestShutdown, or suspend.",
+				ValidateFunc: validation.StringInSlice(hostAutostartDefaultStopActionAllowedValues, false),
+			},
+			"virtual_machine": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "The virtual machines to start with the host, in the order they should be started.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"virtual_machine_id": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The UUID of the virtual machine.",
+						},
+						"start_delay": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      -1,
+							Description:  "The delay, in seconds, to wait after starting this virtual machine. -1 uses the host default.",
+							ValidateFunc: validation.IntAtLeast(-1),
+						},
+						"stop_delay": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      -1,
+							Description:  "The delay, in seconds, to wait after stopping this virtual machine. -1 uses the host default.",
+							ValidateFunc: validation.IntAtLeast(-1),
+						},
+						"start_action": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      string(types.AutoStartActionPowerOn),
+							Description:  "The action to take on this virtual machine when the host starts. Can be one of none or powerOn.",
+							ValidateFunc: validation.StringInSlice(hostAutostartVMStartActionAllowedValues, false),
+						},
+						"stop_action": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      string(types.AutoStartActionSystemDefault),
+							Description:  "The action to take on this virtual machine when the host shuts down. Can be one of none, systemDefault, powerOff, guestShutdown, or suspend.",
+							ValidateFunc: validation.StringInSlice(hostAutostartVMStopActionAllowedValues, false),
+						},
+						"wait_for_heartbeat": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      string(types.AutoStartWaitHeartbeatSettingSystemDefault),
+							Description:  "Whether or not to wait for a VMware Tools heartbeat from this virtual machine before starting the next one. Can be one of yes, no, or systemDefault.",
+							ValidateFunc: validation.StringInSlice(hostAutostartVMWaitForHeartbeatAllowedValues, false),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceVSphereHostAutostartCreate(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] %s: Beginning create", resourceVSphereHostAutostartIDString(d))
+	hsID := d.Get("host_system_id").(string)
+	if err := resourceVSphereHostAutostartApply(d, meta, hsID, false); err != nil {
+		return err
+	}
+	d.SetId(hsID)
+	log.Printf("[DEBUG] %s: Create finished successfully", resourceVSphereHostAutostartIDString(d))
+	return resourceVSphereHostAutostartRead(d, meta)
+}
+
+func resourceVSphereHostAutostartRead(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] %s: Beginning read", resourceVSphereHostAutostartIDString(d))
+	client := meta.(*VSphereClient).vimClient
+	m, err := hostAutoStartManagerFromHostSystemID(client, d.Id())
+	if err != nil {
+		return fmt.Errorf("error loading host autostart manager: %s", err)
+	}
+	config, err := m.Config()
+	if err != nil {
+		return fmt.Errorf("error reading autostart configuration: %s", err)
+	}
+	if err := d.Set("host_system_id", d.Id()); err != nil {
+		return err
+	}
+	if err := flattenHostAutoStartManagerConfig(d, client, config); err != nil {
+		return err
+	}
+	log.Printf("[DEBUG] %s: Read completed successfully", resourceVSphereHostAutostartIDString(d))
+	return nil
+}
+
+func resourceVSphereHostAutostartUpdate(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] %s: Beginning update", resourceVSphereHostAutostartIDString(d))
+	if err := resourceVSphereHostAutostartApply(d, meta, d.Id(), false); err != nil {
+		return err
+	}
+	log.Printf("[DEBUG] %s: Update finished successfully", resourceVSphereHostAutostartIDString(d))
+	return resourceVSphereHostAutostartRead(d, meta)
+}
+
+func resourceVSphereHostAutostartDelete(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] %s: Beginning delete", resourceVSphereHostAutostartIDString(d))
+	if err := resourceVSphereHostAutostartApply(d, meta, d.Id(), true); err != nil {
+		return err
+	}
+	log.Printf("[DEBUG] %s: Delete finished successfully", resourceVSphereHostAutostartIDString(d))
+	return nil
+}
+
+func resourceVSphereHostAutostartImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client := meta.(*VSphereClient).vimClient
+	if _, err := hostAutoStartManagerFromHostSystemID(client, d.Id()); err != nil {
+		return nil, fmt.Errorf("error loading host autostart manager: %s", err)
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// resourceVSphereHostAutostartApply sends the autostart configuration in the
+// ResourceData to the host. Virtual machines that are currently in the start
+// order but not in configuration are removed from it. When reset is true,
+// autostart is disabled and all virtual machines are removed from the start
+// order.
+func resourceVSphereHostAutostartApply(d *schema.ResourceData, meta interface{}, hsID string, reset bool) error {
+	client := meta.(*VSphereClient).vimClient
+	m, err := hostAutoStartManagerFromHostSystemID(client, hsID)
+	if err != nil {
+		return fmt.Errorf("error loading host autostart manager: %s", err)
+	}
+	current, err := m.Config()
+	if err != nil {
+		return fmt.Errorf("error reading autostart configuration: %s", err)
+	}
+	spec, err := expandHostAutoStartManagerConfig(d, client, current, reset)
+	if err != nil {
+		return err
+	}
+	log.Printf("[DEBUG] %s: Reconfiguring autostart", resourceVSphereHostAutostartIDString(d))
+	if err := m.Reconfigure(spec); err != nil {
+		return fmt.Errorf("error reconfiguring autostart: %s", err)
+	}
+	return nil
+}
+
+// expandHostAutoStartManagerConfig reads certain ResourceData keys and
+// returns a HostAutoStartManagerConfig. The current configuration is used to
+// work out which virtual machines need to be removed from the start order.
+func expandHostAutoStartManagerConfig(
+	d *schema.ResourceData,
+	client *govmomi.Client,
+	current *types.HostAutoStartManagerConfig,
+	reset bool,
+) (types.HostAutoStartManagerConfig, error) {
+	spec := types.HostAutoStartManagerConfig{
+		Defaults: &types.AutoStartDefaults{
+			Enabled:          structure.BoolPtr(d.Get("enabled").(bool) && !reset),
+			StartDelay:       int32(d.Get("start_delay").(int)),
+			StopDelay:        int32(d.Get("stop_delay").(int)),
+			WaitForHeartbeat: structure.BoolPtr(d.Get("wait_for_heartbeat").(bool)),
+			StopAction:       d.Get("stop_action").(string),
+		},
+	}
+
+	desired := make(map[string]bool)
+	if !reset {
+		for i, raw := range d.Get("virtual_machine").([]interface{}) {
+			vm := raw.(map[string]interface{})
+			uuid := vm["virtual_machine_id"].(string)
+			result, err := virtualmachine.MOIDForUUID(client, uuid)
+			if err != nil {
+				return spec, fmt.Errorf("error locating virtual machine %q: %s", uuid, err)
+			}
+			if desired[result.MOID] {
+				return spec, fmt.Errorf("virtual machine %q is listed more than once", uuid)
+			}
+			desired[result.MOID] = true
+			spec.PowerInfo = append(spec.PowerInfo, types.AutoStartPowerInfo{
+				Key:              types.ManagedObjectReference{Type: "VirtualMachine", Value: result.MOID},
+				StartOrder:       int32(i + 1),
+				StartDelay:       int32(vm["start_delay"].(int)),
+				StopDelay:        int32(vm["stop_delay"].(int)),
+				StartAction:      vm["start_action"].(string),
+				StopAction:       vm["stop_action"].(string),
+				WaitForHeartbeat: types.AutoStartWaitHeartbeatSetting(vm["wait_for_heartbeat"].(string)),
+			})
+		}
+	}
+
+	for _, pi := range current.PowerInfo {
+		if desired[pi.Key.Value] {
+			continue
+		}
+		if pi.StartOrder < 1 && pi.StartAction == string(types.AutoStartActionNone) {
+			continue
+		}
+		spec.PowerInfo = append(spec.PowerInfo, types.AutoStartPowerInfo{
+			Key:              pi.Key,
+			StartOrder:       -1,
+			StartDelay:       -1,
+			StopDelay:        -1,
+			StartAction:      string(types.AutoStartActionNone),
+			StopAction:       string(types.AutoStartActionNone),
+			WaitForHeartbeat: types.AutoStartWaitHeartbeatSettingSystemDefault,
+		})
+	}
+	return spec, nil
+}
+
+// flattenHostAutoStartManagerConfig reads the autostart configuration of a
+// host into the passed in ResourceData. Virtual machines without a start
+// order are not included.
+func flattenHostAutoStartManagerConfig(d *schema.ResourceData, client *govmomi.Client, config *types.HostAutoStartManagerConfig) error {
+	if config.Defaults != nil {
+		attrs := map[string]interface{}{
+			"start_delay": config.Defaults.StartDelay,
+			"stop_delay":  config.Defaults.StopDelay,
+			"stop_action": config.Defaults.StopAction,
+		}
+		if config.Defaults.Enabled != nil {
+			attrs["enabled"] = *config.Defaults.Enabled
+		}
+		if config.Defaults.WaitForHeartbeat != nil {
+			attrs["wait_for_heartbeat"] = *config.Defaults.WaitForHeartbeat
+		}
+		if err := structure.SetBatch(d, attrs); err != nil {
+			return err
+		}
+	}
+
+	var ordered []types.AutoStartPowerInfo
+	for _, pi := range config.PowerInfo {
+		if pi.StartOrder > 0 {
+			ordered = append(ordered, pi)
+		}
+	}
+	sort.Slice(ordered, func(i, j int) bool { return ordered[i].StartOrder < ordered[j].StartOrder })
+
+	var vms []interface{}
+	for _, pi := range ordered {
+		result, err := virtualmachine.UUIDForMOID(client, pi.Key.Value)
+		if err != nil {
+			return fmt.Errorf("error locating virtual machine %q: %s", pi.Key.Value, err)
+		}
+		vms = append(vms, map[string]interface{}{
+			"virtual_machine_id": result.UUID,
+			"start_delay":        int(pi.StartDelay),
+			"stop_delay":         int(pi.StopDelay),
+			"start_action":       pi.StartAction,
+			"stop_action":        pi.StopAction,
+			"wait_for_heartbeat": string(pi.WaitForHeartbeat),
+		})
+	}
+	return d.Set("virtual_machine", vms)
+}
+
+// resourceVSphereHostAutostartIDString prints a friendly string for the
+// vsphere_host_autostart resource.
+func resourceVSphereHostAutostartIDString(d structure.ResourceIDStringer) string {
+	return structure.ResourceIDString(d, resourceVSphereHostAutostartName)
+}
@@ -0,0 +1,245 @@
+package vsphere
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/virtualmachine"
+)
+
+func TestAccResourceVSphereHostAutostart_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccResourceVSphereHostAutostartPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceVSphereHostAutostartConfigDefaults(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vsphere_host_autostart.autostart", "enabled", "true"),
+					resource.TestCheckResourceAttr("vsphere_host_autostart.autostart", "start_delay", "60"),
+					testAccResourceVSphereHostAutostartOrder(nil),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceVSphereHostAutostart_order(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccResourceVSphereHostAutostartPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceVSphereHostAutostartConfigOrder("vm1", "vm2"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereHostAutostartOrder([]string{"vm1", "vm2"}),
+				),
+			},
+			{
+				Config: testAccResourceVSphereHostAutostartConfigOrder("vm2", "vm1"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereHostAutostartOrder([]string{"vm2", "vm1"}),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceVSphereHostAutostart_import(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccResourceVSphereHostAutostartPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceVSphereHostAutostartConfigOrder("vm1", "vm2"),
+			},
+			{
+				ResourceName:      "vsphere_host_autostart.autostart",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccResourceVSphereHostAutostartPreCheck(t *testing.T) {
+	if os.Getenv("VSPHERE_DATACENTER") == "" {
+		t.Skip("set VSPHERE_DATACENTER to run vsphere_host_autostart acceptance tests")
+	}
+	if os.Getenv("VSPHERE_ESXI_HOST") == "" {
+		t.Skip("set VSPHERE_ESXI_HOST to run vsphere_host_autostart acceptance tests")
+	}
+	if os.Getenv("VSPHERE_DATASTORE") == "" {
+		t.Skip("set VSPHERE_DATASTORE to run vsphere_host_autostart acceptance tests")
+	}
+	if os.Getenv("VSPHERE_NETWORK_LABEL_PXE") == "" {
+		t.Skip("set VSPHERE_NETWORK_LABEL_PXE to run vsphere_host_autostart acceptance tests")
+	}
+}
+
+// testAccResourceVSphereHostAutostartOrder checks that the virtual machines
+// with the supplied resource names are in the autostart order of the host, in
+// the order given.
+func testAccResourceVSphereHostAutostartOrder(names []string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		vars, err := testClientVariablesForResource(s, "vsphere_host_autostart.autostart")
+		if err != nil {
+			return err
+		}
+		m, err := hostAutoStartManagerFromHostSystemID(vars.client, vars.resourceID)
+		if err != nil {
+			return err
+		}
+		config, err := m.Config()
+		if err != nil {
+			return err
+		}
+		expected := make(map[string]int32)
+		for i, name := range names {
+			rs, ok := s.RootModule().Resources["vsphere_virtual_machine."+name]
+			if !ok {
+				return fmt.Errorf("vsphere_virtual_machine.%s not found in state", name)
+			}
+			result, err := virtualmachine.MOIDForUUID(vars.client, rs.Primary.ID)
+			if err != nil {
+				return err
+			}
+			expected[result.MOID] = int32(i + 1)
+		}
+		var actual int
+		for _, pi := range config.PowerInfo {
+			if pi.StartOrder < 1 {
+				continue
+			}
+			actual++
+			if order, ok := expected[pi.Key.Value]; !ok || order != pi.StartOrder {
+				return fmt.Errorf("unexpected start order %d for virtual machine %q", pi.StartOrder, pi.Key.Value)
+			}
+		}
+		if actual != len(names) {
+			return fmt.Errorf("expected %d virtual machines in start order, got %d", len(names), actual)
+		}
+		return nil
+	}
+}
+
+func testAccResourceVSphereHostAutostartConfigBase() string {
+	return fmt.Sprintf(`
+data "vsphere_datacenter" "datacenter" {
+  name = "%s"
+}
+
+data "vsphere_host" "esxi_host" {
+  name          = "%s"
+  datacenter_id = "${data.vsphere_datacenter.datacenter.id}"
+}
+
+data "vsphere_datastore" "datastore" {
+  name          = "%s"
+  datacenter_id = "${data.vsphere_datacenter.datacenter.id}"
+}
+
+data "vsphere_network" "network" {
+  name          = "%s"
+  datacenter_id = "${data.vsphere_datacenter.datacenter.id}"
+}
+`,
+		os.Getenv("VSPHERE_DATACENTER"),
+		os.Getenv("VSPHERE_ESXI_HOST"),
+		os.Getenv("VSPHERE_DATASTORE"),
+		os.Getenv("VSPHERE_NETWORK_LABEL_PXE"),
+	)
+}
+
+func testAccResourceVSphereHostAutostartConfigDefaults() string {
+	return fmt.Sprintf(`
+%s
+
+resource "vsphere_host_autostart" "autostart" {
+  host_system_id = "${data.vsphere_host.esxi_host.id}"
+  start_delay    = 60
+}
+`,
+		testAccResourceVSphereHostAutostartConfigBase(),
+	)
+}
+
+func testAccResourceVSphereHostAutostartConfigOrder(first, second string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "vsphere_virtual_machine" "vm1" {
+  name             = "terraform-test-autostart-1"
+  resource_pool_id = "${data.vsphere_host.esxi_host.resource_pool_id}"
+  host_system_id   = "${data.vsphere_host.esxi_host.id}"
+  datastore_id     = "${data.vsphere_datastore.datastore.id}"
+
+  num_cpus = 1
+  memory   = 512
+  guest_id = "other3xLinux64Guest"
+
+  wait_for_guest_net_timeout = -1
+
+  network_interface {
+    network_id = "${data.vsphere_network.network.id}"
+  }
+
+  disk {
+    label = "disk0"
+    size  = 1
+  }
+}
+
+resource "vsphere_virtual_machine" "vm2" {
+  name             = "terraform-test-autostart-2"
+  resource_pool_id = "${data.vsphere_host.esxi_host.resource_pool_id}"
+  host_system_id   = "${data.vsphere_host.esxi_host.id}"
+  datastore_id     = "${data.vsphere_datastore.datastore.id}"
+
+  num_cpus = 1
+  memory   = 512
+  guest_id = "other3xLinux64Guest"
+
+  wait_for_guest_net_timeout = -1
+
+  network_interface {
+    network_id = "${data.vsphere_network.network.id}"
+  }
+
+  disk {
+    label = "disk0"
+    size  = 1
+  }
+}
+
+resource "vsphere_host_autostart" "autostart" {
+  host_system_id = "${data.vsphere_host.esxi_host.id}"
+
+  virtual_machine {
+    virtual_machine_id = "${vsphere_virtual_machine.%s.id}"
+  }
+
+  virtual_machine {
+    virtual_machine_id = "${vsphere_virtual_machine.%s.id}"
+    start_delay        = 30
+    wait_for_heartbeat = "yes"
+  }
+}
+`,
+		testAccResourceVSphereHostAutostartConfigBase(),
+		first,
+		second,
+	)
+}
@@ -0,0 +1,354 @@
+package vsphere
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/structure"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+const resourceVSphereHostFirewallRulesetName = "vsphere_host_firewall_ruleset"
+
+func resourceVSphereHostFirewallRuleset() *schema.Resource {
+	return &schema.Resource{
+		Create:        resourceVSphereHostFirewallRulesetCreate,
+		Read:          resourceVSphereHostFirewallRulesetRead,
+		Update:        resourceVSphereHostFirewallRulesetUpdate,
+		Delete:        resourceVSphereHostFirewallRulesetDelete,
+		CustomizeDiff: resourceVSphereHostFirewallRulesetCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			State: resourceVSphereHostFirewallRulesetImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"host_system_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The managed object ID of the host to manage the firewall ruleset on.",
+			},
+			"ruleset_key": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The key of the firewall ruleset, such as sshServer or ntpClient.",
+			},
+			"enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether or not the ruleset is enabled.",
+			},
+			"allow_all_ip": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Allow connections from all IP addresses. Must be set to false if allowed_ip_addresses or allowed_ip_networks are specified.",
+			},
+			"allowed_ip_addresses": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "The list of IP addresses that are allowed to connect to this ruleset.",
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.SingleIP(),
+				},
+			},
+			"allowed_ip_networks": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "The list of networks, in CIDR notation, that are allowed to connect to this ruleset.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+					ValidateFunc: func(v interface{}, k string) ([]string, []error) {
+						ws, es := validation.CIDRNetwork(0, 128)(v, k)
+						if len(es) > 0 {
+							return ws, es
+						}
+						// The host only stores the network address, so a CIDR with host
+						// bits set would never match what is read back.
+						ip, ipnet, _ := net.ParseCIDR(v.(string))
+						if !ip.Equal(ipnet.IP) {
+							return ws, []error{fmt.Errorf("%s: %q is not a network address, use %q instead", k, v.(string), ipnet.String())}
+						}
+						return ws, nil
+					},
+				},
+			},
+			"label": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The display label of the ruleset.",
+			},
+			"required": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether or not the ruleset is required by the host and cannot be disabled.",
+			},
+		},
+	}
+}
+
+func resourceVSphereHostFirewallRulesetCreate(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] %s: Beginning create", resourceVSphereHostFirewallRulesetIDString(d))
+	client := meta.(*VSphereClient).vimClient
+	hsID := d.Get("host_system_id").(string)
+	key := d.Get("ruleset_key").(string)
+
+	fs, err := hostFirewallSystemFromHostSystemID(client, hsID)
+	if err != nil {
+		return fmt.Errorf("error loading host firewall system: %s", err)
+	}
+	rs, err := hostFirewallRulesetFromKey(fs, key)
+	if err != nil {
+		return err
+	}
+	if rs == nil {
+		return fmt.Errorf("firewall ruleset %q not found on host %q", key, hsID)
+	}
+
+	if err := resourceVSphereHostFirewallRulesetApply(d, fs, rs); err != nil {
+		return err
+	}
+
+	d.SetId(resourceVSphereHostFirewallRulesetFlattenID(hsID, key))
+	log.Printf("[DEBUG] %s: Create finished successfully", resourceVSphereHostFirewallRulesetIDString(d))
+	return resourceVSphereHostFirewallRulesetRead(d, meta)
+}
+
+func resourceVSphereHostFirewallRulesetRead(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] %s: Beginning read", resourceVSphereHostFirewallRulesetIDString(d))
+	client := meta.(*VSphereClient).vimClient
+	hsID, key, err := resourceVSphereHostFirewallRulesetParseID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	fs, err := hostFirewallSystemFromHostSystemID(client, hsID)
+	if err != nil {
+		return fmt.Errorf("error loading host firewall system: %s", err)
+	}
+	rs, err := hostFirewallRulesetFromKey(fs, key)
+	if err != nil {
+		return err
+	}
+	if rs == nil {
+		log.Printf("[DEBUG] %s: Ruleset not found, marking resource as gone", resourceVSphereHostFirewallRulesetIDString(d))
+		d.SetId("")
+		return nil
+	}
+
+	if err := structure.SetBatch(d, map[string]interface{}{
+		"host_system_id": hsID,
+		"ruleset_key":    rs.Key,
+		"enabled":        rs.Enabled,
+		"label":          rs.Label,
+		"required":       rs.Required,
+	}); err != nil {
+		return err
+	}
+	if err := flattenHostFirewallRulesetIPList(d, rs.AllowedHosts); err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] %s: Read completed successfully", resourceVSphereHostFirewallRulesetIDString(d))
+	return nil
+}
+
+func resourceVSphereHostFirewallRulesetUpdate(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] %s: Beginning update", resourceVSphereHostFirewallRulesetIDString(d))
+	client := meta.(*VSphereClient).vimClient
+	hsID, key, err := resourceVSphereHostFirewallRulesetParseID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	fs, err := hostFirewallSystemFromHostSystemID(client, hsID)
+	if err != nil {
+		return fmt.Errorf("error loading host firewall system: %s", err)
+	}
+	rs, err := hostFirewallRulesetFromKey(fs, key)
+	if err != nil {
+		return err
+	}
+	if rs == nil {
+		return fmt.Errorf("firewall ruleset %q not found on host %q", key, hsID)
+	}
+
+	if err := resourceVSphereHostFirewallRulesetApply(d, fs, rs); err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] %s: Update finished successfully", resourceVSphereHostFirewallRulesetIDString(d))
+	return resourceVSphereHostFirewallRulesetRead(d, meta)
+}
+
+func resourceVSphereHostFirewallRulesetDelete(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] %s: Beginning delete", resourceVSphereHostFirewallRulesetIDString(d))
+	client := meta.(*VSphereClient).vimClient
+	hsID, key, err := resourceVSphereHostFirewallRulesetParseID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	fs, err := hostFirewallSystemFromHostSystemID(client, hsID)
+	if err != nil {
+		return fmt.Errorf("error loading host firewall system: %s", err)
+	}
+
+	// Rulesets are built into the host and cannot be removed. Destroying the
+	// resource opens the ruleset back up to all IP addresses, but leaves its
+	// enabled state alone.
+	spec := types.HostFirewallRulesetRulesetSpec{
+		AllowedHosts: types.HostFirewallRulesetIpList{
+			AllIp: true,
+		},
+	}
+	if err := updateHostFirewallRuleset(fs, key, spec); err != nil {
+		return fmt.Errorf("error resetting allowed hosts on firewall ruleset %q: %s", key, err)
+	}
+
+	log.Printf("[DEBUG] %s: Deleted successfully", resourceVSphereHostFirewallRulesetIDString(d))
+	return nil
+}
+
+func resourceVSphereHostFirewallRulesetImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	hsID, key, err := resourceVSphereHostFirewallRulesetParseID(d.Id())
+	if err != nil {
+		return nil, err
+	}
+	client := meta.(*VSphereClient).vimClient
+	fs, err := hostFirewallSystemFromHostSystemID(client, hsID)
+	if err != nil {
+		return nil, fmt.Errorf("error loading host firewall system: %s", err)
+	}
+	rs, err := hostFirewallRulesetFromKey(fs, key)
+	if err != nil {
+		return nil, err
+	}
+	if rs == nil {
+		return nil, fmt.Errorf("firewall ruleset %q not found on host %q", key, hsID)
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceVSphereHostFirewallRulesetCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if !d.Get("allow_all_ip").(bool) {
+		return nil
+	}
+	if d.Get("allowed_ip_addresses").(*schema.Set).Len() > 0 || d.Get("allowed_ip_networks").(*schema.Set).Len() > 0 {
+		return errors.New("allow_all_ip must be set to false when allowed_ip_addresses or allowed_ip_networks are specified")
+	}
+	return nil
+}
+
+// resourceVSphereHostFirewallRulesetApply sends the enabled state and allowed
+// hosts in the ResourceData to the host, skipping the enable/disable call if
+// the ruleset is already in the desired state.
+func resourceVSphereHostFirewallRulesetApply(d *schema.ResourceData, fs *object.HostFirewallSystem, rs *types.HostFirewallRuleset) error {
+	spec, err := expandHostFirewallRulesetIPList(d)
+	if err != nil {
+		return err
+	}
+	if err := updateHostFirewallRuleset(fs, rs.Key, types.HostFirewallRulesetRulesetSpec{AllowedHosts: *spec}); err != nil {
+		return fmt.Errorf("error updating allowed hosts on firewall ruleset %q: %s", rs.Key, err)
+	}
+
+	enabled := d.Get("enabled").(bool)
+	if enabled == rs.Enabled {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	if enabled {
+		log.Printf("[DEBUG] %s: Enabling ruleset", resourceVSphereHostFirewallRulesetIDString(d))
+		if err := fs.EnableRuleset(ctx, rs.Key); err != nil {
+			return fmt.Errorf("error enabling firewall ruleset %q: %s", rs.Key, err)
+		}
+		return nil
+	}
+	if rs.Required {
+		return fmt.Errorf("firewall ruleset %q is required by the host and cannot be disabled", rs.Key)
+	}
+	log.Printf("[DEBUG] %s: Disabling ruleset", resourceVSphereHostFirewallRulesetIDString(d))
+	if err := fs.DisableRuleset(ctx, rs.Key); err != nil {
+		return fmt.Errorf("error disabling firewall ruleset %q: %s", rs.Key, err)
+	}
+	return nil
+}
+
+// expandHostFirewallRulesetIPList reads certain ResourceData keys and returns
+// a HostFirewallRulesetIpList.
+func expandHostFirewallRulesetIPList(d *schema.ResourceData) (*types.HostFirewallRulesetIpList, error) {
+	obj := &types.HostFirewallRulesetIpList{
+		AllIp:     d.Get("allow_all_ip").(bool),
+		IpAddress: structure.SliceInterfacesToStrings(d.Get("allowed_ip_addresses").(*schema.Set).List()),
+	}
+	for _, v := range d.Get("allowed_ip_networks").(*schema.Set).List() {
+		_, ipnet, err := net.ParseCIDR(v.(string))
+		if err != nil {
+			return nil, fmt.Errorf("error parsing network %q: %s", v, err)
+		}
+		prefix, _ := ipnet.Mask.Size()
+		obj.IpNetwork = append(obj.IpNetwork, types.HostFirewallRulesetIpNetwork{
+			Network:      ipnet.IP.String(),
+			PrefixLength: int32(prefix),
+		})
+	}
+	return obj, nil
+}
+
+// flattenHostFirewallRulesetIPList reads various fields from a
+// HostFirewallRulesetIpList into the passed in ResourceData.
+func flattenHostFirewallRulesetIPList(d *schema.ResourceData, obj *types.HostFirewallRulesetIpList) error {
+	if obj == nil {
+		// No allowed hosts list means the ruleset is open to everyone.
+		obj = &types.HostFirewallRulesetIpList{AllIp: true}
+	}
+	return structure.SetBatch(d, map[string]interface{}{
+		"allow_all_ip":         obj.AllIp,
+		"allowed_ip_addresses": obj.IpAddress,
+		"allowed_ip_networks":  flattenHostFirewallRulesetIPNetworks(obj.IpNetwork),
+	})
+}
+
+// flattenHostFirewallRulesetIPNetworks converts a list of
+// HostFirewallRulesetIpNetwork to CIDR notation strings.
+func flattenHostFirewallRulesetIPNetworks(nets []types.HostFirewallRulesetIpNetwork) []string {
+	var s []string
+	for _, n := range nets {
+		s = append(s, n.Network+"/"+strconv.Itoa(int(n.PrefixLength)))
+	}
+	return s
+}
+
+// resourceVSphereHostFirewallRulesetIDString prints a friendly string for the
+// vsphere_host_firewall_ruleset resource.
+func resourceVSphereHostFirewallRulesetIDString(d structure.ResourceIDStringer) string {
+	return structure.ResourceIDString(d, resourceVSphereHostFirewallRulesetName)
+}
+
+// resourceVSphereHostFirewallRulesetFlattenID makes an ID for the
+// vsphere_host_firewall_ruleset resource.
+func resourceVSphereHostFirewallRulesetFlattenID(hsID, key string) string {
+	return strings.Join([]string{hsID, key}, ":")
+}
+
+// resourceVSphereHostFirewallRulesetParseID parses an ID for the
+// vsphere_host_firewall_ruleset and outputs its parts.
+func resourceVSphereHostFirewallRulesetParseID(id string) (string, string, error) {
+	parts := strings.SplitN(id, ":", 2)
+	if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("bad ID %q", id)
+	}
+	return parts[0], parts[1], nil
+}
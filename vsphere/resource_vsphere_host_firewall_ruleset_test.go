@@ -0,0 +1,369 @@
+package vsphere
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/hostsystem"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+const testAccResourceVSphereHostFirewallRulesetKey = "syslog"
+
+func TestAccResourceVSphereHostFirewallRuleset_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccResourceVSphereHostFirewallRulesetPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccResourceVSphereHostFirewallRulesetAllowsAll(),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceVSphereHostFirewallRulesetConfigAllowAll(),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereHostFirewallRulesetMatch(true, true, nil, nil),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceVSphereHostFirewallRuleset_restricted(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccResourceVSphereHostFirewallRulesetPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccResourceVSphereHostFirewallRulesetAllowsAll(),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceVSphereHostFirewallRulesetConfigRestricted(),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereHostFirewallRulesetMatch(
+						true,
+						false,
+						[]string{"10.0.0.10"},
+						[]types.HostFirewallRulesetIpNetwork{{Network: "192.168.0.0", PrefixLength: 24}},
+					),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceVSphereHostFirewallRuleset_disable(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccResourceVSphereHostFirewallRulesetPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccResourceVSphereHostFirewallRulesetAllowsAll(),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceVSphereHostFirewallRulesetConfigAllowAll(),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereHostFirewallRulesetMatch(true, true, nil, nil),
+				),
+			},
+			{
+				Config: testAccResourceVSphereHostFirewallRulesetConfigDisabled(),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereHostFirewallRulesetMatch(false, true, nil, nil),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceVSphereHostFirewallRuleset_badAllowAll(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccResourceVSphereHostFirewallRulesetPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccResourceVSphereHostFirewallRulesetConfigBadAllowAll(),
+				ExpectError: regexp.MustCompile("allow_all_ip must be set to false"),
+				PlanOnly:    true,
+			},
+			{
+				Config: testAccResourceVSphereEmpty,
+				Check:  resource.ComposeTestCheckFunc(),
+			},
+		},
+	})
+}
+
+func TestAccResourceVSphereHostFirewallRuleset_badNetwork(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccResourceVSphereHostFirewallRulesetPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccResourceVSphereHostFirewallRulesetConfigBadNetwork(),
+				ExpectError: regexp.MustCompile("is not a network address"),
+				PlanOnly:    true,
+			},
+			{
+				Config: testAccResourceVSphereEmpty,
+				Check:  resource.ComposeTestCheckFunc(),
+			},
+		},
+	})
+}
+
+func TestAccResourceVSphereHostFirewallRuleset_import(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccResourceVSphereHostFirewallRulesetPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccResourceVSphereHostFirewallRulesetAllowsAll(),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceVSphereHostFirewallRulesetConfigRestricted(),
+			},
+			{
+				ResourceName:      "vsphere_host_firewall_ruleset.ruleset",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccResourceVSphereHostFirewallRulesetPreCheck(t *testing.T) {
+	if os.Getenv("VSPHERE_DATACENTER") == "" {
+		t.Skip("set VSPHERE_DATACENTER to run vsphere_host_firewall_ruleset acceptance tests")
+	}
+	if os.Getenv("VSPHERE_ESXI_HOST") == "" {
+		t.Skip("set VSPHERE_ESXI_HOST to run vsphere_host_firewall_ruleset acceptance tests")
+	}
+}
+
+func testAccResourceVSphereHostFirewallRulesetGet(s *terraform.State) (*types.HostFirewallRuleset, error) {
+	vars, err := testClientVariablesForResource(s, "vsphere_host_firewall_ruleset.ruleset")
+	if err != nil {
+		return nil, err
+	}
+	hsID, key, err := resourceVSphereHostFirewallRulesetParseID(vars.resourceID)
+	if err != nil {
+		return nil, err
+	}
+	fs, err := hostFirewallSystemFromHostSystemID(vars.client, hsID)
+	if err != nil {
+		return nil, err
+	}
+	rs, err := hostFirewallRulesetFromKey(fs, key)
+	if err != nil {
+		return nil, err
+	}
+	if rs == nil {
+		return nil, fmt.Errorf("firewall ruleset %q not found", key)
+	}
+	return rs, nil
+}
+
+func testAccResourceVSphereHostFirewallRulesetMatch(
+	enabled bool,
+	allIP bool,
+	addresses []string,
+	networks []types.HostFirewallRulesetIpNetwork,
+) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, err := testAccResourceVSphereHostFirewallRulesetGet(s)
+		if err != nil {
+			return err
+		}
+		if rs.Enabled != enabled {
+			return fmt.Errorf("expected enabled to be %t, got %t", enabled, rs.Enabled)
+		}
+		actual := rs.AllowedHosts
+		if actual == nil {
+			actual = &types.HostFirewallRulesetIpList{AllIp: true}
+		}
+		if actual.AllIp != allIP {
+			return fmt.Errorf("expected allIp to be %t, got %t", allIP, actual.AllIp)
+		}
+		if len(actual.IpAddress) != len(addresses) {
+			return fmt.Errorf("expected IP addresses %v, got %v", addresses, actual.IpAddress)
+		}
+		for i := range addresses {
+			if actual.IpAddress[i] != addresses[i] {
+				return fmt.Errorf("expected IP addresses %v, got %v", addresses, actual.IpAddress)
+			}
+		}
+		if len(actual.IpNetwork) != len(networks) {
+			return fmt.Errorf("expected IP networks %+v, got %+v", networks, actual.IpNetwork)
+		}
+		for i := range networks {
+			if actual.IpNetwork[i].Network != networks[i].Network || actual.IpNetwork[i].PrefixLength != networks[i].PrefixLength {
+				return fmt.Errorf("expected IP networks %+v, got %+v", networks, actual.IpNetwork)
+			}
+		}
+		return nil
+	}
+}
+
+// testAccResourceVSphereHostFirewallRulesetAllowsAll checks that the
+// ruleset has been opened back up to all IP addresses on destroy. The
+// resource is not present in state at this point, so the host is looked up
+// from the environment instead.
+func testAccResourceVSphereHostFirewallRulesetAllowsAll() resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*VSphereClient).vimClient
+		dc, err := getDatacenter(client, os.Getenv("VSPHERE_DATACENTER"))
+		if err != nil {
+			return err
+		}
+		hs, err := hostsystem.SystemOrDefault(client, os.Getenv("VSPHERE_ESXI_HOST"), dc)
+		if err != nil {
+			return err
+		}
+		fs, err := hostFirewallSystemFromHostSystemID(client, hs.Reference().Value)
+		if err != nil {
+			return err
+		}
+		rs, err := hostFirewallRulesetFromKey(fs, testAccResourceVSphereHostFirewallRulesetKey)
+		if err != nil {
+			return err
+		}
+		if rs == nil {
+			return fmt.Errorf("firewall ruleset %q not found", testAccResourceVSphereHostFirewallRulesetKey)
+		}
+		if rs.AllowedHosts != nil && !rs.AllowedHosts.AllIp {
+			return fmt.Errorf("expected ruleset %q to allow all IP addresses after destroy", rs.Key)
+		}
+		return nil
+	}
+}
+
+func testAccResourceVSphereHostFirewallRulesetConfigAllowAll() string {
+	return fmt.Sprintf(`
+data "vsphere_datacenter" "datacenter" {
+  name = "%s"
+}
+
+data "vsphere_host" "esxi_host" {
+  name          = "%s"
+  datacenter_id = "${data.vsphere_datacenter.datacenter.id}"
+}
+
+resource "vsphere_host_firewall_ruleset" "ruleset" {
+  host_system_id = "${data.vsphere_host.esxi_host.id}"
+  ruleset_key    = "%s"
+}
+`,
+		os.Getenv("VSPHERE_DATACENTER"),
+		os.Getenv("VSPHERE_ESXI_HOST"),
+		testAccResourceVSphereHostFirewallRulesetKey,
+	)
+}
+
+func testAccResourceVSphereHostFirewallRulesetConfigDisabled() string {
+	return fmt.Sprintf(`
+data "vsphere_datacenter" "datacenter" {
+  name = "%s"
+}
+
+data "vsphere_host" "esxi_host" {
+  name          = "%s"
+  datacenter_id = "${data.vsphere_datacenter.datacenter.id}"
+}
+
+resource "vsphere_host_firewall_ruleset" "ruleset" {
+  host_system_id = "${data.vsphere_host.esxi_host.id}"
+  ruleset_key    = "%s"
+  enabled        = false
+}
+`,
+		os.Getenv("VSPHERE_DATACENTER"),
+		os.Getenv("VSPHERE_ESXI_HOST"),
+		testAccResourceVSphereHostFirewallRulesetKey,
+	)
+}
+
+func testAccResourceVSphereHostFirewallRulesetConfigRestricted() string {
+	return fmt.Sprintf(`
+data "vsphere_datacenter" "datacenter" {
+  name = "%s"
+}
+
+data "vsphere_host" "esxi_host" {
+  name          = "%s"
+  datacenter_id = "${data.vsphere_datacenter.datacenter.id}"
+}
+
+resource "vsphere_host_firewall_ruleset" "ruleset" {
+  host_system_id       = "${data.vsphere_host.esxi_host.id}"
+  ruleset_key          = "%s"
+  allow_all_ip         = false
+  allowed_ip_addresses = ["10.0.0.10"]
+  allowed_ip_networks  = ["192.168.0.0/24"]
+}
+`,
+		os.Getenv("VSPHERE_DATACENTER"),
+		os.Getenv("VSPHERE_ESXI_HOST"),
+		testAccResourceVSphereHostFirewallRulesetKey,
+	)
+}
+
+func testAccResourceVSphereHostFirewallRulesetConfigBadAllowAll() string {
+	return fmt.Sprintf(`
+data "vsphere_datacenter" "datacenter" {
+  name = "%s"
+}
+
+data "vsphere_host" "esxi_host" {
+  name          = "%s"
+  datacenter_id = "${data.vsphere_datacenter.datacenter.id}"
+}
+
+resource "vsphere_host_firewall_ruleset" "ruleset" {
+  host_system_id       = "${data.vsphere_host.esxi_host.id}"
+  ruleset_key          = "%s"
+  allowed_ip_addresses = ["10.0.0.10"]
+}
+`,
+		os.Getenv("VSPHERE_DATACENTER"),
+		os.Getenv("VSPHERE_ESXI_HOST"),
+		testAccResourceVSphereHostFirewallRulesetKey,
+	)
+}
+
+func testAccResourceVSphereHostFirewallRulesetConfigBadNetwork() string {
+	return fmt.Sprintf(`
+data "vsphere_datacenter" "datacenter" {
+  name = "%s"
+}
+
+data "vsphere_host" "esxi_host" {
+  name          = "%s"
+  datacenter_id = "${data.vsphere_datacenter.datacenter.id}"
+}
+
+resource "vsphere_host_firewall_ruleset" "ruleset" {
+  host_system_id      = "${data.vsphere_host.esxi_host.id}"
+  ruleset_key         = "%s"
+  allow_all_ip        = false
+  allowed_ip_networks = ["192.168.0.1/24"]
+}
+`,
+		os.Getenv("VSPHERE_DATACENTER"),
+		os.Getenv("VSPHERE_ESXI_HOST"),
+		testAccResourceVSphereHostFirewallRulesetKey,
+	)
+}
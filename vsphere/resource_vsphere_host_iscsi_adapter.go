@@ -0,0 +1,558 @@
+package vsphere
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/structure"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+const resourceVSphereHostIscsiAdapterName = "vsphere_host_iscsi_adapter"
+
+// hostInternetScsiDefaultPort is the default port for iSCSI targets.
+const hostInternetScsiDefaultPort = 3260
+
+var hostInternetScsiHbaChapAuthenticationTypeAllowedValues = []string{
+	string(types.HostInternetScsiHbaChapAuthenticationTypeChapProhibited),
+	string(types.HostInternetScsiHbaChapAuthenticationTypeChapDiscouraged),
+	string(types.HostInternetScsiHbaChapAuthenticationTypeChapPreferred),
+	string(types.HostInternetScsiHbaChapAuthenticationTypeChapRequired),
+}
+
+func resourceVSphereHostIscsiAdapter() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceVSphereHostIscsiAdapterCreate,
+		Read:   resourceVSphereHostIscsiAdapterRead,
+		Update: resourceVSphereHostIscsiAdapterUpdate,
+		Delete: resourceVSphereHostIscsiAdapterDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceVSphereHostIscsiAdapterImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"host_system_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The managed object ID of the host to enable the software iSCSI adapter on.",
+			},
+			"send_target": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "The dynamic discovery (send) targets of the adapter.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"address": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The address of the iSCSI server.",
+						},
+						"port": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      hostInternetScsiDefaultPort,
+							Description:  "The TCP port of the iSCSI server.",
+							ValidateFunc: validation.IntBetween(1, 65535),
+						},
+					},
+				},
+			},
+			"static_target": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "The static discovery targets of the adapter.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"address": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The address of the iSCSI target.",
+						},
+						"port": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      hostInternetScsiDefaultPort,
+							Description:  "The TCP port of the iSCSI target.",
+							ValidateFunc: validation.IntBetween(1, 65535),
+						},
+						"iqn": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The iSCSI name of the target.",
+						},
+					},
+				},
+			},
+			"chap_authentication_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      string(types.HostInternetScsiHbaChapAuthenticationTypeChapProhibited),
+				Description:  "The CHAP authentication type of the adapter. Can be one of chapProhibited, chapDiscouraged, chapPreferred, or chapRequired.",
+				ValidateFunc: validation.StringInSlice(hostInternetScsiHbaChapAuthenticationTypeAllowedValues, false),
+			},
+			"chap_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The CHAP name the adapter uses to authenticate to targets.",
+			},
+			"chap_secret": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "The CHAP secret the adapter uses to authenticate to targets.",
+			},
+			"mutual_chap_authentication_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      string(types.HostInternetScsiHbaChapAuthenticationTypeChapProhibited),
+				Description:  "The mutual CHAP authentication type of the adapter. Can be one of chapProhibited or chapRequired.",
+				ValidateFunc: validation.StringInSlice(hostInternetScsiHbaChapAuthenticationTypeAllowedValues, false),
+			},
+			"mutual_chap_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The CHAP name targets use to authenticate to the adapter.",
+			},
+			"mutual_chap_secret": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "The CHAP secret targets use to authenticate to the adapter.",
+			},
+			"bound_vmknics": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "The VMkernel network adapters, such as vmk1, to bind to the adapter.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"rescan": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Rescan all host bus adapters and VMFS volumes after targets or port bindings change.",
+			},
+			"device": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The device name of the software iSCSI adapter, such as vmhba64.",
+			},
+			"iscsi_name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The iSCSI qualified name (IQN) of the adapter.",
+			},
+		},
+	}
+}
+
+func resourceVSphereHostIscsiAdapterCreate(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] %s: Beginning create", resourceVSphereHostIscsiAdapterIDString(d))
+	client := meta.(*VSphereClient).vimClient
+	hsID := d.Get("host_system_id").(string)
+	ss, err := hostStorageSystemFromHostSystemID(client, hsID)
+	if err != nil {
+		return fmt.Errorf("error loading host storage system: %s", err)
+	}
+
+	hba, err := resourceVSphereHostIscsiAdapterEnable(ss)
+	if err != nil {
+		return err
+	}
+	// Set the ID now, so that a partially configured adapter is tracked in
+	// state if any of the following steps fail.
+	d.SetId(hsID)
+
+	if err := resourceVSphereHostIscsiAdapterApply(d, meta, ss, hba); err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] %s: Create finished successfully", resourceVSphereHostIscsiAdapterIDString(d))
+	return resourceVSphereHostIscsiAdapterRead(d, meta)
+}
+
+func resourceVSphereHostIscsiAdapterRead(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] %s: Beginning read", resourceVSphereHostIscsiAdapterIDString(d))
+	client := meta.(*VSphereClient).vimClient
+	ss, err := hostStorageSystemFromHostSystemID(client, d.Id())
+	if err != nil {
+		return fmt.Errorf("error loading host storage system: %s", err)
+	}
+	hba, err := hostSoftwareInternetScsiHba(ss)
+	if err != nil {
+		return err
+	}
+	if hba == nil {
+		log.Printf("[DEBUG] %s: Software iSCSI is disabled, marking resource as gone", resourceVSphereHostIscsiAdapterIDString(d))
+		d.SetId("")
+		return nil
+	}
+
+	im, err := hostIscsiManagerFromHostSystemID(client, d.Id())
+	if err != nil {
+		return err
+	}
+	vnics, err := im.BoundVnics(hba.Device)
+	if err != nil {
+		return fmt.Errorf("error querying bound VMkernel adapters: %s", err)
+	}
+
+	if err := structure.SetBatch(d, map[string]interface{}{
+		"host_system_id": d.Id(),
+		"device":         hba.Device,
+		"iscsi_name":     hba.IScsiName,
+		"bound_vmknics":  vnics,
+		"send_target":    flattenHostInternetScsiHbaSendTargets(hba.ConfiguredSendTarget),
+		"static_target":  flattenHostInternetScsiHbaStaticTargets(hba.ConfiguredStaticTarget),
+	}); err != nil {
+		return err
+	}
+	if err := flattenHostInternetScsiHbaAuthenticationProperties(d, &hba.AuthenticationProperties); err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] %s: Read completed successfully", resourceVSphereHostIscsiAdapterIDString(d))
+	return nil
+}
+
+func resourceVSphereHostIscsiAdapterUpdate(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] %s: Beginning update", resourceVSphereHostIscsiAdapterIDString(d))
+	client := meta.(*VSphereClient).vimClient
+	ss, err := hostStorageSystemFromHostSystemID(client, d.Id())
+	if err != nil {
+		return fmt.Errorf("error loading host storage system: %s", err)
+	}
+	hba, err := hostSoftwareInternetScsiHba(ss)
+	if err != nil {
+		return err
+	}
+	if hba == nil {
+		return errors.New("software iSCSI adapter not found, it may have been disabled outside of Terraform")
+	}
+
+	if err := resourceVSphereHostIscsiAdapterApply(d, meta, ss, hba); err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] %s: Update finished successfully", resourceVSphereHostIscsiAdapterIDString(d))
+	return resourceVSphereHostIscsiAdapterRead(d, meta)
+}
+
+func resourceVSphereHostIscsiAdapterDelete(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] %s: Beginning delete", resourceVSphereHostIscsiAdapterIDString(d))
+	client := meta.(*VSphereClient).vimClient
+	ss, err := hostStorageSystemFromHostSystemID(client, d.Id())
+	if err != nil {
+		return fmt.Errorf("error loading host storage system: %s", err)
+	}
+	hba, err := hostSoftwareInternetScsiHba(ss)
+	if err != nil {
+		return err
+	}
+	if hba == nil {
+		// Already disabled, nothing to do.
+		return nil
+	}
+
+	var static []types.HostInternetScsiHbaStaticTarget
+	for _, t := range hba.ConfiguredStaticTarget {
+		if hostInternetScsiHbaStaticTargetIsStatic(t) {
+			static = append(static, t)
+		}
+	}
+	if len(static) > 0 {
+		if err := removeHostInternetScsiStaticTargets(ss, hba.Device, static); err != nil {
+			return fmt.Errorf("error removing static targets: %s", err)
+		}
+	}
+	if len(hba.ConfiguredSendTarget) > 0 {
+		if err := removeHostInternetScsiSendTargets(ss, hba.Device, hba.ConfiguredSendTarget); err != nil {
+			return fmt.Errorf("error removing send targets: %s", err)
+		}
+	}
+
+	im, err := hostIscsiManagerFromHostSystemID(client, d.Id())
+	if err != nil {
+		return err
+	}
+	vnics, err := im.BoundVnics(hba.Device)
+	if err != nil {
+		return fmt.Errorf("error querying bound VMkernel adapters: %s", err)
+	}
+	for _, vnic := range vnics {
+		if err := im.UnbindVnic(hba.Device, vnic); err != nil {
+			return fmt.Errorf("error unbinding VMkernel adapter %q: %s", vnic, err)
+		}
+	}
+
+	if d.Get("rescan").(bool) {
+		if err := rescanHostStorage(ss); err != nil {
+			return err
+		}
+	}
+
+	if err := updateHostSoftwareInternetScsiEnabled(ss, false); err != nil {
+		return fmt.Errorf("error disabling software iSCSI: %s", err)
+	}
+
+	log.Printf("[DEBUG] %s: Deleted successfully", resourceVSphereHostIscsiAdapterIDString(d))
+	return nil
+}
+
+func resourceVSphereHostIscsiAdapterImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client := meta.(*VSphereClient).vimClient
+	ss, err := hostStorageSystemFromHostSystemID(client, d.Id())
+	if err != nil {
+		return nil, fmt.Errorf("error loading host storage system: %s", err)
+	}
+	hba, err := hostSoftwareInternetScsiHba(ss)
+	if err != nil {
+		return nil, err
+	}
+	if hba == nil {
+		return nil, fmt.Errorf("software iSCSI is not enabled on host %q", d.Id())
+	}
+	// rescan is not stored on the host, so set the default here to prevent a
+	// diff after import.
+	d.Set("rescan", true)
+	return []*schema.ResourceData{d}, nil
+}
+
+// resourceVSphereHostIscsiAdapterEnable enables software iSCSI on a host, if
+// it is not enabled already, and waits for the adapter to show up.
+func resourceVSphereHostIscsiAdapterEnable(ss *object.HostStorageSystem) (*types.HostInternetScsiHba, error) {
+	hba, err := hostSoftwareInternetScsiHba(ss)
+	if err != nil {
+		return nil, err
+	}
+	if hba != nil {
+		return hba, nil
+	}
+
+	log.Printf("[DEBUG] Enabling software iSCSI on host storage system %q", ss.Reference().Value)
+	if err := updateHostSoftwareInternetScsiEnabled(ss, true); err != nil {
+		return nil, fmt.Errorf("error enabling software iSCSI: %s", err)
+	}
+
+	err = resource.Retry(time.Minute, func() *resource.RetryError {
+		var rerr error
+		hba, rerr = hostSoftwareInternetScsiHba(ss)
+		if rerr != nil {
+			return resource.NonRetryableError(rerr)
+		}
+		if hba == nil {
+			return resource.RetryableError(errors.New("software iSCSI adapter not present yet"))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error waiting for software iSCSI adapter: %s", err)
+	}
+	return hba, nil
+}
+
+// resourceVSphereHostIscsiAdapterApply reconciles the authentication
+// settings, port bindings, and targets of an adapter with the configuration,
+// and rescans the host's storage if anything that can affect the visible
+// devices has changed.
+func resourceVSphereHostIscsiAdapterApply(
+	d *schema.ResourceData,
+	meta interface{},
+	ss *object.HostStorageSystem,
+	hba *types.HostInternetScsiHba,
+) error {
+	client := meta.(*VSphereClient).vimClient
+	device := hba.Device
+
+	if d.IsNewResource() || d.HasChange("chap_authentication_type") || d.HasChange("chap_name") ||
+		d.HasChange("chap_secret") || d.HasChange("mutual_chap_authentication_type") ||
+		d.HasChange("mutual_chap_name") || d.HasChange("mutual_chap_secret") {
+		log.Printf("[DEBUG] %s: Updating CHAP settings", resourceVSphereHostIscsiAdapterIDString(d))
+		if err := updateHostInternetScsiAuthenticationProperties(ss, device, expandHostInternetScsiHbaAuthenticationProperties(d)); err != nil {
+			return fmt.Errorf("error updating CHAP settings: %s", err)
+		}
+	}
+
+	var rescan bool
+
+	if d.HasChange("bound_vmknics") {
+		im, err := hostIscsiManagerFromHostSystemID(client, d.Id())
+		if err != nil {
+			return err
+		}
+		o, n := d.GetChange("bound_vmknics")
+		for _, vnic := range o.(*schema.Set).Difference(n.(*schema.Set)).List() {
+			log.Printf("[DEBUG] %s: Unbinding VMkernel adapter %q", resourceVSphereHostIscsiAdapterIDString(d), vnic)
+			if err := im.UnbindVnic(device, vnic.(string)); err != nil {
+				return fmt.Errorf("error unbinding VMkernel adapter %q: %s", vnic, err)
+			}
+		}
+		for _, vnic := range n.(*schema.Set).Difference(o.(*schema.Set)).List() {
+			log.Printf("[DEBUG] %s: Binding VMkernel adapter %q", resourceVSphereHostIscsiAdapterIDString(d), vnic)
+			if err := im.BindVnic(device, vnic.(string)); err != nil {
+				return fmt.Errorf("error binding VMkernel adapter %q: %s", vnic, err)
+			}
+		}
+		rescan = true
+	}
+
+	if d.HasChange("static_target") {
+		o, n := d.GetChange("static_target")
+		if remove := expandHostInternetScsiHbaStaticTargets(o.(*schema.Set).Difference(n.(*schema.Set))); len(remove) > 0 {
+			if err := removeHostInternetScsiStaticTargets(ss, device, remove); err != nil {
+				return fmt.Errorf("error removing static targets: %s", err)
+			}
+		}
+		if add := expandHostInternetScsiHbaStaticTargets(n.(*schema.Set).Difference(o.(*schema.Set))); len(add) > 0 {
+			if err := addHostInternetScsiStaticTargets(ss, device, add); err != nil {
+				return fmt.Errorf("error adding static targets: %s", err)
+			}
+		}
+		rescan = true
+	}
+
+	if d.HasChange("send_target") {
+		o, n := d.GetChange("send_target")
+		if remove := expandHostInternetScsiHbaSendTargets(o.(*schema.Set).Difference(n.(*schema.Set))); len(remove) > 0 {
+			if err := removeHostInternetScsiSendTargets(ss, device, remove); err != nil {
+				return fmt.Errorf("error removing send targets: %s", err)
+			}
+		}
+		if add := expandHostInternetScsiHbaSendTargets(n.(*schema.Set).Difference(o.(*schema.Set))); len(add) > 0 {
+			if err := addHostInternetScsiSendTargets(ss, device, add); err != nil {
+				return fmt.Errorf("error adding send targets: %s", err)
+			}
+		}
+		rescan = true
+	}
+
+	if rescan && d.Get("rescan").(bool) {
+		log.Printf("[DEBUG] %s: Rescanning host storage", resourceVSphereHostIscsiAdapterIDString(d))
+		if err := rescanHostStorage(ss); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// expandHostInternetScsiHbaAuthenticationProperties reads certain
+// ResourceData keys and returns a HostInternetScsiHbaAuthenticationProperties.
+func expandHostInternetScsiHbaAuthenticationProperties(d *schema.ResourceData) types.HostInternetScsiHbaAuthenticationProperties {
+	chapType := d.Get("chap_authentication_type").(string)
+	return types.HostInternetScsiHbaAuthenticationProperties{
+		ChapAuthEnabled:              chapType != string(types.HostInternetScsiHbaChapAuthenticationTypeChapProhibited),
+		ChapAuthenticationType:       chapType,
+		ChapName:                     d.Get("chap_name").(string),
+		ChapSecret:                   d.Get("chap_secret").(string),
+		MutualChapAuthenticationType: d.Get("mutual_chap_authentication_type").(string),
+		MutualChapName:               d.Get("mutual_chap_name").(string),
+		MutualChapSecret:             d.Get("mutual_chap_secret").(string),
+	}
+}
+
+// flattenHostInternetScsiHbaAuthenticationProperties reads various fields
+// from a HostInternetScsiHbaAuthenticationProperties into the passed in
+// ResourceData. Secrets are not returned by the API and are left untouched.
+func flattenHostInternetScsiHbaAuthenticationProperties(d *schema.ResourceData, obj *types.HostInternetScsiHbaAuthenticationProperties) error {
+	chapType := obj.ChapAuthenticationType
+	if chapType == "" {
+		chapType = string(types.HostInternetScsiHbaChapAuthenticationTypeChapProhibited)
+	}
+	mutualChapType := obj.MutualChapAuthenticationType
+	if mutualChapType == "" {
+		mutualChapType = string(types.HostInternetScsiHbaChapAuthenticationTypeChapProhibited)
+	}
+	return structure.SetBatch(d, map[string]interface{}{
+		"chap_authentication_type":        chapType,
+		"chap_name":                       obj.ChapName,
+		"mutual_chap_authentication_type": mutualChapType,
+		"mutual_chap_name":                obj.MutualChapName,
+	})
+}
+
+// expandHostInternetScsiHbaSendTargets converts a set of send_target entries
+// into a list of HostInternetScsiHbaSendTarget.
+func expandHostInternetScsiHbaSendTargets(s *schema.Set) []types.HostInternetScsiHbaSendTarget {
+	var targets []types.HostInternetScsiHbaSendTarget
+	for _, v := range s.List() {
+		m := v.(map[string]interface{})
+		targets = append(targets, types.HostInternetScsiHbaSendTarget{
+			Address: m["address"].(string),
+			Port:    int32(m["port"].(int)),
+		})
+	}
+	return targets
+}
+
+// flattenHostInternetScsiHbaSendTargets converts a list of
+// HostInternetScsiHbaSendTarget into send_target entries.
+func flattenHostInternetScsiHbaSendTargets(targets []types.HostInternetScsiHbaSendTarget) []interface{} {
+	var s []interface{}
+	for _, t := range targets {
+		port := t.Port
+		if port == 0 {
+			port = hostInternetScsiDefaultPort
+		}
+		s = append(s, map[string]interface{}{
+			"address": t.Address,
+			"port":    port,
+		})
+	}
+	return s
+}
+
+// expandHostInternetScsiHbaStaticTargets converts a set of static_target
+// entries into a list of HostInternetScsiHbaStaticTarget.
+func expandHostInternetScsiHbaStaticTargets(s *schema.Set) []types.HostInternetScsiHbaStaticTarget {
+	var targets []types.HostInternetScsiHbaStaticTarget
+	for _, v := range s.List() {
+		m := v.(map[string]interface{})
+		targets = append(targets, types.HostInternetScsiHbaStaticTarget{
+			Address:   m["address"].(string),
+			Port:      int32(m["port"].(int)),
+			IScsiName: m["iqn"].(string),
+		})
+	}
+	return targets
+}
+
+// flattenHostInternetScsiHbaStaticTargets converts a list of
+// HostInternetScsiHbaStaticTarget into static_target entries. Targets that
+// were discovered through send targets are skipped, as they are not managed
+// directly.
+func flattenHostInternetScsiHbaStaticTargets(targets []types.HostInternetScsiHbaStaticTarget) []interface{} {
+	var s []interface{}
+	for _, t := range targets {
+		if !hostInternetScsiHbaStaticTargetIsStatic(t) {
+			continue
+		}
+		port := t.Port
+		if port == 0 {
+			port = hostInternetScsiDefaultPort
+		}
+		s = append(s, map[string]interface{}{
+			"address": t.Address,
+			"port":    port,
+			"iqn":     t.IScsiName,
+		})
+	}
+	return s
+}
+
+// hostInternetScsiHbaStaticTargetIsStatic returns true if the static target
+// was configured directly, rather than discovered through a send target.
+func hostInternetScsiHbaStaticTargetIsStatic(t types.HostInternetScsiHbaStaticTarget) bool {
+	return t.DiscoveryMethod == "" || t.DiscoveryMethod == string(types.HostInternetScsiHbaStaticTargetTargetDiscoveryMethodStaticMethod)
+}
+
+// resourceVSphereHostIscsiAdapterIDString prints a friendly string for the
+// vsphere_host_iscsi_adapter resource.
+func resourceVSphereHostIscsiAdapterIDString(d structure.ResourceIDStringer) string {
+	return structure.ResourceIDString(d, resourceVSphereHostIscsiAdapterName)
+}
@@ -0,0 +1,168 @@
+package vsphere
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/hostsystem"
+)
+
+func TestAccResourceVSphereHostIscsiAdapter_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccResourceVSphereHostIscsiAdapterPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccResourceVSphereHostIscsiAdapterExists(false),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceVSphereHostIscsiAdapterConfigBasic(),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereHostIscsiAdapterExists(true),
+					resource.TestMatchResourceAttr("vsphere_host_iscsi_adapter.iscsi", "device", regexp.MustCompile("^vmhba[0-9]+$")),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceVSphereHostIscsiAdapter_targets(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccResourceVSphereHostIscsiAdapterPreCheck(t)
+			testAccResourceVSphereHostIscsiAdapterTargetPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccResourceVSphereHostIscsiAdapterExists(false),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceVSphereHostIscsiAdapterConfigBasic(),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereHostIscsiAdapterExists(true),
+					resource.TestCheckResourceAttr("vsphere_host_iscsi_adapter.iscsi", "send_target.#", "0"),
+				),
+			},
+			{
+				Config: testAccResourceVSphereHostIscsiAdapterConfigSendTarget(),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereHostIscsiAdapterExists(true),
+					resource.TestCheckResourceAttr("vsphere_host_iscsi_adapter.iscsi", "send_target.#", "1"),
+					resource.TestCheckResourceAttr("vsphere_host_iscsi_adapter.iscsi", "static_target.#", "1"),
+				),
+			},
+			{
+				ResourceName:            "vsphere_host_iscsi_adapter.iscsi",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"chap_secret", "mutual_chap_secret"},
+			},
+		},
+	})
+}
+
+func testAccResourceVSphereHostIscsiAdapterPreCheck(t *testing.T) {
+	if os.Getenv("VSPHERE_DATACENTER") == "" {
+		t.Skip("set VSPHERE_DATACENTER to run vsphere_host_iscsi_adapter acceptance tests")
+	}
+	if os.Getenv("VSPHERE_ESXI_HOST") == "" {
+		t.Skip("set VSPHERE_ESXI_HOST to run vsphere_host_iscsi_adapter acceptance tests")
+	}
+}
+
+func testAccResourceVSphereHostIscsiAdapterTargetPreCheck(t *testing.T) {
+	if os.Getenv("VSPHERE_ISCSI_TARGET_ADDRESS") == "" {
+		t.Skip("set VSPHERE_ISCSI_TARGET_ADDRESS to run vsphere_host_iscsi_adapter target acceptance tests")
+	}
+	if os.Getenv("VSPHERE_ISCSI_TARGET_IQN") == "" {
+		t.Skip("set VSPHERE_ISCSI_TARGET_IQN to run vsphere_host_iscsi_adapter target acceptance tests")
+	}
+}
+
+func testAccResourceVSphereHostIscsiAdapterExists(expected bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*VSphereClient).vimClient
+		dc, err := getDatacenter(client, os.Getenv("VSPHERE_DATACENTER"))
+		if err != nil {
+			return err
+		}
+		hs, err := hostsystem.SystemOrDefault(client, os.Getenv("VSPHERE_ESXI_HOST"), dc)
+		if err != nil {
+			return err
+		}
+		ss, err := hostStorageSystemFromHostSystemID(client, hs.Reference().Value)
+		if err != nil {
+			return err
+		}
+		hba, err := hostSoftwareInternetScsiHba(ss)
+		if err != nil {
+			return err
+		}
+		switch {
+		case hba == nil && expected:
+			return errors.New("expected software iSCSI adapter to be present")
+		case hba != nil && !expected:
+			return fmt.Errorf("expected software iSCSI adapter %q to be disabled", hba.Device)
+		}
+		return nil
+	}
+}
+
+func testAccResourceVSphereHostIscsiAdapterConfigBasic() string {
+	return fmt.Sprintf(`
+data "vsphere_datacenter" "datacenter" {
+  name = "%s"
+}
+
+data "vsphere_host" "esxi_host" {
+  name          = "%s"
+  datacenter_id = "${data.vsphere_datacenter.datacenter.id}"
+}
+
+resource "vsphere_host_iscsi_adapter" "iscsi" {
+  host_system_id = "${data.vsphere_host.esxi_host.id}"
+}
+`, os.Getenv("VSPHERE_DATACENTER"), os.Getenv("VSPHERE_ESXI_HOST"))
+}
+
+func testAccResourceVSphereHostIscsiAdapterConfigSendTarget() string {
+	return fmt.Sprintf(`
+data "vsphere_datacenter" "datacenter" {
+  name = "%s"
+}
+
+data "vsphere_host" "esxi_host" {
+  name          = "%s"
+  datacenter_id = "${data.vsphere_datacenter.datacenter.id}"
+}
+
+resource "vsphere_host_iscsi_adapter" "iscsi" {
+  host_system_id = "${data.vsphere_host.esxi_host.id}"
+
+  send_target {
+    address = "%s"
+  }
+
+  static_target {
+    address = "%s"
+    iqn     = "%s"
+  }
+}
+
+data "vsphere_vmfs_disks" "available" {
+  host_system_id = "${vsphere_host_iscsi_adapter.iscsi.id}"
+}
+`,
+		os.Getenv("VSPHERE_DATACENTER"),
+		os.Getenv("VSPHERE_ESXI_HOST"),
+		os.Getenv("VSPHERE_ISCSI_TARGET_ADDRESS"),
+		os.Getenv("VSPHERE_ISCSI_TARGET_ADDRESS"),
+		os.Getenv("VSPHERE_ISCSI_TARGET_IQN"),
+	)
+}
@@ -0,0 +1,241 @@
+package vsphere
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/structure"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/viapi"
+	"github.com/vmware/govmomi"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+const resourceVSphereHostLocalUserName = "vsphere_host_local_user"
+
+func resourceVSphereHostLocalUser() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceVSphereHostLocalUserCreate,
+		Read:   resourceVSphereHostLocalUserRead,
+		Update: resourceVSphereHostLocalUserUpdate,
+		Delete: resourceVSphereHostLocalUserDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceVSphereHostLocalUserImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The login name of the local user.",
+			},
+			"password": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Sensitive:   true,
+				Description: "The password of the local user.",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The description of the local user.",
+			},
+			"role": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The name of the role to assign to the user on the root folder of the host, such as Admin or ReadOnly.",
+			},
+			"propagate": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether or not the role assignment propagates to child objects of the root folder.",
+			},
+		},
+	}
+}
+
+func resourceVSphereHostLocalUserCreate(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] %s: Beginning create", resourceVSphereHostLocalUserIDString(d))
+	client := meta.(*VSphereClient).vimClient
+	if err := validateHostLocalUserClient(client); err != nil {
+		return err
+	}
+	name := d.Get("name").(string)
+	if err := createHostLocalUser(client, expandHostAccountSpec(d)); err != nil {
+		return fmt.Errorf("error creating local user %q: %s", name, err)
+	}
+	d.SetId(name)
+	if err := resourceVSphereHostLocalUserApplyRole(d, client); err != nil {
+		return err
+	}
+	log.Printf("[DEBUG] %s: Create finished successfully", resourceVSphereHostLocalUserIDString(d))
+	return resourceVSphereHostLocalUserRead(d, meta)
+}
+
+func resourceVSphereHostLocalUserRead(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] %s: Beginning read", resourceVSphereHostLocalUserIDString(d))
+	client := meta.(*VSphereClient).vimClient
+	if err := validateHostLocalUserClient(client); err != nil {
+		return err
+	}
+	user, err := hostLocalUserFromName(client, d.Id())
+	if err != nil {
+		return fmt.Errorf("error looking up local user %q: %s", d.Id(), err)
+	}
+	if user == nil {
+		log.Printf("[DEBUG] %s: User not found, marking resource as gone", resourceVSphereHostLocalUserIDString(d))
+		d.SetId("")
+		return nil
+	}
+
+	var role string
+	propagate := d.Get("propagate").(bool)
+	perm, err := entityPermissionForPrincipal(client, client.ServiceContent.RootFolder, user.Principal, false)
+	if err != nil {
+		return fmt.Errorf("error reading permissions for local user %q: %s", d.Id(), err)
+	}
+	if perm != nil {
+		r, err := authorizationRoleFromID(client, perm.RoleId)
+		if err != nil {
+			return err
+		}
+		role = r.Name
+		propagate = perm.Propagate
+	}
+
+	if err := structure.SetBatch(d, map[string]interface{}{
+		"name":        user.Principal,
+		"description": user.FullName,
+		"role":        role,
+		"propagate":   propagate,
+	}); err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] %s: Read completed successfully", resourceVSphereHostLocalUserIDString(d))
+	return nil
+}
+
+func resourceVSphereHostLocalUserUpdate(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] %s: Beginning update", resourceVSphereHostLocalUserIDString(d))
+	client := meta.(*VSphereClient).vimClient
+	if err := validateHostLocalUserClient(client); err != nil {
+		return err
+	}
+	if d.HasChange("password") || d.HasChange("description") {
+		if err := updateHostLocalUser(client, expandHostAccountSpec(d)); err != nil {
+			return fmt.Errorf("error updating local user %q: %s", d.Id(), err)
+		}
+	}
+	if d.HasChange("role") || d.HasChange("propagate") {
+		if err := resourceVSphereHostLocalUserApplyRole(d, client); err != nil {
+			return err
+		}
+	}
+	log.Printf("[DEBUG] %s: Update finished successfully", resourceVSphereHostLocalUserIDString(d))
+	return resourceVSphereHostLocalUserRead(d, meta)
+}
+
+func resourceVSphereHostLocalUserDelete(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] %s: Beginning delete", resourceVSphereHostLocalUserIDString(d))
+	client := meta.(*VSphereClient).vimClient
+	if err := validateHostLocalUserClient(client); err != nil {
+		return err
+	}
+	perm, err := entityPermissionForPrincipal(client, client.ServiceContent.RootFolder, d.Id(), false)
+	if err != nil {
+		return fmt.Errorf("error reading permissions for local user %q: %s", d.Id(), err)
+	}
+	if perm != nil {
+		if err := removeEntityPermission(client, client.ServiceContent.RootFolder, d.Id(), false); err != nil {
+			return fmt.Errorf("error removing permission for local user %q: %s", d.Id(), err)
+		}
+	}
+	if err := removeHostLocalUser(client, d.Id()); err != nil {
+		return fmt.Errorf("error removing local user %q: %s", d.Id(), err)
+	}
+	log.Printf("[DEBUG] %s: Delete finished successfully", resourceVSphereHostLocalUserIDString(d))
+	return nil
+}
+
+func resourceVSphereHostLocalUserImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client := meta.(*VSphereClient).vimClient
+	if err := validateHostLocalUserClient(client); err != nil {
+		return nil, err
+	}
+	user, err := hostLocalUserFromName(client, d.Id())
+	if err != nil {
+		return nil, fmt.Errorf("error looking up local user %q: %s", d.Id(), err)
+	}
+	if user == nil {
+		return nil, fmt.Errorf("local user %q not found", d.Id())
+	}
+	d.Set("propagate", true)
+	return []*schema.ResourceData{d}, nil
+}
+
+// resourceVSphereHostLocalUserApplyRole assigns the configured role to the
+// user on the root folder of the host, or removes the existing assignment if
+// no role is configured.
+func resourceVSphereHostLocalUserApplyRole(d *schema.ResourceData, client *govmomi.Client) error {
+	root := client.ServiceContent.RootFolder
+	name := d.Get("role").(string)
+	if name == "" {
+		perm, err := entityPermissionForPrincipal(client, root, d.Id(), false)
+		if err != nil {
+			return fmt.Errorf("error reading permissions for local user %q: %s", d.Id(), err)
+		}
+		if perm == nil {
+			return nil
+		}
+		log.Printf("[DEBUG] %s: Removing role assignment from root folder", resourceVSphereHostLocalUserIDString(d))
+		if err := removeEntityPermission(client, root, d.Id(), false); err != nil {
+			return fmt.Errorf("error removing permission for local user %q: %s", d.Id(), err)
+		}
+		return nil
+	}
+
+	role, err := authorizationRoleFromName(client, name)
+	if err != nil {
+		return err
+	}
+	log.Printf("[DEBUG] %s: Assigning role %q on root folder", resourceVSphereHostLocalUserIDString(d), name)
+	perm := types.Permission{
+		Principal: d.Id(),
+		Group:     false,
+		RoleId:    role.RoleId,
+		Propagate: d.Get("propagate").(bool),
+	}
+	if err := setEntityPermission(client, root, perm); err != nil {
+		return fmt.Errorf("error assigning role %q to local user %q: %s", name, d.Id(), err)
+	}
+	return nil
+}
+
+// validateHostLocalUserClient ensures that the provider is connected directly
+// to an ESXi host. Local accounts on hosts managed by vCenter should be
+// managed through the host itself.
+func validateHostLocalUserClient(client *govmomi.Client) error {
+	if err := viapi.ValidateVirtualCenter(client); err == nil {
+		return fmt.Errorf("%s can only be used when connected directly to an ESXi host", resourceVSphereHostLocalUserName)
+	}
+	return nil
+}
+
+// expandHostAccountSpec reads certain ResourceData keys and returns a
+// HostAccountSpec.
+func expandHostAccountSpec(d *schema.ResourceData) *types.HostAccountSpec {
+	return &types.HostAccountSpec{
+		Id:          d.Get("name").(string),
+		Password:    d.Get("password").(string),
+		Description: d.Get("description").(string),
+	}
+}
+
+// resourceVSphereHostLocalUserIDString prints a friendly string for the
+// vsphere_host_local_user resource.
+func resourceVSphereHostLocalUserIDString(d structure.ResourceIDStringer) string {
+	return structure.ResourceIDString(d, resourceVSphereHostLocalUserName)
+}
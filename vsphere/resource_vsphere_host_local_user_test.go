@@ -0,0 +1,135 @@
+package vsphere
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+const testAccResourceVSphereHostLocalUserName = "terraform-test-user"
+
+func TestAccResourceVSphereHostLocalUser_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccSkipIfNotEsxi(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccResourceVSphereHostLocalUserExists(false),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceVSphereHostLocalUserConfig("Terraform test user", ""),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereHostLocalUserExists(true),
+					testAccResourceVSphereHostLocalUserHasRole(""),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceVSphereHostLocalUser_role(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccSkipIfNotEsxi(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccResourceVSphereHostLocalUserExists(false),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceVSphereHostLocalUserConfig("Terraform test user", "ReadOnly"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereHostLocalUserExists(true),
+					testAccResourceVSphereHostLocalUserHasRole("ReadOnly"),
+				),
+			},
+			{
+				Config: testAccResourceVSphereHostLocalUserConfig("Terraform test user (updated)", "Admin"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereHostLocalUserHasRole("Admin"),
+					resource.TestCheckResourceAttr("vsphere_host_local_user.user", "description", "Terraform test user (updated)"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceVSphereHostLocalUser_import(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccSkipIfNotEsxi(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccResourceVSphereHostLocalUserExists(false),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceVSphereHostLocalUserConfig("Terraform test user", "ReadOnly"),
+			},
+			{
+				ResourceName:            "vsphere_host_local_user.user",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"password"},
+			},
+		},
+	})
+}
+
+func testAccResourceVSphereHostLocalUserExists(expected bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*VSphereClient).vimClient
+		user, err := hostLocalUserFromName(client, testAccResourceVSphereHostLocalUserName)
+		if err != nil {
+			return err
+		}
+		switch {
+		case user == nil && expected:
+			return errors.New("local user not found")
+		case user != nil && !expected:
+			return fmt.Errorf("local user %q still exists", user.Principal)
+		}
+		return nil
+	}
+}
+
+func testAccResourceVSphereHostLocalUserHasRole(expected string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*VSphereClient).vimClient
+		perm, err := entityPermissionForPrincipal(client, client.ServiceContent.RootFolder, testAccResourceVSphereHostLocalUserName, false)
+		if err != nil {
+			return err
+		}
+		var actual string
+		if perm != nil {
+			role, err := authorizationRoleFromID(client, perm.RoleId)
+			if err != nil {
+				return err
+			}
+			actual = role.Name
+		}
+		if actual != expected {
+			return fmt.Errorf("expected role to be %q, got %q", expected, actual)
+		}
+		return nil
+	}
+}
+
+func testAccResourceVSphereHostLocalUserConfig(description, role string) string {
+	return fmt.Sprintf(`
+resource "vsphere_host_local_user" "user" {
+  name        = "%s"
+  password    = "Terraform-Test-1!"
+  description = "%s"
+  role        = "%s"
+}
+`,
+		testAccResourceVSphereHostLocalUserName,
+		description,
+		role,
+	)
+}
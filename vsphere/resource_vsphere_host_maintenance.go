@@ -0,0 +1,205 @@
+package vsphere
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/hostsystem"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/structure"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/viapi"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+const resourceVSphereHostMaintenanceName = "vsphere_host_maintenance"
+
+var hostMaintenanceVsanDataMigrationModeAllowedValues = []string{
+	string(types.VsanHostDecommissionModeObjectActionNoAction),
+	string(types.VsanHostDecommissionModeObjectActionEnsureObjectAccessibility),
+	string(types.VsanHostDecommissionModeObjectActionEvacuateAllData),
+}
+
+func resourceVSphereHostMaintenance() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceVSphereHostMaintenanceCreate,
+		Read:   resourceVSphereHostMaintenanceRead,
+		Update: resourceVSphereHostMaintenanceUpdate,
+		Delete: resourceVSphereHostMaintenanceDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceVSphereHostMaintenanceImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"host_system_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The managed object ID of the host to manage maintenance mode for.",
+			},
+			"maintenance_mode": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "The desired maintenance mode state of the host.",
+			},
+			"evacuate": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Evacuate powered off and suspended virtual machines when entering maintenance mode. Only applicable when connected to vCenter.",
+			},
+			"vsan_data_migration_mode": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "The vSAN data migration mode to use when entering maintenance mode. Can be one of noAction, ensureObjectAccessibility, or evacuateAllData.",
+				ValidateFunc: validation.StringInSlice(hostMaintenanceVsanDataMigrationModeAllowedValues, false),
+			},
+			"timeout": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      600,
+				Description:  "The timeout, in seconds, for each maintenance mode operation.",
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"exit_on_destroy": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Take the host out of maintenance mode when this resource is destroyed.",
+			},
+		},
+	}
+}
+
+func resourceVSphereHostMaintenanceCreate(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] %s: Beginning create", resourceVSphereHostMaintenanceIDString(d))
+	hsID := d.Get("host_system_id").(string)
+	if err := resourceVSphereHostMaintenanceApply(d, meta, hsID); err != nil {
+		return err
+	}
+	d.SetId(hsID)
+	log.Printf("[DEBUG] %s: Create finished successfully", resourceVSphereHostMaintenanceIDString(d))
+	return resourceVSphereHostMaintenanceRead(d, meta)
+}
+
+func resourceVSphereHostMaintenanceRead(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] %s: Beginning read", resourceVSphereHostMaintenanceIDString(d))
+	client := meta.(*VSphereClient).vimClient
+	hs, err := hostsystem.FromID(client, d.Id())
+	if err != nil {
+		if viapi.IsManagedObjectNotFoundError(err) {
+			log.Printf("[DEBUG] %s: Host not found, marking resource as gone", resourceVSphereHostMaintenanceIDString(d))
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("error loading host: %s", err)
+	}
+	inMaintenance, err := hostsystem.InMaintenanceMode(hs)
+	if err != nil {
+		return fmt.Errorf("error reading maintenance mode state for host %q: %s", hs.Name(), err)
+	}
+
+	if err := structure.SetBatch(d, map[string]interface{}{
+		"host_system_id":   d.Id(),
+		"maintenance_mode": inMaintenance,
+	}); err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] %s: Read completed successfully", resourceVSphereHostMaintenanceIDString(d))
+	return nil
+}
+
+func resourceVSphereHostMaintenanceUpdate(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] %s: Beginning update", resourceVSphereHostMaintenanceIDString(d))
+	if d.HasChange("maintenance_mode") {
+		if err := resourceVSphereHostMaintenanceApply(d, meta, d.Id()); err != nil {
+			return err
+		}
+	}
+	log.Printf("[DEBUG] %s: Update finished successfully", resourceVSphereHostMaintenanceIDString(d))
+	return resourceVSphereHostMaintenanceRead(d, meta)
+}
+
+func resourceVSphereHostMaintenanceDelete(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] %s: Beginning delete", resourceVSphereHostMaintenanceIDString(d))
+	if !d.Get("exit_on_destroy").(bool) {
+		log.Printf("[DEBUG] %s: Removing from state, maintenance mode is left as-is on the host", resourceVSphereHostMaintenanceIDString(d))
+		return nil
+	}
+	client := meta.(*VSphereClient).vimClient
+	hs, err := hostsystem.FromID(client, d.Id())
+	if err != nil {
+		return fmt.Errorf("error loading host: %s", err)
+	}
+	inMaintenance, err := hostsystem.InMaintenanceMode(hs)
+	if err != nil {
+		return fmt.Errorf("error reading maintenance mode state for host %q: %s", hs.Name(), err)
+	}
+	if inMaintenance {
+		if err := hostsystem.ExitMaintenanceMode(hs, d.Get("timeout").(int)); err != nil {
+			return fmt.Errorf("error taking host %q out of maintenance mode: %s", hs.Name(), err)
+		}
+	}
+	log.Printf("[DEBUG] %s: Delete finished successfully", resourceVSphereHostMaintenanceIDString(d))
+	return nil
+}
+
+func resourceVSphereHostMaintenanceImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client := meta.(*VSphereClient).vimClient
+	if _, err := hostsystem.FromID(client, d.Id()); err != nil {
+		return nil, fmt.Errorf("error loading host: %s", err)
+	}
+	d.Set("host_system_id", d.Id())
+	return []*schema.ResourceData{d}, nil
+}
+
+// resourceVSphereHostMaintenanceApply moves the host into or out of
+// maintenance mode, depending on the desired state in the ResourceData.
+func resourceVSphereHostMaintenanceApply(d *schema.ResourceData, meta interface{}, hsID string) error {
+	client := meta.(*VSphereClient).vimClient
+	hs, err := hostsystem.FromID(client, hsID)
+	if err != nil {
+		return fmt.Errorf("error loading host: %s", err)
+	}
+	inMaintenance, err := hostsystem.InMaintenanceMode(hs)
+	if err != nil {
+		return fmt.Errorf("error reading maintenance mode state for host %q: %s", hs.Name(), err)
+	}
+	want := d.Get("maintenance_mode").(bool)
+	timeout := d.Get("timeout").(int)
+	switch {
+	case want && !inMaintenance:
+		if err := hostsystem.EnterMaintenanceModeWithSpec(hs, timeout, d.Get("evacuate").(bool), expandHostMaintenanceSpec(d)); err != nil {
+			return fmt.Errorf("error putting host %q into maintenance mode: %s", hs.Name(), err)
+		}
+	case !want && inMaintenance:
+		if err := hostsystem.ExitMaintenanceMode(hs, timeout); err != nil {
+			return fmt.Errorf("error taking host %q out of maintenance mode: %s", hs.Name(), err)
+		}
+	default:
+		log.Printf("[DEBUG] %s: Host %q already in desired maintenance mode state (%t)", resourceVSphereHostMaintenanceIDString(d), hs.Name(), want)
+	}
+	return nil
+}
+
+// expandHostMaintenanceSpec reads certain ResourceData keys and returns a
+// HostMaintenanceSpec, or nil if no vSAN data migration mode was specified.
+func expandHostMaintenanceSpec(d *schema.ResourceData) *types.HostMaintenanceSpec {
+	mode := d.Get("vsan_data_migration_mode").(string)
+	if mode == "" {
+		return nil
+	}
+	return &types.HostMaintenanceSpec{
+		VsanMode: &types.VsanHostDecommissionMode{
+			ObjectAction: mode,
+		},
+	}
+}
+
+// resourceVSphereHostMaintenanceIDString prints a friendly string for the
+// vsphere_host_maintenance resource.
+func resourceVSphereHostMaintenanceIDString(d structure.ResourceIDStringer) string {
+	return structure.ResourceIDString(d, resourceVSphereHostMaintenanceName)
+}
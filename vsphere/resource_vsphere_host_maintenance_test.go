@@ -0,0 +1,159 @@
+package vsphere
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/hostsystem"
+)
+
+func TestAccResourceVSphereHostMaintenance_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccResourceVSphereHostMaintenancePreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccResourceVSphereHostMaintenanceExitedCheck(),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceVSphereHostMaintenanceConfig(true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereHostMaintenanceMatch(true),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceVSphereHostMaintenance_toggle(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccResourceVSphereHostMaintenancePreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccResourceVSphereHostMaintenanceExitedCheck(),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceVSphereHostMaintenanceConfig(true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereHostMaintenanceMatch(true),
+				),
+			},
+			{
+				Config: testAccResourceVSphereHostMaintenanceConfig(false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereHostMaintenanceMatch(false),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceVSphereHostMaintenance_import(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccResourceVSphereHostMaintenancePreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccResourceVSphereHostMaintenanceExitedCheck(),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceVSphereHostMaintenanceConfig(true),
+			},
+			{
+				ResourceName:      "vsphere_host_maintenance.maintenance",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"evacuate",
+					"timeout",
+					"exit_on_destroy",
+				},
+			},
+		},
+	})
+}
+
+func testAccResourceVSphereHostMaintenancePreCheck(t *testing.T) {
+	if os.Getenv("VSPHERE_DATACENTER") == "" {
+		t.Skip("set VSPHERE_DATACENTER to run vsphere_host_maintenance acceptance tests")
+	}
+	if os.Getenv("VSPHERE_ESXI_HOST") == "" {
+		t.Skip("set VSPHERE_ESXI_HOST to run vsphere_host_maintenance acceptance tests")
+	}
+}
+
+func testAccResourceVSphereHostMaintenanceMatch(expected bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		vars, err := testClientVariablesForResource(s, "vsphere_host_maintenance.maintenance")
+		if err != nil {
+			return err
+		}
+		hs, err := hostsystem.FromID(vars.client, vars.resourceID)
+		if err != nil {
+			return err
+		}
+		actual, err := hostsystem.InMaintenanceMode(hs)
+		if err != nil {
+			return err
+		}
+		if actual != expected {
+			return fmt.Errorf("expected maintenance mode to be %t, got %t", expected, actual)
+		}
+		return nil
+	}
+}
+
+// testAccResourceVSphereHostMaintenanceExitedCheck checks that the host has
+// been taken out of maintenance mode on destroy. The resource is not present
+// in state at this point, so the host is looked up from the environment
+// instead.
+func testAccResourceVSphereHostMaintenanceExitedCheck() resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*VSphereClient).vimClient
+		dc, err := getDatacenter(client, os.Getenv("VSPHERE_DATACENTER"))
+		if err != nil {
+			return err
+		}
+		hs, err := hostsystem.SystemOrDefault(client, os.Getenv("VSPHERE_ESXI_HOST"), dc)
+		if err != nil {
+			return err
+		}
+		actual, err := hostsystem.InMaintenanceMode(hs)
+		if err != nil {
+			return err
+		}
+		if actual {
+			return fmt.Errorf("expected host %q to be out of maintenance mode after destroy", hs.Name())
+		}
+		return nil
+	}
+}
+
+func testAccResourceVSphereHostMaintenanceConfig(enabled bool) string {
+	return fmt.Sprintf(`
+data "vsphere_datacenter" "datacenter" {
+  name = "%s"
+}
+
+data "vsphere_host" "esxi_host" {
+  name          = "%s"
+  datacenter_id = "${data.vsphere_datacenter.datacenter.id}"
+}
+
+resource "vsphere_host_maintenance" "maintenance" {
+  host_system_id   = "${data.vsphere_host.esxi_host.id}"
+  maintenance_mode = %t
+}
+`,
+		os.Getenv("VSPHERE_DATACENTER"),
+		os.Getenv("VSPHERE_ESXI_HOST"),
+		enabled,
+	)
+}
@@ -0,0 +1,362 @@
+package vsphere
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/structure"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+const resourceVSphereHostMultipathPolicyName = "vsphere_host_multipath_policy"
+
+// hostMultipathPolicyFixed is the name of the fixed path selection policy,
+// the only built-in policy that takes a preferred path.
+const hostMultipathPolicyFixed = "VMW_PSP_FIXED"
+
+// hostMultipathPolicyRoundRobin is the name of the Round Robin path selection
+// policy, which takes the round_robin_* options.
+const hostMultipathPolicyRoundRobin = "VMW_PSP_RR"
+
+func resourceVSphereHostMultipathPolicy() *schema.Resource {
+	return &schema.Resource{
+		Create:        resourceVSphereHostMultipathPolicyCreate,
+		Read:          resourceVSphereHostMultipathPolicyRead,
+		Update:        resourceVSphereHostMultipathPolicyUpdate,
+		Delete:        resourceVSphereHostMultipathPolicyDelete,
+		CustomizeDiff: resourceVSphereHostMultipathPolicyCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			State: resourceVSphereHostMultipathPolicyImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"host_system_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The managed object ID of the host the LUN is attached to.",
+			},
+			"canonical_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The canonical name of the LUN, such as the ones returned by the vsphere_vmfs_disks data source.",
+			},
+			"policy": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The path selection policy for the LUN, such as VMW_PSP_RR, VMW_PSP_FIXED, or VMW_PSP_MRU.",
+			},
+			"preferred_path": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The name of the preferred path when using the VMW_PSP_FIXED policy.",
+			},
+			"round_robin_iops": {
+				Type:          schema.TypeInt,
+				Optional:      true,
+				ConflictsWith: []string{"round_robin_bytes"},
+				Description:   "The number of I/O operations to send down a path before switching to the next path when using the VMW_PSP_RR policy.",
+				ValidateFunc:  validation.IntAtLeast(1),
+			},
+			"round_robin_bytes": {
+				Type:          schema.TypeInt,
+				Optional:      true,
+				ConflictsWith: []string{"round_robin_iops"},
+				Description:   "The number of bytes to send down a path before switching to the next path when using the VMW_PSP_RR policy.",
+				ValidateFunc:  validation.IntAtLeast(1),
+			},
+			"storage_array_type_policy": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The storage array type policy (SATP) that has claimed the LUN.",
+			},
+		},
+	}
+}
+
+func resourceVSphereHostMultipathPolicyCreate(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] %s: Beginning create", resourceVSphereHostMultipathPolicyIDString(d))
+	hsID := d.Get("host_system_id").(string)
+	name := d.Get("canonical_name").(string)
+	if err := resourceVSphereHostMultipathPolicyApply(d, meta, hsID, name); err != nil {
+		return err
+	}
+	d.SetId(resourceVSphereHostMultipathPolicyFlattenID(hsID, name))
+	log.Printf("[DEBUG] %s: Create finished successfully", resourceVSphereHostMultipathPolicyIDString(d))
+	return resourceVSphereHostMultipathPolicyRead(d, meta)
+}
+
+func resourceVSphereHostMultipathPolicyRead(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] %s: Beginning read", resourceVSphereHostMultipathPolicyIDString(d))
+	client := meta.(*VSphereClient).vimClient
+	hsID, name, err := resourceVSphereHostMultipathPolicyParseID(d.Id())
+	if err != nil {
+		return err
+	}
+	ss, err := hostStorageSystemFromHostSystemID(client, hsID)
+	if err != nil {
+		return fmt.Errorf("error loading host storage system: %s", err)
+	}
+	lu, err := hostMultipathLogicalUnitFromCanonicalName(ss, name)
+	if err != nil {
+		return err
+	}
+	if lu == nil {
+		log.Printf("[DEBUG] %s: LUN not found, marking resource as gone", resourceVSphereHostMultipathPolicyIDString(d))
+		d.SetId("")
+		return nil
+	}
+
+	if err := structure.SetBatch(d, map[string]interface{}{
+		"host_system_id": hsID,
+		"canonical_name": name,
+	}); err != nil {
+		return err
+	}
+	if err := flattenHostMultipathInfoLogicalUnit(d, lu); err != nil {
+		return err
+	}
+	if err := resourceVSphereHostMultipathPolicyReadRoundRobin(d, meta, hsID, lu); err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] %s: Read completed successfully", resourceVSphereHostMultipathPolicyIDString(d))
+	return nil
+}
+
+func resourceVSphereHostMultipathPolicyUpdate(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] %s: Beginning update", resourceVSphereHostMultipathPolicyIDString(d))
+	hsID, name, err := resourceVSphereHostMultipathPolicyParseID(d.Id())
+	if err != nil {
+		return err
+	}
+	if err := resourceVSphereHostMultipathPolicyApply(d, meta, hsID, name); err != nil {
+		return err
+	}
+	log.Printf("[DEBUG] %s: Update finished successfully", resourceVSphereHostMultipathPolicyIDString(d))
+	return resourceVSphereHostMultipathPolicyRead(d, meta)
+}
+
+func resourceVSphereHostMultipathPolicyDelete(d *schema.ResourceData, meta interface{}) error {
+	// A LUN always has a path selection policy, so there is nothing to remove.
+	// The current policy is left in place.
+	log.Printf("[DEBUG] %s: Removing from state, policy is left as-is on the host", resourceVSphereHostMultipathPolicyIDString(d))
+	return nil
+}
+
+func resourceVSphereHostMultipathPolicyImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	hsID, name, err := resourceVSphereHostMultipathPolicyParseID(d.Id())
+	if err != nil {
+		return nil, err
+	}
+	client := meta.(*VSphereClient).vimClient
+	ss, err := hostStorageSystemFromHostSystemID(client, hsID)
+	if err != nil {
+		return nil, fmt.Errorf("error loading host storage system: %s", err)
+	}
+	lu, err := hostMultipathLogicalUnitFromCanonicalName(ss, name)
+	if err != nil {
+		return nil, err
+	}
+	if lu == nil {
+		return nil, fmt.Errorf("LUN %q not found on host %q", name, hsID)
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceVSphereHostMultipathPolicyCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if v, ok := d.GetOk("preferred_path"); ok && v.(string) != "" && d.HasChange("preferred_path") {
+		if d.Get("policy").(string) != hostMultipathPolicyFixed {
+			return fmt.Errorf("preferred_path can only be set when policy is %s", hostMultipathPolicyFixed)
+		}
+	}
+	if d.Get("round_robin_iops").(int) > 0 || d.Get("round_robin_bytes").(int) > 0 {
+		if d.Get("policy").(string) != hostMultipathPolicyRoundRobin {
+			return fmt.Errorf("round_robin_iops and round_robin_bytes can only be set when policy is %s", hostMultipathPolicyRoundRobin)
+		}
+	}
+	return nil
+}
+
+// resourceVSphereHostMultipathPolicyApply sends the policy in the
+// ResourceData to the LUN.
+func resourceVSphereHostMultipathPolicyApply(d *schema.ResourceData, meta interface{}, hsID, name string) error {
+	client := meta.(*VSphereClient).vimClient
+	ss, err := hostStorageSystemFromHostSystemID(client, hsID)
+	if err != nil {
+		return fmt.Errorf("error loading host storage system: %s", err)
+	}
+	lu, err := hostMultipathLogicalUnitFromCanonicalName(ss, name)
+	if err != nil {
+		return err
+	}
+	if lu == nil {
+		return fmt.Errorf("LUN %q not found on host %q", name, hsID)
+	}
+
+	policy, err := expandHostMultipathInfoLogicalUnitPolicy(d, lu)
+	if err != nil {
+		return err
+	}
+	log.Printf("[DEBUG] %s: Setting path selection policy on LUN %q", resourceVSphereHostMultipathPolicyIDString(d), lu.Id)
+	if err := setHostMultipathLunPolicy(ss, lu.Id, policy); err != nil {
+		return fmt.Errorf("error setting path selection policy on LUN %q: %s", name, err)
+	}
+
+	// The Round Robin options are sent whenever they are set, as changing the
+	// policy resets them. If they have been removed from configuration, the
+	// default of switching paths every 1000 I/O operations is restored.
+	if d.Get("policy").(string) != hostMultipathPolicyRoundRobin {
+		return nil
+	}
+	config := expandHostMultipathRoundRobinConfig(d)
+	if config.LimitType == "default" && !d.HasChange("round_robin_iops") && !d.HasChange("round_robin_bytes") {
+		return nil
+	}
+	e, err := hostEsxcliExecutor(client, hsID)
+	if err != nil {
+		return fmt.Errorf("error connecting to esxcli on host %q: %s", hsID, err)
+	}
+	log.Printf("[DEBUG] %s: Setting Round Robin options on LUN %q", resourceVSphereHostMultipathPolicyIDString(d), name)
+	if err := setHostMultipathRoundRobinConfig(e, name, config); err != nil {
+		return fmt.Errorf("error setting Round Robin options on LUN %q: %s", name, err)
+	}
+	return nil
+}
+
+// resourceVSphereHostMultipathPolicyReadRoundRobin reads the Round Robin
+// options of a LUN into the ResourceData. The options are only tracked if
+// they are set, as reading them requires esxcli.
+func resourceVSphereHostMultipathPolicyReadRoundRobin(d *schema.ResourceData, meta interface{}, hsID string, lu *hostMultipathLogicalUnit) error {
+	if d.Get("round_robin_iops").(int) == 0 && d.Get("round_robin_bytes").(int) == 0 {
+		return nil
+	}
+	config := &hostMultipathRoundRobinConfig{LimitType: "default"}
+	if policy, _, _ := hostMultipathLogicalUnitPolicies(lu); policy == hostMultipathPolicyRoundRobin {
+		client := meta.(*VSphereClient).vimClient
+		e, err := hostEsxcliExecutor(client, hsID)
+		if err != nil {
+			return fmt.Errorf("error connecting to esxcli on host %q: %s", hsID, err)
+		}
+		config, err = getHostMultipathRoundRobinConfig(e, lu.CanonicalName)
+		if err != nil {
+			return fmt.Errorf("error reading Round Robin options of LUN %q: %s", lu.CanonicalName, err)
+		}
+	}
+	return flattenHostMultipathRoundRobinConfig(d, config)
+}
+
+// expandHostMultipathRoundRobinConfig reads certain ResourceData keys and
+// returns a hostMultipathRoundRobinConfig.
+func expandHostMultipathRoundRobinConfig(d *schema.ResourceData) *hostMultipathRoundRobinConfig {
+	config := &hostMultipathRoundRobinConfig{
+		LimitType: "default",
+		IOPS:      d.Get("round_robin_iops").(int),
+		Bytes:     d.Get("round_robin_bytes").(int),
+	}
+	switch {
+	case config.IOPS > 0:
+		config.LimitType = "iops"
+	case config.Bytes > 0:
+		config.LimitType = "bytes"
+	}
+	return config
+}
+
+// flattenHostMultipathRoundRobinConfig reads the limit of a
+// hostMultipathRoundRobinConfig into the passed in ResourceData. Only the
+// limit that is in effect is set.
+func flattenHostMultipathRoundRobinConfig(d *schema.ResourceData, config *hostMultipathRoundRobinConfig) error {
+	var iops, bytes int
+	switch config.LimitType {
+	case "iops":
+		iops = config.IOPS
+	case "bytes":
+		bytes = config.Bytes
+	}
+	return structure.SetBatch(d, map[string]interface{}{
+		"round_robin_iops":  iops,
+		"round_robin_bytes": bytes,
+	})
+}
+
+// expandHostMultipathInfoLogicalUnitPolicy reads certain ResourceData keys
+// and returns a BaseHostMultipathInfoLogicalUnitPolicy.
+func expandHostMultipathInfoLogicalUnitPolicy(d *schema.ResourceData, lu *hostMultipathLogicalUnit) (types.BaseHostMultipathInfoLogicalUnitPolicy, error) {
+	policy := d.Get("policy").(string)
+	if policy != hostMultipathPolicyFixed {
+		return &types.HostMultipathInfoLogicalUnitPolicy{Policy: policy}, nil
+	}
+
+	prefer := d.Get("preferred_path").(string)
+	if prefer == "" {
+		return &types.HostMultipathInfoFixedLogicalUnitPolicy{
+			HostMultipathInfoLogicalUnitPolicy: types.HostMultipathInfoLogicalUnitPolicy{Policy: policy},
+		}, nil
+	}
+	for _, p := range lu.Path {
+		if p.Name == prefer {
+			return &types.HostMultipathInfoFixedLogicalUnitPolicy{
+				HostMultipathInfoLogicalUnitPolicy: types.HostMultipathInfoLogicalUnitPolicy{Policy: policy},
+				Prefer:                             prefer,
+			}, nil
+		}
+	}
+	return nil, fmt.Errorf("preferred_path %q is not a path to LUN %q", prefer, lu.CanonicalName)
+}
+
+// flattenHostMultipathInfoLogicalUnit reads the policy fields of a
+// multipath logical unit into the passed in ResourceData.
+func flattenHostMultipathInfoLogicalUnit(d *schema.ResourceData, lu *hostMultipathLogicalUnit) error {
+	policy, prefer, satp := hostMultipathLogicalUnitPolicies(lu)
+	return structure.SetBatch(d, map[string]interface{}{
+		"policy":                    policy,
+		"preferred_path":            prefer,
+		"storage_array_type_policy": satp,
+	})
+}
+
+// hostMultipathLogicalUnitPolicies returns the path selection policy,
+// preferred path (if any), and storage array type policy of a multipath
+// logical unit.
+func hostMultipathLogicalUnitPolicies(lu *hostMultipathLogicalUnit) (string, string, string) {
+	var policy, prefer, satp string
+	if lu.Policy != nil {
+		policy = lu.Policy.GetHostMultipathInfoLogicalUnitPolicy().Policy
+		if fixed, ok := lu.Policy.(*types.HostMultipathInfoFixedLogicalUnitPolicy); ok {
+			prefer = fixed.Prefer
+		}
+	}
+	if lu.StorageArrayTypePolicy != nil {
+		satp = lu.StorageArrayTypePolicy.Policy
+	}
+	return policy, prefer, satp
+}
+
+// resourceVSphereHostMultipathPolicyIDString prints a friendly string for the
+// vsphere_host_multipath_policy resource.
+func resourceVSphereHostMultipathPolicyIDString(d structure.ResourceIDStringer) string {
+	return structure.ResourceIDString(d, resourceVSphereHostMultipathPolicyName)
+}
+
+// resourceVSphereHostMultipathPolicyFlattenID makes an ID for the
+// vsphere_host_multipath_policy resource.
+func resourceVSphereHostMultipathPolicyFlattenID(hsID, name string) string {
+	return strings.Join([]string{hsID, name}, ":")
+}
+
+// resourceVSphereHostMultipathPolicyParseID parses an ID for the
+// vsphere_host_multipath_policy and outputs its parts. Canonical names can
+// contain colons, so only the first colon is used as a separator.
+func resourceVSphereHostMultipathPolicyParseID(id string) (string, string, error) {
+	parts := strings.SplitN(id, ":", 2)
+	if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("bad ID %q", id)
+	}
+	return parts[0], parts[1], nil
+}
@@ -0,0 +1,286 @@
+package vsphere
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccResourceVSphereHostMultipathPolicy_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccResourceVSphereHostMultipathPolicyPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceVSphereHostMultipathPolicyConfig("VMW_PSP_RR"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereHostMultipathPolicyMatch("VMW_PSP_RR"),
+				),
+			},
+			{
+				Config: testAccResourceVSphereHostMultipathPolicyConfig("VMW_PSP_MRU"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereHostMultipathPolicyMatch("VMW_PSP_MRU"),
+				),
+			},
+			{
+				ResourceName:      "vsphere_host_multipath_policy.policy",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccResourceVSphereHostMultipathPolicy_roundRobinIOPS(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccResourceVSphereHostMultipathPolicyPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceVSphereHostMultipathPolicyConfigRoundRobin("round_robin_iops = 1"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereHostMultipathPolicyMatch("VMW_PSP_RR"),
+					testAccResourceVSphereHostMultipathPolicyRoundRobinMatch("iops", 1),
+				),
+			},
+			{
+				Config: testAccResourceVSphereHostMultipathPolicyConfigRoundRobin("round_robin_bytes = 1048576"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereHostMultipathPolicyRoundRobinMatch("bytes", 1048576),
+				),
+			},
+			{
+				Config: testAccResourceVSphereHostMultipathPolicyConfigRoundRobin(""),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereHostMultipathPolicyRoundRobinMatch("default", 0),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceVSphereHostMultipathPolicy_badRoundRobin(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccResourceVSphereHostMultipathPolicyPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccResourceVSphereHostMultipathPolicyConfigBadRoundRobin(),
+				ExpectError: regexp.MustCompile("round_robin_iops and round_robin_bytes can only be set when policy is VMW_PSP_RR"),
+				PlanOnly:    true,
+			},
+			{
+				Config: testAccResourceVSphereEmpty,
+				Check:  resource.ComposeTestCheckFunc(),
+			},
+		},
+	})
+}
+
+func TestAccResourceVSphereHostMultipathPolicy_badPreferredPath(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccResourceVSphereHostMultipathPolicyPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccResourceVSphereHostMultipathPolicyConfigBadPreferredPath(),
+				ExpectError: regexp.MustCompile("preferred_path can only be set when policy is VMW_PSP_FIXED"),
+				PlanOnly:    true,
+			},
+			{
+				Config: testAccResourceVSphereEmpty,
+				Check:  resource.ComposeTestCheckFunc(),
+			},
+		},
+	})
+}
+
+func testAccResourceVSphereHostMultipathPolicyPreCheck(t *testing.T) {
+	if os.Getenv("VSPHERE_DATACENTER") == "" {
+		t.Skip("set VSPHERE_DATACENTER to run vsphere_host_multipath_policy acceptance tests")
+	}
+	if os.Getenv("VSPHERE_ESXI_HOST") == "" {
+		t.Skip("set VSPHERE_ESXI_HOST to run vsphere_host_multipath_policy acceptance tests")
+	}
+	if os.Getenv("VSPHERE_DS_VMFS_DISK0") == "" {
+		t.Skip("set VSPHERE_DS_VMFS_DISK0 to run vsphere_host_multipath_policy acceptance tests")
+	}
+}
+
+func testAccResourceVSphereHostMultipathPolicyMatch(expected string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		vars, err := testClientVariablesForResource(s, "vsphere_host_multipath_policy.policy")
+		if err != nil {
+			return err
+		}
+		hsID, name, err := resourceVSphereHostMultipathPolicyParseID(vars.resourceID)
+		if err != nil {
+			return err
+		}
+		ss, err := hostStorageSystemFromHostSystemID(vars.client, hsID)
+		if err != nil {
+			return err
+		}
+		lu, err := hostMultipathLogicalUnitFromCanonicalName(ss, name)
+		if err != nil {
+			return err
+		}
+		if lu == nil {
+			return fmt.Errorf("LUN %q not found", name)
+		}
+		actual, _, _ := hostMultipathLogicalUnitPolicies(lu)
+		if actual != expected {
+			return fmt.Errorf("expected policy %q, got %q", expected, actual)
+		}
+		return nil
+	}
+}
+
+func testAccResourceVSphereHostMultipathPolicyRoundRobinMatch(limitType string, limit int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		vars, err := testClientVariablesForResource(s, "vsphere_host_multipath_policy.policy")
+		if err != nil {
+			return err
+		}
+		hsID, name, err := resourceVSphereHostMultipathPolicyParseID(vars.resourceID)
+		if err != nil {
+			return err
+		}
+		e, err := hostEsxcliExecutor(vars.client, hsID)
+		if err != nil {
+			return err
+		}
+		config, err := getHostMultipathRoundRobinConfig(e, name)
+		if err != nil {
+			return err
+		}
+		if config.LimitType != limitType {
+			return fmt.Errorf("expected limit type %q, got %q", limitType, config.LimitType)
+		}
+		var actual int
+		switch limitType {
+		case "iops":
+			actual = config.IOPS
+		case "bytes":
+			actual = config.Bytes
+		}
+		if actual != limit {
+			return fmt.Errorf("expected %s limit %d, got %d", limitType, limit, actual)
+		}
+		return nil
+	}
+}
+
+func testAccResourceVSphereHostMultipathPolicyConfig(policy string) string {
+	return fmt.Sprintf(`
+data "vsphere_datacenter" "datacenter" {
+  name = "%s"
+}
+
+data "vsphere_host" "esxi_host" {
+  name          = "%s"
+  datacenter_id = "${data.vsphere_datacenter.datacenter.id}"
+}
+
+resource "vsphere_host_multipath_policy" "policy" {
+  host_system_id = "${data.vsphere_host.esxi_host.id}"
+  canonical_name = "%s"
+  policy         = "%s"
+}
+`,
+		os.Getenv("VSPHERE_DATACENTER"),
+		os.Getenv("VSPHERE_ESXI_HOST"),
+		os.Getenv("VSPHERE_DS_VMFS_DISK0"),
+		policy,
+	)
+}
+
+func testAccResourceVSphereHostMultipathPolicyConfigBadPreferredPath() string {
+	return fmt.Sprintf(`
+data "vsphere_datacenter" "datacenter" {
+  name = "%s"
+}
+
+data "vsphere_host" "esxi_host" {
+  name          = "%s"
+  datacenter_id = "${data.vsphere_datacenter.datacenter.id}"
+}
+
+resource "vsphere_host_multipath_policy" "policy" {
+  host_system_id = "${data.vsphere_host.esxi_host.id}"
+  canonical_name = "%s"
+  policy         = "VMW_PSP_RR"
+  preferred_path = "vmhba1:C0:T0:L0"
+}
+`,
+		os.Getenv("VSPHERE_DATACENTER"),
+		os.Getenv("VSPHERE_ESXI_HOST"),
+		os.Getenv("VSPHERE_DS_VMFS_DISK0"),
+	)
+}
+
+func testAccResourceVSphereHostMultipathPolicyConfigRoundRobin(options string) string {
+	return fmt.Sprintf(`
+data "vsphere_datacenter" "datacenter" {
+  name = "%s"
+}
+
+data "vsphere_host" "esxi_host" {
+  name          = "%s"
+  datacenter_id = "${data.vsphere_datacenter.datacenter.id}"
+}
+
+resource "vsphere_host_multipath_policy" "policy" {
+  host_system_id = "${data.vsphere_host.esxi_host.id}"
+  canonical_name = "%s"
+  policy         = "VMW_PSP_RR"
+  %s
+}
+`,
+		os.Getenv("VSPHERE_DATACENTER"),
+		os.Getenv("VSPHERE_ESXI_HOST"),
+		os.Getenv("VSPHERE_DS_VMFS_DISK0"),
+		options,
+	)
+}
+
+func testAccResourceVSphereHostMultipathPolicyConfigBadRoundRobin() string {
+	return fmt.Sprintf(`
+data "vsphere_datacenter" "datacenter" {
+  name = "%s"
+}
+
+data "vsphere_host" "esxi_host" {
+  name          = "%s"
+  datacenter_id = "${data.vsphere_datacenter.datacenter.id}"
+}
+
+resource "vsphere_host_multipath_policy" "policy" {
+  host_system_id   = "${data.vsphere_host.esxi_host.id}"
+  canonical_name   = "%s"
+  policy           = "VMW_PSP_MRU"
+  round_robin_iops = 1
+}
+`,
+		os.Getenv("VSPHERE_DATACENTER"),
+		os.Getenv("VSPHERE_ESXI_HOST"),
+		os.Getenv("VSPHERE_DS_VMFS_DISK0"),
+	)
+}
@@ -0,0 +1,280 @@
+package vsphere
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/structure"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+const resourceVSphereHostPciPassthroughName = "vsphere_host_pci_passthrough"
+
+func resourceVSphereHostPciPassthrough() *schema.Resource {
+	return &schema.Resource{
+		Create:        resourceVSphereHostPciPassthroughCreate,
+		Read:          resourceVSphereHostPciPassthroughRead,
+		Update:        resourceVSphereHostPciPassthroughUpdate,
+		Delete:        resourceVSphereHostPciPassthroughDelete,
+		CustomizeDiff: resourceVSphereHostPciPassthroughCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			State: resourceVSphereHostPciPassthroughImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"host_system_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The managed object ID of the host the PCI device is installed in.",
+			},
+			"pci_device_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The PCI ID of the device, in the form domain:bus:slot.function.",
+			},
+			"passthrough_enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Enable DirectPath I/O passthrough for the device.",
+			},
+			"num_virtual_functions": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				Description:  "The number of SR-IOV virtual functions to configure on the device. Setting this to a value greater than 0 enables SR-IOV.",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"passthrough_active": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether or not passthrough is currently active for the device.",
+			},
+			"active_virtual_functions": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of SR-IOV virtual functions currently active on the device.",
+			},
+			"reboot_required": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether or not the host must be rebooted for the configuration of the device to take effect.",
+			},
+		},
+	}
+}
+
+func resourceVSphereHostPciPassthroughCreate(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] %s: Beginning create", resourceVSphereHostPciPassthroughIDString(d))
+	hsID := d.Get("host_system_id").(string)
+	devID := d.Get("pci_device_id").(string)
+	if err := resourceVSphereHostPciPassthroughApply(d, meta, hsID, devID, false); err != nil {
+		return err
+	}
+	d.SetId(resourceVSphereHostPciPassthroughFlattenID(hsID, devID))
+	log.Printf("[DEBUG] %s: Create finished successfully", resourceVSphereHostPciPassthroughIDString(d))
+	return resourceVSphereHostPciPassthroughRead(d, meta)
+}
+
+func resourceVSphereHostPciPassthroughRead(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] %s: Beginning read", resourceVSphereHostPciPassthroughIDString(d))
+	client := meta.(*VSphereClient).vimClient
+	hsID, devID, err := resourceVSphereHostPciPassthroughParseID(d.Id())
+	if err != nil {
+		return err
+	}
+	ps, err := hostPciPassthruSystemFromHostSystemID(client, hsID)
+	if err != nil {
+		return fmt.Errorf("error loading host PCI passthrough system: %s", err)
+	}
+	info, err := ps.InfoForDevice(devID)
+	if err != nil {
+		return fmt.Errorf("error reading PCI passthrough information: %s", err)
+	}
+	if info == nil {
+		log.Printf("[DEBUG] %s: PCI device not found, marking resource as gone", resourceVSphereHostPciPassthroughIDString(d))
+		d.SetId("")
+		return nil
+	}
+
+	if err := structure.SetBatch(d, map[string]interface{}{
+		"host_system_id": hsID,
+		"pci_device_id":  devID,
+	}); err != nil {
+		return err
+	}
+	if err := flattenHostPciPassthruInfo(d, info); err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] %s: Read completed successfully", resourceVSphereHostPciPassthroughIDString(d))
+	return nil
+}
+
+func resourceVSphereHostPciPassthroughUpdate(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] %s: Beginning update", resourceVSphereHostPciPassthroughIDString(d))
+	hsID, devID, err := resourceVSphereHostPciPassthroughParseID(d.Id())
+	if err != nil {
+		return err
+	}
+	if err := resourceVSphereHostPciPassthroughApply(d, meta, hsID, devID, false); err != nil {
+		return err
+	}
+	log.Printf("[DEBUG] %s: Update finished successfully", resourceVSphereHostPciPassthroughIDString(d))
+	return resourceVSphereHostPciPassthroughRead(d, meta)
+}
+
+func resourceVSphereHostPciPassthroughDelete(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] %s: Beginning delete", resourceVSphereHostPciPassthroughIDString(d))
+	hsID, devID, err := resourceVSphereHostPciPassthroughParseID(d.Id())
+	if err != nil {
+		return err
+	}
+	if err := resourceVSphereHostPciPassthroughApply(d, meta, hsID, devID, true); err != nil {
+		return err
+	}
+	log.Printf("[DEBUG] %s: Delete finished successfully", resourceVSphereHostPciPassthroughIDString(d))
+	return nil
+}
+
+func resourceVSphereHostPciPassthroughImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	hsID, devID, err := resourceVSphereHostPciPassthroughParseID(d.Id())
+	if err != nil {
+		return nil, err
+	}
+	client := meta.(*VSphereClient).vimClient
+	ps, err := hostPciPassthruSystemFromHostSystemID(client, hsID)
+	if err != nil {
+		return nil, fmt.Errorf("error loading host PCI passthrough system: %s", err)
+	}
+	info, err := ps.InfoForDevice(devID)
+	if err != nil {
+		return nil, fmt.Errorf("error reading PCI passthrough information: %s", err)
+	}
+	if info == nil {
+		return nil, fmt.Errorf("PCI device %q not found on host %q", devID, hsID)
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceVSphereHostPciPassthroughCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if d.Get("passthrough_enabled").(bool) && d.Get("num_virtual_functions").(int) > 0 {
+		return fmt.Errorf("passthrough_enabled and num_virtual_functions cannot be used together")
+	}
+	return nil
+}
+
+// resourceVSphereHostPciPassthroughApply sends the passthrough configuration
+// in the ResourceData to the device. When reset is true, passthrough and
+// SR-IOV are disabled instead.
+func resourceVSphereHostPciPassthroughApply(d *schema.ResourceData, meta interface{}, hsID, devID string, reset bool) error {
+	client := meta.(*VSphereClient).vimClient
+	ps, err := hostPciPassthruSystemFromHostSystemID(client, hsID)
+	if err != nil {
+		return fmt.Errorf("error loading host PCI passthrough system: %s", err)
+	}
+	info, err := ps.InfoForDevice(devID)
+	if err != nil {
+		return fmt.Errorf("error reading PCI passthrough information: %s", err)
+	}
+	if info == nil {
+		return fmt.Errorf("PCI device %q not found on host %q", devID, hsID)
+	}
+
+	config, err := expandHostPciPassthruConfig(d, info, reset)
+	if err != nil {
+		return err
+	}
+	log.Printf("[DEBUG] %s: Updating passthrough configuration for PCI device %q", resourceVSphereHostPciPassthroughIDString(d), devID)
+	if err := ps.UpdatePassthruConfig([]types.BaseHostPciPassthruConfig{config}); err != nil {
+		return fmt.Errorf("error updating passthrough configuration for PCI device %q: %s", devID, err)
+	}
+	return ps.Refresh()
+}
+
+// expandHostPciPassthruConfig reads certain ResourceData keys and returns a
+// BaseHostPciPassthruConfig for the device, validating the request against
+// the capabilities reported by the host.
+func expandHostPciPassthruConfig(d *schema.ResourceData, info types.BaseHostPciPassthruInfo, reset bool) (types.BaseHostPciPassthruConfig, error) {
+	base := info.GetHostPciPassthruInfo()
+	enabled := d.Get("passthrough_enabled").(bool)
+	numVFs := d.Get("num_virtual_functions").(int)
+	if reset {
+		enabled = false
+		numVFs = 0
+	}
+
+	sriov, isSriov := info.(*types.HostSriovInfo)
+	if numVFs > 0 {
+		if !isSriov || !sriov.SriovCapable {
+			return nil, fmt.Errorf("PCI device %q does not support SR-IOV", base.Id)
+		}
+		if int32(numVFs) > sriov.MaxVirtualFunctionSupported {
+			return nil, fmt.Errorf("PCI device %q supports at most %d virtual functions", base.Id, sriov.MaxVirtualFunctionSupported)
+		}
+	}
+	if enabled && !base.PassthruCapable {
+		return nil, fmt.Errorf("PCI device %q is not capable of passthrough", base.Id)
+	}
+
+	if isSriov && sriov.SriovCapable {
+		return &types.HostSriovConfig{
+			HostPciPassthruConfig: types.HostPciPassthruConfig{
+				Id:              base.Id,
+				PassthruEnabled: enabled,
+			},
+			SriovEnabled:       numVFs > 0,
+			NumVirtualFunction: int32(numVFs),
+		}, nil
+	}
+	return &types.HostPciPassthruConfig{
+		Id:              base.Id,
+		PassthruEnabled: enabled,
+	}, nil
+}
+
+// flattenHostPciPassthruInfo reads the passthrough state of a PCI device
+// into the passed in ResourceData.
+func flattenHostPciPassthruInfo(d *schema.ResourceData, info types.BaseHostPciPassthruInfo) error {
+	base := info.GetHostPciPassthruInfo()
+	var numVFs, activeVFs int
+	if sriov, ok := info.(*types.HostSriovInfo); ok && sriov.SriovEnabled {
+		numVFs = int(sriov.NumVirtualFunctionRequested)
+		activeVFs = int(sriov.NumVirtualFunction)
+	}
+	return structure.SetBatch(d, map[string]interface{}{
+		"passthrough_enabled":      base.PassthruEnabled,
+		"num_virtual_functions":    numVFs,
+		"passthrough_active":       base.PassthruActive,
+		"active_virtual_functions": activeVFs,
+		"reboot_required":          hostPciPassthruInfoRebootRequired(info),
+	})
+}
+
+// resourceVSphereHostPciPassthroughIDString prints a friendly string for the
+// vsphere_host_pci_passthrough resource.
+func resourceVSphereHostPciPassthroughIDString(d structure.ResourceIDStringer) string {
+	return structure.ResourceIDString(d, resourceVSphereHostPciPassthroughName)
+}
+
+// resourceVSphereHostPciPassthroughFlattenID makes an ID for the
+// vsphere_host_pci_passthrough resource.
+func resourceVSphereHostPciPassthroughFlattenID(hsID, devID string) string {
+	return strings.Join([]string{hsID, devID}, ":")
+}
+
+// resourceVSphereHostPciPassthroughParseID parses an ID for the
+// vsphere_host_pci_passthrough and outputs its parts. PCI IDs contain colons,
+// so only the first colon is used as a separator.
+func resourceVSphereHostPciPassthroughParseID(id string) (string, string, error) {
+	parts := strings.SplitN(id, ":", 2)
+	if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("bad ID %q", id)
+	}
+	return parts[0], parts[1], nil
+}
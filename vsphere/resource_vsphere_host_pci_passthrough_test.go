@@ -0,0 +1,118 @@
+package vsphere
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccResourceVSphereHostPciPassthrough_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccResourceVSphereHostPciPassthroughPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceVSphereHostPciPassthroughConfig(true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereHostPciPassthroughEnabled(true),
+				),
+			},
+			{
+				Config: testAccResourceVSphereHostPciPassthroughConfig(false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereHostPciPassthroughEnabled(false),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceVSphereHostPciPassthrough_import(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccResourceVSphereHostPciPassthroughPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceVSphereHostPciPassthroughConfig(true),
+			},
+			{
+				ResourceName:      "vsphere_host_pci_passthrough.passthrough",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccResourceVSphereHostPciPassthroughPreCheck(t *testing.T) {
+	if os.Getenv("VSPHERE_DATACENTER") == "" {
+		t.Skip("set VSPHERE_DATACENTER to run vsphere_host_pci_passthrough acceptance tests")
+	}
+	if os.Getenv("VSPHERE_ESXI_HOST") == "" {
+		t.Skip("set VSPHERE_ESXI_HOST to run vsphere_host_pci_passthrough acceptance tests")
+	}
+	if os.Getenv("VSPHERE_PCI_PASSTHROUGH_DEVICE") == "" {
+		t.Skip("set VSPHERE_PCI_PASSTHROUGH_DEVICE to run vsphere_host_pci_passthrough acceptance tests")
+	}
+}
+
+func testAccResourceVSphereHostPciPassthroughEnabled(expected bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		vars, err := testClientVariablesForResource(s, "vsphere_host_pci_passthrough.passthrough")
+		if err != nil {
+			return err
+		}
+		hsID, devID, err := resourceVSphereHostPciPassthroughParseID(vars.resourceID)
+		if err != nil {
+			return err
+		}
+		ps, err := hostPciPassthruSystemFromHostSystemID(vars.client, hsID)
+		if err != nil {
+			return err
+		}
+		info, err := ps.InfoForDevice(devID)
+		if err != nil {
+			return err
+		}
+		if info == nil {
+			return fmt.Errorf("PCI device %q not found", devID)
+		}
+		if actual := info.GetHostPciPassthruInfo().PassthruEnabled; actual != expected {
+			return fmt.Errorf("expected passthrough enabled to be %t, got %t", expected, actual)
+		}
+		return nil
+	}
+}
+
+func testAccResourceVSphereHostPciPassthroughConfig(enabled bool) string {
+	return fmt.Sprintf(`
+data "vsphere_datacenter" "datacenter" {
+  name = "%s"
+}
+
+data "vsphere_host" "esxi_host" {
+  name          = "%s"
+  datacenter_id = "${data.vsphere_datacenter.datacenter.id}"
+}
+
+resource "vsphere_host_pci_passthrough" "passthrough" {
+  host_system_id      = "${data.vsphere_host.esxi_host.id}"
+  pci_device_id       = "%s"
+  passthrough_enabled = %t
+}
+`,
+		os.Getenv("VSPHERE_DATACENTER"),
+		os.Getenv("VSPHERE_ESXI_HOST"),
+		os.Getenv("VSPHERE_PCI_PASSTHROUGH_DEVICE"),
+		enabled,
+	)
+}
@@ -0,0 +1,212 @@
+package vsphere
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/structure"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+const resourceVSphereHostSnmpName = "vsphere_host_snmp"
+
+func resourceVSphereHostSnmp() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceVSphereHostSnmpCreate,
+		Read:   resourceVSphereHostSnmpRead,
+		Update: resourceVSphereHostSnmpUpdate,
+		Delete: resourceVSphereHostSnmpDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceVSphereHostSnmpImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"host_system_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The managed object ID of the host to configure the SNMP agent for.",
+			},
+			"enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Enable the SNMP agent on the host.",
+			},
+			"port": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      161,
+				Description:  "The UDP port the SNMP agent listens on.",
+				ValidateFunc: validation.IntBetween(1, 65535),
+			},
+			"read_only_communities": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "The read-only communities of the SNMP agent.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"trap_target": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "The destinations to send SNMP traps to.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"host_name": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The host name or IP address of the trap receiver.",
+						},
+						"port": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      162,
+							Description:  "The UDP port of the trap receiver.",
+							ValidateFunc: validation.IntBetween(1, 65535),
+						},
+						"community": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Sensitive:   true,
+							Description: "The community to send traps with.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceVSphereHostSnmpCreate(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] %s: Beginning create", resourceVSphereHostSnmpIDString(d))
+	hsID := d.Get("host_system_id").(string)
+	if err := resourceVSphereHostSnmpApply(d, meta, hsID, expandHostSnmpConfigSpec(d)); err != nil {
+		return err
+	}
+	d.SetId(hsID)
+	log.Printf("[DEBUG] %s: Create finished successfully", resourceVSphereHostSnmpIDString(d))
+	return resourceVSphereHostSnmpRead(d, meta)
+}
+
+func resourceVSphereHostSnmpRead(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] %s: Beginning read", resourceVSphereHostSnmpIDString(d))
+	client := meta.(*VSphereClient).vimClient
+	ss, err := hostSnmpSystemFromHostSystemID(client, d.Id())
+	if err != nil {
+		return fmt.Errorf("error loading host SNMP system: %s", err)
+	}
+	config, err := ss.Configuration()
+	if err != nil {
+		return fmt.Errorf("error reading SNMP configuration: %s", err)
+	}
+	if err := d.Set("host_system_id", d.Id()); err != nil {
+		return err
+	}
+	if err := flattenHostSnmpConfigSpec(d, config); err != nil {
+		return err
+	}
+	log.Printf("[DEBUG] %s: Read completed successfully", resourceVSphereHostSnmpIDString(d))
+	return nil
+}
+
+func resourceVSphereHostSnmpUpdate(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] %s: Beginning update", resourceVSphereHostSnmpIDString(d))
+	if err := resourceVSphereHostSnmpApply(d, meta, d.Id(), expandHostSnmpConfigSpec(d)); err != nil {
+		return err
+	}
+	log.Printf("[DEBUG] %s: Update finished successfully", resourceVSphereHostSnmpIDString(d))
+	return resourceVSphereHostSnmpRead(d, meta)
+}
+
+func resourceVSphereHostSnmpDelete(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] %s: Beginning delete", resourceVSphereHostSnmpIDString(d))
+	// Only the agent is disabled. Communities and trap targets are left in
+	// place, as they have no effect while the agent is not running.
+	spec := types.HostSnmpConfigSpec{
+		Enabled: structure.BoolPtr(false),
+	}
+	if err := resourceVSphereHostSnmpApply(d, meta, d.Id(), spec); err != nil {
+		return err
+	}
+	log.Printf("[DEBUG] %s: Delete finished successfully", resourceVSphereHostSnmpIDString(d))
+	return nil
+}
+
+func resourceVSphereHostSnmpImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client := meta.(*VSphereClient).vimClient
+	if _, err := hostSnmpSystemFromHostSystemID(client, d.Id()); err != nil {
+		return nil, fmt.Errorf("error loading host SNMP system: %s", err)
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// resourceVSphereHostSnmpApply sends the supplied SNMP agent configuration to
+// the host.
+func resourceVSphereHostSnmpApply(d *schema.ResourceData, meta interface{}, hsID string, spec types.HostSnmpConfigSpec) error {
+	client := meta.(*VSphereClient).vimClient
+	ss, err := hostSnmpSystemFromHostSystemID(client, hsID)
+	if err != nil {
+		return fmt.Errorf("error loading host SNMP system: %s", err)
+	}
+	log.Printf("[DEBUG] %s: Reconfiguring SNMP agent", resourceVSphereHostSnmpIDString(d))
+	if err := ss.Reconfigure(spec); err != nil {
+		return fmt.Errorf("error reconfiguring SNMP agent: %s", err)
+	}
+	return nil
+}
+
+// expandHostSnmpConfigSpec reads certain ResourceData keys and returns a
+// HostSnmpConfigSpec.
+func expandHostSnmpConfigSpec(d *schema.ResourceData) types.HostSnmpConfigSpec {
+	spec := types.HostSnmpConfigSpec{
+		Enabled:             structure.BoolPtr(d.Get("enabled").(bool)),
+		Port:                int32(d.Get("port").(int)),
+		ReadOnlyCommunities: structure.SliceInterfacesToStrings(d.Get("read_only_communities").(*schema.Set).List()),
+	}
+	for _, raw := range d.Get("trap_target").([]interface{}) {
+		t := raw.(map[string]interface{})
+		spec.TrapTargets = append(spec.TrapTargets, types.HostSnmpDestination{
+			HostName:  t["host_name"].(string),
+			Port:      int32(t["port"].(int)),
+			Community: t["community"].(string),
+		})
+	}
+	return spec
+}
+
+// flattenHostSnmpConfigSpec reads a HostSnmpConfigSpec into the passed in
+// ResourceData.
+func flattenHostSnmpConfigSpec(d *schema.ResourceData, config *types.HostSnmpConfigSpec) error {
+	var communities []string
+	for _, c := range config.ReadOnlyCommunities {
+		if c != "" {
+			communities = append(communities, c)
+		}
+	}
+	var targets []interface{}
+	for _, t := range config.TrapTargets {
+		targets = append(targets, map[string]interface{}{
+			"host_name": t.HostName,
+			"port":      int(t.Port),
+			"community": t.Community,
+		})
+	}
+	attrs := map[string]interface{}{
+		"port":                  int(config.Port),
+		"read_only_communities": communities,
+		"trap_target":           targets,
+	}
+	if config.Enabled != nil {
+		attrs["enabled"] = *config.Enabled
+	}
+	return structure.SetBatch(d, attrs)
+}
+
+// resourceVSphereHostSnmpIDString prints a friendly string for the
+// vsphere_host_snmp resource.
+func resourceVSphereHostSnmpIDString(d structure.ResourceIDStringer) string {
+	return structure.ResourceIDString(d, resourceVSphereHostSnmpName)
+}
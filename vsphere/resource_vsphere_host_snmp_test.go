@@ -0,0 +1,115 @@
+package vsphere
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccResourceVSphereHostSnmp_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccResourceVSphereHostSnmpPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceVSphereHostSnmpConfig(true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereHostSnmpMatch(true, "10.0.0.30"),
+				),
+			},
+			{
+				Config: testAccResourceVSphereHostSnmpConfig(false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereHostSnmpMatch(false, "10.0.0.30"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceVSphereHostSnmp_import(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccResourceVSphereHostSnmpPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceVSphereHostSnmpConfig(true),
+			},
+			{
+				ResourceName:      "vsphere_host_snmp.snmp",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccResourceVSphereHostSnmpPreCheck(t *testing.T) {
+	if os.Getenv("VSPHERE_DATACENTER") == "" {
+		t.Skip("set VSPHERE_DATACENTER to run vsphere_host_snmp acceptance tests")
+	}
+	if os.Getenv("VSPHERE_ESXI_HOST") == "" {
+		t.Skip("set VSPHERE_ESXI_HOST to run vsphere_host_snmp acceptance tests")
+	}
+}
+
+func testAccResourceVSphereHostSnmpMatch(enabled bool, trapHost string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		vars, err := testClientVariablesForResource(s, "vsphere_host_snmp.snmp")
+		if err != nil {
+			return err
+		}
+		ss, err := hostSnmpSystemFromHostSystemID(vars.client, vars.resourceID)
+		if err != nil {
+			return err
+		}
+		config, err := ss.Configuration()
+		if err != nil {
+			return err
+		}
+		if config.Enabled == nil || *config.Enabled != enabled {
+			return fmt.Errorf("expected SNMP agent enabled to be %t", enabled)
+		}
+		if len(config.TrapTargets) != 1 || config.TrapTargets[0].HostName != trapHost {
+			return fmt.Errorf("expected a single trap target %q, got %+v", trapHost, config.TrapTargets)
+		}
+		return nil
+	}
+}
+
+func testAccResourceVSphereHostSnmpConfig(enabled bool) string {
+	return fmt.Sprintf(`
+data "vsphere_datacenter" "datacenter" {
+  name = "%s"
+}
+
+data "vsphere_host" "esxi_host" {
+  name          = "%s"
+  datacenter_id = "${data.vsphere_datacenter.datacenter.id}"
+}
+
+resource "vsphere_host_snmp" "snmp" {
+  host_system_id        = "${data.vsphere_host.esxi_host.id}"
+  enabled               = %t
+  read_only_communities = ["terraform-test"]
+
+  trap_target {
+    host_name = "10.0.0.30"
+    community = "terraform-test"
+  }
+}
+`,
+		os.Getenv("VSPHERE_DATACENTER"),
+		os.Getenv("VSPHERE_ESXI_HOST"),
+		enabled,
+	)
+}
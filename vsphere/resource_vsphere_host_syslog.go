@@ -0,0 +1,215 @@
+package vsphere
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/structure"
+)
+
+const resourceVSphereHostSyslogName = "vsphere_host_syslog"
+
+// The advanced options that make up the syslog configuration of a host.
+const (
+	hostSyslogOptionLogHost      = "Syslog.global.logHost"
+	hostSyslogOptionLogDir       = "Syslog.global.logDir"
+	hostSyslogOptionLogDirUnique = "Syslog.global.logDirUnique"
+	hostSyslogOptionRotate       = "Syslog.global.defaultRotate"
+	hostSyslogOptionSize         = "Syslog.global.defaultSize"
+)
+
+func resourceVSphereHostSyslog() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceVSphereHostSyslogCreate,
+		Read:   resourceVSphereHostSyslogRead,
+		Update: resourceVSphereHostSyslogUpdate,
+		Delete: resourceVSphereHostSyslogDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceVSphereHostSyslogImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"host_system_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The managed object ID of the host to configure syslog for.",
+			},
+			"log_hosts": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "The remote hosts to forward logs to, such as udp://10.0.0.10:514 or ssl://logs.example.com:1514.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"log_dir": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The datastore path of the directory to write local logs to, such as [datastore1] logs.",
+			},
+			"log_dir_unique": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "Write logs to a subdirectory of log_dir named after the host. Useful when log_dir is on shared storage.",
+			},
+			"rotate": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				Description:  "The number of rotated log files to keep.",
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"rotate_size": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				Description:  "The size, in KiB, a log file can grow to before it is rotated.",
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+		},
+	}
+}
+
+func resourceVSphereHostSyslogCreate(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] %s: Beginning create", resourceVSphereHostSyslogIDString(d))
+	hsID := d.Get("host_system_id").(string)
+	if err := resourceVSphereHostSyslogApply(d, meta, hsID); err != nil {
+		return err
+	}
+	d.SetId(hsID)
+	log.Printf("[DEBUG] %s: Create finished successfully", resourceVSphereHostSyslogIDString(d))
+	return resourceVSphereHostSyslogRead(d, meta)
+}
+
+func resourceVSphereHostSyslogRead(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] %s: Beginning read", resourceVSphereHostSyslogIDString(d))
+	client := meta.(*VSphereClient).vimClient
+	m, err := hostOptionManagerFromHostSystemID(client, d.Id())
+	if err != nil {
+		return fmt.Errorf("error loading host option manager: %s", err)
+	}
+	opts, err := hostAdvancedOptions(m, []string{
+		hostSyslogOptionLogHost,
+		hostSyslogOptionLogDir,
+		hostSyslogOptionLogDirUnique,
+		hostSyslogOptionRotate,
+		hostSyslogOptionSize,
+	})
+	if err != nil {
+		return err
+	}
+
+	attrs := map[string]interface{}{
+		"host_system_id": d.Id(),
+		"log_hosts":      flattenHostSyslogLogHosts(opts[hostSyslogOptionLogHost]),
+	}
+	if v, ok := opts[hostSyslogOptionLogDir].(string); ok {
+		attrs["log_dir"] = v
+	}
+	if v, ok := opts[hostSyslogOptionLogDirUnique].(bool); ok {
+		attrs["log_dir_unique"] = v
+	}
+	if v, ok := opts[hostSyslogOptionRotate]; ok {
+		if n, err := strconv.Atoi(fmt.Sprintf("%v", v)); err == nil {
+			attrs["rotate"] = n
+		}
+	}
+	if v, ok := opts[hostSyslogOptionSize]; ok {
+		if n, err := strconv.Atoi(fmt.Sprintf("%v", v)); err == nil {
+			attrs["rotate_size"] = n
+		}
+	}
+	if err := structure.SetBatch(d, attrs); err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] %s: Read completed successfully", resourceVSphereHostSyslogIDString(d))
+	return nil
+}
+
+func resourceVSphereHostSyslogUpdate(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] %s: Beginning update", resourceVSphereHostSyslogIDString(d))
+	if err := resourceVSphereHostSyslogApply(d, meta, d.Id()); err != nil {
+		return err
+	}
+	log.Printf("[DEBUG] %s: Update finished successfully", resourceVSphereHostSyslogIDString(d))
+	return resourceVSphereHostSyslogRead(d, meta)
+}
+
+func resourceVSphereHostSyslogDelete(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] %s: Beginning delete", resourceVSphereHostSyslogIDString(d))
+	client := meta.(*VSphereClient).vimClient
+	m, err := hostOptionManagerFromHostSystemID(client, d.Id())
+	if err != nil {
+		return fmt.Errorf("error loading host option manager: %s", err)
+	}
+	// Only log forwarding is removed. Local log settings are left as-is, as the
+	// host always needs somewhere to write logs to.
+	if err := updateHostAdvancedOptions(m, map[string]interface{}{hostSyslogOptionLogHost: ""}); err != nil {
+		return fmt.Errorf("error removing remote syslog hosts: %s", err)
+	}
+	log.Printf("[DEBUG] %s: Delete finished successfully", resourceVSphereHostSyslogIDString(d))
+	return nil
+}
+
+func resourceVSphereHostSyslogImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client := meta.(*VSphereClient).vimClient
+	if _, err := hostOptionManagerFromHostSystemID(client, d.Id()); err != nil {
+		return nil, fmt.Errorf("error loading host option manager: %s", err)
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// resourceVSphereHostSyslogApply sends the syslog settings in the
+// ResourceData to the host.
+func resourceVSphereHostSyslogApply(d *schema.ResourceData, meta interface{}, hsID string) error {
+	client := meta.(*VSphereClient).vimClient
+	m, err := hostOptionManagerFromHostSystemID(client, hsID)
+	if err != nil {
+		return fmt.Errorf("error loading host option manager: %s", err)
+	}
+	values := map[string]interface{}{
+		hostSyslogOptionLogHost: strings.Join(structure.SliceInterfacesToStrings(d.Get("log_hosts").([]interface{})), ","),
+	}
+	if v, ok := d.GetOk("log_dir"); ok {
+		values[hostSyslogOptionLogDir] = v.(string)
+	}
+	if v, ok := d.GetOkExists("log_dir_unique"); ok {
+		values[hostSyslogOptionLogDirUnique] = v.(bool)
+	}
+	if v, ok := d.GetOk("rotate"); ok {
+		values[hostSyslogOptionRotate] = v.(int)
+	}
+	if v, ok := d.GetOk("rotate_size"); ok {
+		values[hostSyslogOptionSize] = v.(int)
+	}
+	log.Printf("[DEBUG] %s: Updating syslog settings", resourceVSphereHostSyslogIDString(d))
+	if err := updateHostAdvancedOptions(m, values); err != nil {
+		return fmt.Errorf("error updating syslog settings: %s", err)
+	}
+	return nil
+}
+
+// flattenHostSyslogLogHosts splits the comma-separated value of the logHost
+// advanced option into a list.
+func flattenHostSyslogLogHosts(v interface{}) []string {
+	var hosts []string
+	s, _ := v.(string)
+	for _, h := range strings.Split(s, ",") {
+		if h = strings.TrimSpace(h); h != "" {
+			hosts = append(hosts, h)
+		}
+	}
+	return hosts
+}
+
+// resourceVSphereHostSyslogIDString prints a friendly string for the
+// vsphere_host_syslog resource.
+func resourceVSphereHostSyslogIDString(d structure.ResourceIDStringer) string {
+	return structure.ResourceIDString(d, resourceVSphereHostSyslogName)
+}
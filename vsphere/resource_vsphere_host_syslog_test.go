@@ -0,0 +1,110 @@
+package vsphere
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccResourceVSphereHostSyslog_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccResourceVSphereHostSyslogPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceVSphereHostSyslogConfig("udp://10.0.0.10:514", 8),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereHostSyslogLogHost("udp://10.0.0.10:514"),
+					resource.TestCheckResourceAttr("vsphere_host_syslog.syslog", "rotate", "8"),
+				),
+			},
+			{
+				Config: testAccResourceVSphereHostSyslogConfig("tcp://10.0.0.20:514", 10),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereHostSyslogLogHost("tcp://10.0.0.20:514"),
+					resource.TestCheckResourceAttr("vsphere_host_syslog.syslog", "rotate", "10"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceVSphereHostSyslog_import(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccResourceVSphereHostSyslogPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceVSphereHostSyslogConfig("udp://10.0.0.10:514", 8),
+			},
+			{
+				ResourceName:      "vsphere_host_syslog.syslog",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccResourceVSphereHostSyslogPreCheck(t *testing.T) {
+	if os.Getenv("VSPHERE_DATACENTER") == "" {
+		t.Skip("set VSPHERE_DATACENTER to run vsphere_host_syslog acceptance tests")
+	}
+	if os.Getenv("VSPHERE_ESXI_HOST") == "" {
+		t.Skip("set VSPHERE_ESXI_HOST to run vsphere_host_syslog acceptance tests")
+	}
+}
+
+func testAccResourceVSphereHostSyslogLogHost(expected string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		vars, err := testClientVariablesForResource(s, "vsphere_host_syslog.syslog")
+		if err != nil {
+			return err
+		}
+		m, err := hostOptionManagerFromHostSystemID(vars.client, vars.resourceID)
+		if err != nil {
+			return err
+		}
+		opts, err := hostAdvancedOptions(m, []string{hostSyslogOptionLogHost})
+		if err != nil {
+			return err
+		}
+		if actual := opts[hostSyslogOptionLogHost]; actual != expected {
+			return fmt.Errorf("expected %s to be %q, got %q", hostSyslogOptionLogHost, expected, actual)
+		}
+		return nil
+	}
+}
+
+func testAccResourceVSphereHostSyslogConfig(logHost string, rotate int) string {
+	return fmt.Sprintf(`
+data "vsphere_datacenter" "datacenter" {
+  name = "%s"
+}
+
+data "vsphere_host" "esxi_host" {
+  name          = "%s"
+  datacenter_id = "${data.vsphere_datacenter.datacenter.id}"
+}
+
+resource "vsphere_host_syslog" "syslog" {
+  host_system_id = "${data.vsphere_host.esxi_host.id}"
+  log_hosts      = ["%s"]
+  rotate         = %d
+}
+`,
+		os.Getenv("VSPHERE_DATACENTER"),
+		os.Getenv("VSPHERE_ESXI_HOST"),
+		logHost,
+		rotate,
+	)
+}
@@ -0,0 +1,194 @@
+package vsphere
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/structure"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+const resourceVSphereRoleName = "vsphere_role"
+
+// authorizationRoleDefaultPrivileges are the privileges that vSphere adds to
+// every role. They are ignored when reading a role, unless they have been
+// explicitly configured, to avoid a perpetual diff.
+var authorizationRoleDefaultPrivileges = []string{
+	"System.Anonymous",
+	"System.Read",
+	"System.View",
+}
+
+func resourceVSphereRole() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceVSphereRoleCreate,
+		Read:   resourceVSphereRoleRead,
+		Update: resourceVSphereRoleUpdate,
+		Delete: resourceVSphereRoleDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceVSphereRoleImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the role.",
+			},
+			"role_privileges": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "The privileges to grant with the role, such as VirtualMachine.Interact.PowerOn.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"label": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The display label of the role.",
+			},
+		},
+	}
+}
+
+func resourceVSphereRoleCreate(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] %s: Beginning create", resourceVSphereRoleIDString(d))
+	client := meta.(*VSphereClient).vimClient
+	name := d.Get("name").(string)
+	privileges := structure.SliceInterfacesToStrings(d.Get("role_privileges").(*schema.Set).List())
+	if err := validateAuthorizationPrivileges(client, privileges); err != nil {
+		return err
+	}
+	id, err := addAuthorizationRole(client, name, privileges)
+	if err != nil {
+		return fmt.Errorf("error creating role %q: %s", name, err)
+	}
+	d.SetId(strconv.Itoa(int(id)))
+	log.Printf("[DEBUG] %s: Create finished successfully", resourceVSphereRoleIDString(d))
+	return resourceVSphereRoleRead(d, meta)
+}
+
+func resourceVSphereRoleRead(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] %s: Beginning read", resourceVSphereRoleIDString(d))
+	client := meta.(*VSphereClient).vimClient
+	id, err := resourceVSphereRoleParseID(d.Id())
+	if err != nil {
+		return err
+	}
+	role, err := authorizationRoleFromID(client, id)
+	if err != nil {
+		if isAuthorizationRoleNotFoundError(err) {
+			log.Printf("[DEBUG] %s: %s, marking resource as gone", resourceVSphereRoleIDString(d), err)
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+	if err := flattenAuthorizationRole(d, role); err != nil {
+		return err
+	}
+	log.Printf("[DEBUG] %s: Read completed successfully", resourceVSphereRoleIDString(d))
+	return nil
+}
+
+func resourceVSphereRoleUpdate(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] %s: Beginning update", resourceVSphereRoleIDString(d))
+	client := meta.(*VSphereClient).vimClient
+	id, err := resourceVSphereRoleParseID(d.Id())
+	if err != nil {
+		return err
+	}
+	privileges := structure.SliceInterfacesToStrings(d.Get("role_privileges").(*schema.Set).List())
+	if err := validateAuthorizationPrivileges(client, privileges); err != nil {
+		return err
+	}
+	if err := updateAuthorizationRole(client, id, d.Get("name").(string), privileges); err != nil {
+		return fmt.Errorf("error updating role: %s", err)
+	}
+	log.Printf("[DEBUG] %s: Update finished successfully", resourceVSphereRoleIDString(d))
+	return resourceVSphereRoleRead(d, meta)
+}
+
+func resourceVSphereRoleDelete(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] %s: Beginning delete", resourceVSphereRoleIDString(d))
+	client := meta.(*VSphereClient).vimClient
+	id, err := resourceVSphereRoleParseID(d.Id())
+	if err != nil {
+		return err
+	}
+	if err := removeAuthorizationRole(client, id); err != nil {
+		return fmt.Errorf("error removing role: %s", err)
+	}
+	log.Printf("[DEBUG] %s: Delete finished successfully", resourceVSphereRoleIDString(d))
+	return nil
+}
+
+func resourceVSphereRoleImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client := meta.(*VSphereClient).vimClient
+	// Roles can be imported either by ID or by name.
+	var role *types.AuthorizationRole
+	if id, err := resourceVSphereRoleParseID(d.Id()); err == nil {
+		role, err = authorizationRoleFromID(client, id)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		role, err = authorizationRoleFromName(client, d.Id())
+		if err != nil {
+			return nil, err
+		}
+	}
+	if role.System {
+		return nil, fmt.Errorf("role %q is a system role and cannot be managed", role.Name)
+	}
+	d.SetId(strconv.Itoa(int(role.RoleId)))
+	return []*schema.ResourceData{d}, nil
+}
+
+// flattenAuthorizationRole reads an AuthorizationRole into the passed in
+// ResourceData.
+func flattenAuthorizationRole(d *schema.ResourceData, role *types.AuthorizationRole) error {
+	configured := make(map[string]bool)
+	if v, ok := d.GetOk("role_privileges"); ok {
+		for _, p := range v.(*schema.Set).List() {
+			configured[p.(string)] = true
+		}
+	}
+	defaults := make(map[string]bool)
+	for _, p := range authorizationRoleDefaultPrivileges {
+		defaults[p] = true
+	}
+	var privileges []string
+	for _, p := range role.Privilege {
+		if defaults[p] && !configured[p] {
+			continue
+		}
+		privileges = append(privileges, p)
+	}
+	var label string
+	if role.Info != nil {
+		label = role.Info.GetDescription().Label
+	}
+	return structure.SetBatch(d, map[string]interface{}{
+		"name":            role.Name,
+		"role_privileges": privileges,
+		"label":           label,
+	})
+}
+
+// resourceVSphereRoleParseID parses the ID of a vsphere_role, which is the
+// numeric role ID.
+func resourceVSphereRoleParseID(id string) (int32, error) {
+	n, err := strconv.ParseInt(id, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("bad role ID %q: %s", id, err)
+	}
+	return int32(n), nil
+}
+
+// resourceVSphereRoleIDString prints a friendly string for the vsphere_role
+// resource.
+func resourceVSphereRoleIDString(d structure.ResourceIDStringer) string {
+	return structure.ResourceIDString(d, resourceVSphereRoleName)
+}
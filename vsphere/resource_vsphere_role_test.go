@@ -0,0 +1,134 @@
+package vsphere
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+const testAccResourceVSphereRoleName = "terraform-test-role"
+
+func TestAccResourceVSphereRole_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccResourceVSphereRoleExists(false),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceVSphereRoleConfig(testAccResourceVSphereRoleName, []string{"VirtualMachine.Interact.PowerOn"}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereRoleExists(true),
+					resource.TestCheckResourceAttr("vsphere_role.role", "role_privileges.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceVSphereRole_update(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccResourceVSphereRoleExists(false),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceVSphereRoleConfig(testAccResourceVSphereRoleName, []string{"VirtualMachine.Interact.PowerOn"}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereRoleExists(true),
+				),
+			},
+			{
+				Config: testAccResourceVSphereRoleConfig(
+					testAccResourceVSphereRoleName+"-renamed",
+					[]string{"VirtualMachine.Interact.PowerOn", "VirtualMachine.Interact.PowerOff"},
+				),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vsphere_role.role", "name", testAccResourceVSphereRoleName+"-renamed"),
+					resource.TestCheckResourceAttr("vsphere_role.role", "role_privileges.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceVSphereRole_badPrivilege(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccResourceVSphereRoleExists(false),
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccResourceVSphereRoleConfig(testAccResourceVSphereRoleName, []string{"VirtualMachine.Bogus"}),
+				ExpectError: regexp.MustCompile("unknown privileges: VirtualMachine.Bogus"),
+			},
+		},
+	})
+}
+
+func TestAccResourceVSphereRole_import(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccResourceVSphereRoleExists(false),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceVSphereRoleConfig(testAccResourceVSphereRoleName, []string{"VirtualMachine.Interact.PowerOn"}),
+			},
+			{
+				ResourceName:      "vsphere_role.role",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      "vsphere_role.role",
+				ImportState:       true,
+				ImportStateId:     testAccResourceVSphereRoleName,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccResourceVSphereRoleExists(expected bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*VSphereClient).vimClient
+		for _, name := range []string{testAccResourceVSphereRoleName, testAccResourceVSphereRoleName + "-renamed"} {
+			_, err := authorizationRoleFromName(client, name)
+			switch {
+			case err == nil && !expected:
+				return fmt.Errorf("role %q still exists", name)
+			case err == nil && expected:
+				return nil
+			}
+		}
+		if expected {
+			return errors.New("role not found")
+		}
+		return nil
+	}
+}
+
+func testAccResourceVSphereRoleConfig(name string, privileges []string) string {
+	return fmt.Sprintf(`
+resource "vsphere_role" "role" {
+  name            = "%s"
+  role_privileges = ["%s"]
+}
+`,
+		name,
+		strings.Join(privileges, `", "`),
+	)
+}
@@ -0,0 +1,541 @@
+package vsphere
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/structure"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/viapi"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/virtualmachine"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+const resourceVSphereScheduledTaskName = "vsphere_scheduled_task"
+
+const (
+	scheduledTaskScheduleOnce   = "once"
+	scheduledTaskScheduleHourly = "hourly"
+	scheduledTaskScheduleDaily  = "daily"
+	scheduledTaskScheduleWeekly = "weekly"
+)
+
+var scheduledTaskScheduleAllowedValues = []string{
+	scheduledTaskScheduleOnce,
+	scheduledTaskScheduleHourly,
+	scheduledTaskScheduleDaily,
+	scheduledTaskScheduleWeekly,
+}
+
+const (
+	scheduledTaskActionPowerOn        = "powerOn"
+	scheduledTaskActionPowerOff       = "powerOff"
+	scheduledTaskActionShutdownGuest  = "shutdownGuest"
+	scheduledTaskActionReset          = "reset"
+	scheduledTaskActionCreateSnapshot = "createSnapshot"
+	scheduledTaskActionReconfigure    = "reconfigure"
+)
+
+var scheduledTaskActionAllowedValues = []string{
+	scheduledTaskActionPowerOn,
+	scheduledTaskActionPowerOff,
+	scheduledTaskActionShutdownGuest,
+	scheduledTaskActionReset,
+	scheduledTaskActionCreateSnapshot,
+	scheduledTaskActionReconfigure,
+}
+
+// scheduledTaskActionMethods maps the actions of the vsphere_scheduled_task
+// resource to the VirtualMachine methods that implement them.
+var scheduledTaskActionMethods = map[string]string{
+	scheduledTaskActionPowerOn:        "PowerOnVM_Task",
+	scheduledTaskActionPowerOff:       "PowerOffVM_Task",
+	scheduledTaskActionShutdownGuest:  "ShutdownGuest",
+	scheduledTaskActionReset:          "ResetVM_Task",
+	scheduledTaskActionCreateSnapshot: "CreateSnapshot_Task",
+	scheduledTaskActionReconfigure:    "ReconfigVM_Task",
+}
+
+var scheduledTaskWeekdayAllowedValues = []string{
+	"sunday",
+	"monday",
+	"tuesday",
+	"wednesday",
+	"thursday",
+	"friday",
+	"saturday",
+}
+
+func resourceVSphereScheduledTask() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceVSphereScheduledTaskCreate,
+		Read:   resourceVSphereScheduledTaskRead,
+		Update: resourceVSphereScheduledTaskUpdate,
+		Delete: resourceVSphereScheduledTaskDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceVSphereScheduledTaskImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"virtual_machine_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The managed object ID of the virtual machine to run the task on.",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the scheduled task.",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The description of the scheduled task.",
+			},
+			"enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether or not the scheduled task is enabled.",
+			},
+			"notification": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The email address to notify when the task completes.",
+			},
+			"schedule": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "How often the task runs. Can be one of once, hourly, daily, or weekly.",
+				ValidateFunc: validation.StringInSlice(scheduledTaskScheduleAllowedValues, false),
+			},
+			"run_at": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				Description:      "The time to run a once task at, in RFC3339 format. If not set, the task runs as soon as it is created.",
+				ValidateFunc:     validation.ValidateRFC3339TimeString,
+				DiffSuppressFunc: resourceVSphereScheduledTaskTimeDiffSuppress,
+			},
+			"interval": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1,
+				Description:  "The number of hours, days, or weeks between runs of a recurring task.",
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"minute": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "The minute of the hour to run a recurring task at.",
+				ValidateFunc: validation.IntBetween(0, 59),
+			},
+			"hour": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "The hour of the day, in UTC, to run a daily or weekly task at.",
+				ValidateFunc: validation.IntBetween(0, 23),
+			},
+			"days": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "The days of the week to run a weekly task on.",
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice(scheduledTaskWeekdayAllowedValues, false),
+				},
+			},
+			"active_time": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Description:      "The time, in RFC3339 format, a recurring task becomes active.",
+				ValidateFunc:     validation.ValidateRFC3339TimeString,
+				DiffSuppressFunc: resourceVSphereScheduledTaskTimeDiffSuppress,
+			},
+			"expire_time": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Description:      "The time, in RFC3339 format, a recurring task stops running.",
+				ValidateFunc:     validation.ValidateRFC3339TimeString,
+				DiffSuppressFunc: resourceVSphereScheduledTaskTimeDiffSuppress,
+			},
+			"action": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The operation to run. Can be one of powerOn, powerOff, shutdownGuest, reset, createSnapshot, or reconfigure.",
+				ValidateFunc: validation.StringInSlice(scheduledTaskActionAllowedValues, false),
+			},
+			"snapshot_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The name of the snapshot to create. Required for the createSnapshot action.",
+			},
+			"snapshot_description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The description of the snapshot to create.",
+			},
+			"snapshot_memory": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Include the memory of the virtual machine in the snapshot.",
+			},
+			"snapshot_quiesce": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Quiesce the file system of the virtual machine before taking the snapshot.",
+			},
+			"num_cpus": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "The number of virtual processors to set with the reconfigure action.",
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"memory": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "The amount of memory, in MB, to set with the reconfigure action.",
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"next_run_time": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The time the task runs next, in RFC3339 format.",
+			},
+			"last_run_time": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The time the task last ran, in RFC3339 format.",
+			},
+			"last_result": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The state of the last run of the task. One of queued, running, success, or error.",
+			},
+			"last_error": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The error message of the last run of the task, if it failed.",
+			},
+		},
+	}
+}
+
+func resourceVSphereScheduledTaskCreate(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] %s: Beginning create", resourceVSphereScheduledTaskIDString(d))
+	client := meta.(*VSphereClient).vimClient
+	if err := viapi.ValidateVirtualCenter(client); err != nil {
+		return err
+	}
+	spec, err := expandScheduledTaskSpec(d)
+	if err != nil {
+		return err
+	}
+	vm, err := virtualmachine.FromMOID(client, d.Get("virtual_machine_id").(string))
+	if err != nil {
+		return fmt.Errorf("cannot locate virtual machine: %s", err)
+	}
+	task, err := createScheduledTask(client, vm.Reference(), spec)
+	if err != nil {
+		return fmt.Errorf("error creating scheduled task %q: %s", spec.Name, err)
+	}
+	d.SetId(task.Value)
+	log.Printf("[DEBUG] %s: Create finished successfully", resourceVSphereScheduledTaskIDString(d))
+	return resourceVSphereScheduledTaskRead(d, meta)
+}
+
+func resourceVSphereScheduledTaskRead(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] %s: Beginning read", resourceVSphereScheduledTaskIDString(d))
+	client := meta.(*VSphereClient).vimClient
+	props, err := scheduledTaskProperties(client, scheduledTaskFromID(d.Id()))
+	if err != nil {
+		if viapi.IsManagedObjectNotFoundError(err) {
+			log.Printf("[DEBUG] %s: Scheduled task not found, marking resource as gone", resourceVSphereScheduledTaskIDString(d))
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("error fetching scheduled task properties: %s", err)
+	}
+	if err := flattenScheduledTaskInfo(d, &props.Info); err != nil {
+		return err
+	}
+	log.Printf("[DEBUG] %s: Read completed successfully", resourceVSphereScheduledTaskIDString(d))
+	return nil
+}
+
+func resourceVSphereScheduledTaskUpdate(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] %s: Beginning update", resourceVSphereScheduledTaskIDString(d))
+	client := meta.(*VSphereClient).vimClient
+	spec, err := expandScheduledTaskSpec(d)
+	if err != nil {
+		return err
+	}
+	if err := reconfigureScheduledTask(client, scheduledTaskFromID(d.Id()), spec); err != nil {
+		return fmt.Errorf("error reconfiguring scheduled task: %s", err)
+	}
+	log.Printf("[DEBUG] %s: Update finished successfully", resourceVSphereScheduledTaskIDString(d))
+	return resourceVSphereScheduledTaskRead(d, meta)
+}
+
+func resourceVSphereScheduledTaskDelete(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] %s: Beginning delete", resourceVSphereScheduledTaskIDString(d))
+	client := meta.(*VSphereClient).vimClient
+	if err := removeScheduledTask(client, scheduledTaskFromID(d.Id())); err != nil {
+		return fmt.Errorf("error removing scheduled task: %s", err)
+	}
+	log.Printf("[DEBUG] %s: Delete finished successfully", resourceVSphereScheduledTaskIDString(d))
+	return nil
+}
+
+func resourceVSphereScheduledTaskImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client := meta.(*VSphereClient).vimClient
+	if err := viapi.ValidateVirtualCenter(client); err != nil {
+		return nil, err
+	}
+	props, err := scheduledTaskProperties(client, scheduledTaskFromID(d.Id()))
+	if err != nil {
+		return nil, fmt.Errorf("error fetching scheduled task properties: %s", err)
+	}
+	if props.Info.Entity.Type != "VirtualMachine" {
+		return nil, fmt.Errorf("scheduled task %q targets a %s, only virtual machine tasks are supported", d.Id(), props.Info.Entity.Type)
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// expandScheduledTaskSpec reads certain ResourceData keys and returns a
+// ScheduledTaskSpec.
+func expandScheduledTaskSpec(d *schema.ResourceData) (types.ScheduledTaskSpec, error) {
+	spec := types.ScheduledTaskSpec{
+		Name:         d.Get("name").(string),
+		Description:  d.Get("description").(string),
+		Enabled:      d.Get("enabled").(bool),
+		Notification: d.Get("notification").(string),
+	}
+	scheduler, err := expandScheduledTaskScheduler(d)
+	if err != nil {
+		return spec, err
+	}
+	spec.Scheduler = scheduler
+	action, err := expandScheduledTaskAction(d)
+	if err != nil {
+		return spec, err
+	}
+	spec.Action = action
+	return spec, nil
+}
+
+// expandScheduledTaskScheduler reads the schedule attributes and returns the
+// matching TaskScheduler.
+func expandScheduledTaskScheduler(d *schema.ResourceData) (types.BaseTaskScheduler, error) {
+	base := types.TaskScheduler{
+		ActiveTime: structure.ParseTimePtr(d.Get("active_time").(string)),
+		ExpireTime: structure.ParseTimePtr(d.Get("expire_time").(string)),
+	}
+	hourly := types.HourlyTaskScheduler{
+		RecurrentTaskScheduler: types.RecurrentTaskScheduler{
+			TaskScheduler: base,
+			Interval:      int32(d.Get("interval").(int)),
+		},
+		Minute: int32(d.Get("minute").(int)),
+	}
+	daily := types.DailyTaskScheduler{
+		HourlyTaskScheduler: hourly,
+		Hour:                int32(d.Get("hour").(int)),
+	}
+
+	switch d.Get("schedule").(string) {
+	case scheduledTaskScheduleOnce:
+		return &types.OnceTaskScheduler{
+			TaskScheduler: base,
+			RunAt:         structure.ParseTimePtr(d.Get("run_at").(string)),
+		}, nil
+	case scheduledTaskScheduleHourly:
+		return &hourly, nil
+	case scheduledTaskScheduleDaily:
+		return &daily, nil
+	}
+
+	days := make(map[string]bool)
+	for _, v := range d.Get("days").(*schema.Set).List() {
+		days[v.(string)] = true
+	}
+	if len(days) < 1 {
+		return nil, errors.New("days must be specified for weekly tasks")
+	}
+	return &types.WeeklyTaskScheduler{
+		DailyTaskScheduler: daily,
+		Sunday:             days["sunday"],
+		Monday:             days["monday"],
+		Tuesday:            days["tuesday"],
+		Wednesday:          days["wednesday"],
+		Thursday:           days["thursday"],
+		Friday:             days["friday"],
+		Saturday:           days["saturday"],
+	}, nil
+}
+
+// expandScheduledTaskAction reads the action attributes and returns the
+// matching MethodAction.
+func expandScheduledTaskAction(d *schema.ResourceData) (*types.MethodAction, error) {
+	action := d.Get("action").(string)
+	ma := &types.MethodAction{
+		Name: scheduledTaskActionMethods[action],
+	}
+	switch action {
+	case scheduledTaskActionCreateSnapshot:
+		name := d.Get("snapshot_name").(string)
+		if name == "" {
+			return nil, errors.New("snapshot_name must be specified for the createSnapshot action")
+		}
+		ma.Argument = []types.MethodActionArgument{
+			{Value: name},
+			{Value: d.Get("snapshot_description").(string)},
+			{Value: d.Get("snapshot_memory").(bool)},
+			{Value: d.Get("snapshot_quiesce").(bool)},
+		}
+	case scheduledTaskActionReconfigure:
+		spec := types.VirtualMachineConfigSpec{
+			NumCPUs:  int32(d.Get("num_cpus").(int)),
+			MemoryMB: int64(d.Get("memory").(int)),
+		}
+		if spec.NumCPUs == 0 && spec.MemoryMB == 0 {
+			return nil, errors.New("one of num_cpus or memory must be specified for the reconfigure action")
+		}
+		ma.Argument = []types.MethodActionArgument{
+			{Value: spec},
+		}
+	}
+	return ma, nil
+}
+
+// flattenScheduledTaskInfo reads a ScheduledTaskInfo into the passed in
+// ResourceData.
+func flattenScheduledTaskInfo(d *schema.ResourceData, info *types.ScheduledTaskInfo) error {
+	attrs := map[string]interface{}{
+		"virtual_machine_id": info.Entity.Value,
+		"name":               info.Name,
+		"description":        info.Description,
+		"enabled":            info.Enabled,
+		"notification":       info.Notification,
+		"next_run_time":      resourceVSphereScheduledTaskFormatTime(info.NextRunTime),
+		"last_run_time":      resourceVSphereScheduledTaskFormatTime(info.PrevRunTime),
+		"last_result":        string(info.State),
+		"last_error":         "",
+	}
+	if info.Error != nil {
+		attrs["last_error"] = info.Error.LocalizedMessage
+	}
+
+	var base *types.TaskScheduler
+	switch s := info.Scheduler.(type) {
+	case *types.OnceTaskScheduler:
+		attrs["schedule"] = scheduledTaskScheduleOnce
+		attrs["run_at"] = resourceVSphereScheduledTaskFormatTime(s.RunAt)
+		base = &s.TaskScheduler
+	case *types.HourlyTaskScheduler:
+		attrs["schedule"] = scheduledTaskScheduleHourly
+		attrs["interval"] = int(s.Interval)
+		attrs["minute"] = int(s.Minute)
+		base = &s.TaskScheduler
+	case *types.DailyTaskScheduler:
+		attrs["schedule"] = scheduledTaskScheduleDaily
+		attrs["interval"] = int(s.Interval)
+		attrs["minute"] = int(s.Minute)
+		attrs["hour"] = int(s.Hour)
+		base = &s.TaskScheduler
+	case *types.WeeklyTaskScheduler:
+		attrs["schedule"] = scheduledTaskScheduleWeekly
+		attrs["interval"] = int(s.Interval)
+		attrs["minute"] = int(s.Minute)
+		attrs["hour"] = int(s.Hour)
+		var days []string
+		for i, on := range []bool{s.Sunday, s.Monday, s.Tuesday, s.Wednesday, s.Thursday, s.Friday, s.Saturday} {
+			if on {
+				days = append(days, scheduledTaskWeekdayAllowedValues[i])
+			}
+		}
+		attrs["days"] = days
+		base = &s.TaskScheduler
+	default:
+		log.Printf("[DEBUG] %s: Unsupported scheduler type %T", resourceVSphereScheduledTaskIDString(d), info.Scheduler)
+	}
+	if base != nil {
+		attrs["active_time"] = resourceVSphereScheduledTaskFormatTime(base.ActiveTime)
+		attrs["expire_time"] = resourceVSphereScheduledTaskFormatTime(base.ExpireTime)
+	}
+
+	if ma, ok := info.Action.(*types.MethodAction); ok {
+		for action, method := range scheduledTaskActionMethods {
+			if method == ma.Name {
+				attrs["action"] = action
+			}
+		}
+		flattenScheduledTaskActionArguments(attrs, ma)
+	} else {
+		log.Printf("[DEBUG] %s: Unsupported action type %T", resourceVSphereScheduledTaskIDString(d), info.Action)
+	}
+
+	return structure.SetBatch(d, attrs)
+}
+
+// flattenScheduledTaskActionArguments reads the arguments of a MethodAction
+// into the attribute map for the action.
+func flattenScheduledTaskActionArguments(attrs map[string]interface{}, ma *types.MethodAction) {
+	switch ma.Name {
+	case scheduledTaskActionMethods[scheduledTaskActionCreateSnapshot]:
+		keys := []string{"snapshot_name", "snapshot_description", "snapshot_memory", "snapshot_quiesce"}
+		for i, arg := range ma.Argument {
+			if i < len(keys) && arg.Value != nil {
+				attrs[keys[i]] = arg.Value
+			}
+		}
+	case scheduledTaskActionMethods[scheduledTaskActionReconfigure]:
+		if len(ma.Argument) < 1 {
+			return
+		}
+		var spec *types.VirtualMachineConfigSpec
+		switch v := ma.Argument[0].Value.(type) {
+		case types.VirtualMachineConfigSpec:
+			spec = &v
+		case *types.VirtualMachineConfigSpec:
+			spec = v
+		}
+		if spec != nil {
+			attrs["num_cpus"] = int(spec.NumCPUs)
+			attrs["memory"] = int(spec.MemoryMB)
+		}
+	}
+}
+
+// resourceVSphereScheduledTaskFormatTime formats a time in RFC3339 format,
+// in UTC. A nil time returns an empty string.
+func resourceVSphereScheduledTaskFormatTime(t *time.Time) string {
+	if t == nil || t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// resourceVSphereScheduledTaskTimeDiffSuppress suppresses diffs between two
+// RFC3339 time strings that refer to the same point in time, as vCenter
+// returns all times in UTC.
+func resourceVSphereScheduledTaskTimeDiffSuppress(k, old, new string, d *schema.ResourceData) bool {
+	o := structure.ParseTimePtr(old)
+	n := structure.ParseTimePtr(new)
+	if o == nil || n == nil {
+		return old == new
+	}
+	return o.Equal(*n)
+}
+
+// resourceVSphereScheduledTaskIDString prints a friendly string for the
+// vsphere_scheduled_task resource.
+func resourceVSphereScheduledTaskIDString(d structure.ResourceIDStringer) string {
+	return structure.ResourceIDString(d, resourceVSphereScheduledTaskName)
+}
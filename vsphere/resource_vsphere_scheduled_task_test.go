@@ -0,0 +1,205 @@
+package vsphere
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/viapi"
+)
+
+func TestAccResourceVSphereScheduledTask_daily(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccResourceVSphereScheduledTaskPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccResourceVSphereScheduledTaskExists(false),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceVSphereScheduledTaskConfigDaily(2),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereScheduledTaskExists(true),
+					resource.TestCheckResourceAttr("vsphere_scheduled_task.task", "schedule", "daily"),
+					resource.TestCheckResourceAttr("vsphere_scheduled_task.task", "action", "createSnapshot"),
+					resource.TestCheckResourceAttrSet("vsphere_scheduled_task.task", "next_run_time"),
+				),
+			},
+			{
+				Config: testAccResourceVSphereScheduledTaskConfigDaily(3),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vsphere_scheduled_task.task", "hour", "3"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceVSphereScheduledTask_weekly(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccResourceVSphereScheduledTaskPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccResourceVSphereScheduledTaskExists(false),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceVSphereScheduledTaskConfigWeekly(),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereScheduledTaskExists(true),
+					resource.TestCheckResourceAttr("vsphere_scheduled_task.task", "days.#", "2"),
+					resource.TestCheckResourceAttr("vsphere_scheduled_task.task", "action", "powerOff"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceVSphereScheduledTask_import(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccResourceVSphereScheduledTaskPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccResourceVSphereScheduledTaskExists(false),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceVSphereScheduledTaskConfigDaily(2),
+			},
+			{
+				ResourceName:            "vsphere_scheduled_task.task",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"next_run_time", "last_run_time", "last_result"},
+			},
+		},
+	})
+}
+
+func testAccResourceVSphereScheduledTaskPreCheck(t *testing.T) {
+	if os.Getenv("VSPHERE_DATACENTER") == "" {
+		t.Skip("set VSPHERE_DATACENTER to run vsphere_scheduled_task acceptance tests")
+	}
+	if os.Getenv("VSPHERE_ESXI_HOST") == "" {
+		t.Skip("set VSPHERE_ESXI_HOST to run vsphere_scheduled_task acceptance tests")
+	}
+	if os.Getenv("VSPHERE_DATASTORE") == "" {
+		t.Skip("set VSPHERE_DATASTORE to run vsphere_scheduled_task acceptance tests")
+	}
+	if os.Getenv("VSPHERE_NETWORK_LABEL_PXE") == "" {
+		t.Skip("set VSPHERE_NETWORK_LABEL_PXE to run vsphere_scheduled_task acceptance tests")
+	}
+}
+
+func testAccResourceVSphereScheduledTaskExists(expected bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		vars, err := testClientVariablesForResource(s, "vsphere_scheduled_task.task")
+		if err != nil {
+			return err
+		}
+		_, err = scheduledTaskProperties(vars.client, scheduledTaskFromID(vars.resourceID))
+		switch {
+		case err != nil && viapi.IsManagedObjectNotFoundError(err) && !expected:
+			return nil
+		case err != nil && viapi.IsManagedObjectNotFoundError(err):
+			return errors.New("scheduled task not found")
+		case err != nil:
+			return err
+		case !expected:
+			return fmt.Errorf("scheduled task %q still exists", vars.resourceID)
+		}
+		return nil
+	}
+}
+
+func testAccResourceVSphereScheduledTaskConfigBase() string {
+	return fmt.Sprintf(`
+data "vsphere_datacenter" "datacenter" {
+  name = "%s"
+}
+
+data "vsphere_host" "esxi_host" {
+  name          = "%s"
+  datacenter_id = "${data.vsphere_datacenter.datacenter.id}"
+}
+
+data "vsphere_datastore" "datastore" {
+  name          = "%s"
+  datacenter_id = "${data.vsphere_datacenter.datacenter.id}"
+}
+
+data "vsphere_network" "network" {
+  name          = "%s"
+  datacenter_id = "${data.vsphere_datacenter.datacenter.id}"
+}
+
+resource "vsphere_virtual_machine" "vm" {
+  name             = "terraform-test-scheduled-task"
+  resource_pool_id = "${data.vsphere_host.esxi_host.resource_pool_id}"
+  host_system_id   = "${data.vsphere_host.esxi_host.id}"
+  datastore_id     = "${data.vsphere_datastore.datastore.id}"
+
+  num_cpus = 1
+  memory   = 512
+  guest_id = "other3xLinux64Guest"
+
+  wait_for_guest_net_timeout = -1
+
+  network_interface {
+    network_id = "${data.vsphere_network.network.id}"
+  }
+
+  disk {
+    label = "disk0"
+    size  = 1
+  }
+}
+`,
+		os.Getenv("VSPHERE_DATACENTER"),
+		os.Getenv("VSPHERE_ESXI_HOST"),
+		os.Getenv("VSPHERE_DATASTORE"),
+		os.Getenv("VSPHERE_NETWORK_LABEL_PXE"),
+	)
+}
+
+func testAccResourceVSphereScheduledTaskConfigDaily(hour int) string {
+	return fmt.Sprintf(`
+%s
+
+resource "vsphere_scheduled_task" "task" {
+  virtual_machine_id = "${vsphere_virtual_machine.vm.moid}"
+  name               = "terraform-test-nightly-snapshot"
+  schedule           = "daily"
+  hour               = %d
+  minute             = 30
+  action             = "createSnapshot"
+  snapshot_name      = "nightly"
+}
+`,
+		testAccResourceVSphereScheduledTaskConfigBase(),
+		hour,
+	)
+}
+
+func testAccResourceVSphereScheduledTaskConfigWeekly() string {
+	return fmt.Sprintf(`
+%s
+
+resource "vsphere_scheduled_task" "task" {
+  virtual_machine_id = "${vsphere_virtual_machine.vm.moid}"
+  name               = "terraform-test-weekend-power-off"
+  schedule           = "weekly"
+  days               = ["saturday", "sunday"]
+  hour               = 22
+  action             = "powerOff"
+}
+`,
+		testAccResourceVSphereScheduledTaskConfigBase(),
+	)
+}
@@ -0,0 +1,87 @@
+package vsphere
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/vmware/govmomi"
+	"github.com/vmware/govmomi/property"
+	"github.com/vmware/govmomi/vim25/methods"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+// scheduledTaskManagerReference returns the reference to the
+// ScheduledTaskManager of the connected vCenter. govmomi does not ship a
+// higher-level object for this manager.
+func scheduledTaskManagerReference(client *govmomi.Client) (types.ManagedObjectReference, error) {
+	if client.ServiceContent.ScheduledTaskManager == nil {
+		return types.ManagedObjectReference{}, fmt.Errorf("scheduled task manager is not available on this connection")
+	}
+	return *client.ServiceContent.ScheduledTaskManager, nil
+}
+
+// scheduledTaskFromID returns a reference to the scheduled task with the
+// supplied managed object ID.
+func scheduledTaskFromID(id string) types.ManagedObjectReference {
+	return types.ManagedObjectReference{
+		Type:  "ScheduledTask",
+		Value: id,
+	}
+}
+
+// scheduledTaskProperties returns the properties of a scheduled task.
+func scheduledTaskProperties(client *govmomi.Client, task types.ManagedObjectReference) (*mo.ScheduledTask, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	var props mo.ScheduledTask
+	pc := property.DefaultCollector(client.Client)
+	if err := pc.RetrieveOne(ctx, task, []string{"info"}, &props); err != nil {
+		return nil, err
+	}
+	return &props, nil
+}
+
+// createScheduledTask creates a scheduled task on an entity and returns its
+// reference.
+func createScheduledTask(client *govmomi.Client, entity types.ManagedObjectReference, spec types.ScheduledTaskSpec) (types.ManagedObjectReference, error) {
+	ref, err := scheduledTaskManagerReference(client)
+	if err != nil {
+		return types.ManagedObjectReference{}, err
+	}
+	req := types.CreateScheduledTask{
+		This:   ref,
+		Entity: entity,
+		Spec:   &spec,
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	res, err := methods.CreateScheduledTask(ctx, client.Client, &req)
+	if err != nil {
+		return types.ManagedObjectReference{}, err
+	}
+	return res.Returnval, nil
+}
+
+// reconfigureScheduledTask replaces the definition of a scheduled task.
+func reconfigureScheduledTask(client *govmomi.Client, task types.ManagedObjectReference, spec types.ScheduledTaskSpec) error {
+	req := types.ReconfigureScheduledTask{
+		This: task,
+		Spec: &spec,
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	_, err := methods.ReconfigureScheduledTask(ctx, client.Client, &req)
+	return err
+}
+
+// removeScheduledTask removes a scheduled task.
+func removeScheduledTask(client *govmomi.Client, task types.ManagedObjectReference) error {
+	req := types.RemoveScheduledTask{
+		This: task,
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	_, err := methods.RemoveScheduledTask(ctx, client.Client, &req)
+	return err
+}